@@ -1,35 +1,136 @@
 package app
 
 import (
+	"context"
+	"fmt"
+	"time"
+
 	"yatter-backend-go/app/config"
 	"yatter-backend-go/app/dao"
+	"yatter-backend-go/app/export"
+	"yatter-backend-go/app/importer"
+	"yatter-backend-go/app/logger"
+	"yatter-backend-go/app/metrics"
+	"yatter-backend-go/app/ratelimit"
+	"yatter-backend-go/app/trends"
+	"yatter-backend-go/app/worker"
+
+	"go.uber.org/zap"
 )
 
 // Dependency manager for whole application
 type App struct {
-	Dao dao.Dao
+	Config  *config.Config
+	Dao     dao.Dao
+	Worker  *worker.Runner
+	Metrics *metrics.Metrics
+	Logger  *zap.Logger
+
+	// Rate limiter of write endpoints
+	RateLimiter *ratelimit.Limiter
+
+	// Throttle of recording IP addresses used by accounts
+	AccountIPThrottle ratelimit.Store
+
+	// Checks run by the readiness probe
+	HealthChecks []HealthCheck
 }
 
 // Create dependency manager
-func NewApp() (*App, error) {
-	// panic if lacking something
-	daoCfg := config.MySQLConfig()
+func NewApp(cfg *config.Config) (*App, error) {
+	l, err := logger.New(cfg.Log)
+	if err != nil {
+		return nil, err
+	}
 
-	dao, err := dao.New(daoCfg)
+	dao, err := dao.New(cfg.MySQL.DriverConfig())
 	if err != nil {
 		return nil, err
 	}
 
-	return &App{Dao: dao}, nil
+	app := &App{
+		Config:  cfg,
+		Dao:     dao,
+		Worker:  worker.New(l.Named("worker")),
+		Metrics: metrics.New(),
+		Logger:  l,
+	}
+	// Redis などの共有ストアに差し替える場合はここを変更する
+	store := ratelimit.NewMemoryStore()
+	app.RateLimiter = ratelimit.New(cfg.RateLimit, store)
+	app.Worker.Every("ratelimit.cleanup", time.Minute, store.Cleanup)
+
+	// 認証のたびに account_ip を書き込まないように、同じアカウントと IP の組は一定時間に 1 回だけ記録する
+	ipThrottle := ratelimit.NewMemoryStore()
+	app.AccountIPThrottle = ipThrottle
+	app.Worker.Every("account_ip.throttle_cleanup", time.Minute, ipThrottle.Cleanup)
+
+	app.Worker.Every("mute.delete_expired", time.Minute, func(ctx context.Context) error {
+		_, err := dao.Mute().DeleteExpired(ctx)
+		return err
+	})
+
+	app.Worker.Every("account.delete_scheduled", time.Minute, func(ctx context.Context) error {
+		n, err := dao.Account().DeleteScheduled(ctx)
+		if n > 0 {
+			l.Info("deleted scheduled accounts", zap.Int64("count", n))
+		}
+		return err
+	})
+
+	app.Worker.Every("poll.close_expired", time.Minute, func(ctx context.Context) error {
+		_, err := dao.Poll().CloseExpired(ctx)
+		return err
+	})
+
+	app.Worker.Every("scheduled_status.publish", 10*time.Second, func(ctx context.Context) error {
+		n, err := dao.ScheduledStatus().PublishDue(ctx)
+		app.Metrics.StatusesCreated.Add(float64(n))
+		return err
+	})
+
+	exporter := export.New(dao, cfg.Export, cfg.Server.PublicURL, l.Named("export"))
+	app.Worker.Every("export.build", 10*time.Second, exporter.BuildPending)
+	app.Worker.Every("export.cleanup", time.Hour, exporter.Cleanup)
+
+	imp := importer.New(dao, cfg.Server.PublicURL, l.Named("import"))
+	app.Worker.Every("import.process", 10*time.Second, imp.ProcessPending)
+
+	calculator := trends.New(dao, cfg.Trends, l.Named("trends"))
+	app.Worker.Every("trends.refresh", cfg.Trends.Interval, calculator.Refresh)
+
+	app.RegisterHealthCheck("mysql", dao.Ping)
+	app.Metrics.RegisterDB(cfg.MySQL.Database, dao.Stats)
+
+	return app, nil
 }
 
 // Create dependency manager for tests
 func NewTestApp() (*App, error) {
-	testCfg := config.MySQLTestConfig()
-	dao, err := dao.New(testCfg)
+	cfg, err := config.Load(nil)
 	if err != nil {
 		return nil, err
 	}
+	testCfg, err := cfg.MySQL.ForTest()
+	if err != nil {
+		return nil, err
+	}
+	cfg.MySQL = testCfg
+
+	return NewApp(cfg)
+}
 
-	return &App{Dao: dao}, nil
+// Stop background workers and release resources
+//
+// ワーカーが DB を使っている可能性があるので、ワーカーを止めてから DB を閉じる
+func (a *App) Close(ctx context.Context) error {
+	if err := a.Worker.Stop(ctx); err != nil {
+		return fmt.Errorf("stop workers: %w", err)
+	}
+	if err := a.Dao.Close(); err != nil {
+		return fmt.Errorf("close dao: %w", err)
+	}
+	// バッファに残っているログを書き出す。stderr への Sync は失敗することがあるので無視する
+	_ = a.Logger.Sync()
+	return nil
 }
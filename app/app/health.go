@@ -0,0 +1,19 @@
+package app
+
+import "context"
+
+// Dependency checked by the readiness probe
+type HealthCheck struct {
+	// Name shown in the probe response
+	Name string
+
+	// Return non-nil error when the dependency is unavailable
+	Check func(ctx context.Context) error
+}
+
+// Register dependency checked by the readiness probe
+//
+// ストレージやキャッシュなどを追加した時は、ここで登録すれば `/v1/health/ready` で確認される
+func (a *App) RegisterHealthCheck(name string, check func(ctx context.Context) error) {
+	a.HealthChecks = append(a.HealthChecks, HealthCheck{Name: name, Check: check})
+}
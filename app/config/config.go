@@ -1,41 +1,644 @@
 package config
 
 import (
+	"flag"
 	"fmt"
+	"net"
+	"net/url"
 	"os"
+	"path/filepath"
 	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	configFileKey = "CONFIG_FILE"
+	defaultPort   = 8080
+)
+
+// Whole configuration of the server
+//
+// 値の優先順位は flags > 環境変数 > 設定ファイル > デフォルト値
+type Config struct {
+	Server    Server    `yaml:"server"`
+	MySQL     MySQL     `yaml:"mysql"`
+	Health    Health    `yaml:"health"`
+	Metrics   Metrics   `yaml:"metrics"`
+	Tracing   Tracing   `yaml:"tracing"`
+	Log       Log       `yaml:"log"`
+	RateLimit RateLimit `yaml:"rate_limit"`
+	Accounts  Accounts  `yaml:"accounts"`
+	Export    Export    `yaml:"export"`
+	Trends    Trends    `yaml:"trends"`
+	Admin     Admin     `yaml:"admin"`
+}
+
+// Configuration of the HTTP server
+type Server struct {
+	// Port to listen on
+	Port int `yaml:"port"`
+
+	// URL the server is reachable at, used to build absolute links
+	PublicURL string `yaml:"public_url"`
+
+	// Maximum duration for reading the entire request, including the body
+	ReadTimeout time.Duration `yaml:"read_timeout"`
+
+	// Maximum duration for reading the request headers
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout"`
+
+	// Maximum duration before timing out writes of the response
+	WriteTimeout time.Duration `yaml:"write_timeout"`
+
+	// Maximum duration to wait for the next request on keep-alive connections
+	IdleTimeout time.Duration `yaml:"idle_timeout"`
+
+	// Timeout set on the request context of each handler
+	HandlerTimeout time.Duration `yaml:"handler_timeout"`
+
+	// Maximum duration to wait for in-flight requests on shutdown
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+
+	// TLS settings. TLS is disabled unless both files are set
+	TLS TLS `yaml:"tls"`
+
+	// Addresses or CIDRs of reverse proxies allowed to set X-Forwarded-For / X-Real-IP
+	TrustedProxies []string `yaml:"trusted_proxies"`
+}
+
+// Configuration of TLS
+type TLS struct {
+	// Path to the PEM encoded certificate
+	CertFile string `yaml:"cert_file"`
+
+	// Path to the PEM encoded private key
+	KeyFile string `yaml:"key_file"`
+
+	// Interval to check the files for updates
+	ReloadInterval time.Duration `yaml:"reload_interval"`
+}
+
+// Configuration of health checks
+type Health struct {
+	// Timeout of each dependency check on readiness probe
+	CheckTimeout time.Duration `yaml:"check_timeout"`
+}
+
+// Configuration of Prometheus metrics
+type Metrics struct {
+	// Expose `/metrics` endpoint
+	Enabled bool `yaml:"enabled"`
+}
+
+// Configuration of accounts
+type Accounts struct {
+	// Duration between a deletion request and the actual removal of the account
+	DeletionGracePeriod time.Duration `yaml:"deletion_grace_period"`
+}
+
+// Configuration of personal data exports
+type Export struct {
+	// Directory to store archives
+	Dir string `yaml:"dir"`
+
+	// Duration a download link is valid for
+	LinkTTL time.Duration `yaml:"link_ttl"`
+
+	// Duration after which a running export is taken over by another worker
+	ClaimTimeout time.Duration `yaml:"claim_timeout"`
+}
+
+// Configuration of trending hashtags and statuses
+type Trends struct {
+	// Interval to recompute the rankings
+	Interval time.Duration `yaml:"interval"`
+
+	// Time for a use of a hashtag to lose half of its weight
+	HalfLife time.Duration `yaml:"half_life"`
+
+	// Minimum number of distinct accounts in the last day for a hashtag to trend
+	MinAccounts int `yaml:"min_accounts"`
+}
+
+// Configuration of administration
+type Admin struct {
+	// Usernames which always have the admin role, regardless of the role saved in DB.
+	// Used to set up the first administrator, who can grant roles to others
+	Usernames []string `yaml:"usernames"`
+}
+
+// Report whether the account is an administrator by configuration
+func (c Admin) IsAdmin(username string) bool {
+	for _, u := range c.Usernames {
+		if u == username {
+			return true
+		}
+	}
+	return false
+}
+
+// Names of rate limit policies
+const (
+	RateLimitAccountsCreate = "accounts.create"
+	RateLimitStatusesCreate = "statuses.create"
+	RateLimitExportsCreate  = "exports.create"
+	RateLimitImportsCreate  = "imports.create"
 )
 
+// Configuration of rate limiting
+type RateLimit struct {
+	// Apply rate limiting
+	Enabled bool `yaml:"enabled"`
+
+	// Policies by name (e.g. "statuses.create")
+	Policies map[string]RateLimitPolicy `yaml:"policies"`
+}
+
+// Number of requests allowed per window
+type RateLimitPolicy struct {
+	Limit  int           `yaml:"limit"`
+	Window time.Duration `yaml:"window"`
+}
+
+// Formats of log lines
 const (
-	portKey     = "PORT"
-	defaultPort = 8080
+	LogFormatJSON    = "json"
+	LogFormatConsole = "console"
 )
 
-// Read port to listen on
-func Port() int {
-	num, err := getInt(portKey)
+// Configuration of logging
+type Log struct {
+	// One of "debug", "info", "warn" and "error"
+	Level string `yaml:"level"`
+
+	// One of "json" and "console"
+	Format string `yaml:"format"`
+}
+
+// Exporters of OpenTelemetry traces
+const (
+	TracingExporterNone   = "none"
+	TracingExporterStdout = "stdout"
+	TracingExporterOTLP   = "otlp"
+)
+
+// Configuration of OpenTelemetry tracing
+type Tracing struct {
+	// One of "none", "stdout" and "otlp"
+	Exporter string `yaml:"exporter"`
+
+	// OTLP/HTTP collector endpoint (host:port)
+	Endpoint string `yaml:"endpoint"`
+
+	// Send OTLP without TLS
+	Insecure bool `yaml:"insecure"`
+
+	// Service name attached to every span
+	ServiceName string `yaml:"service_name"`
+
+	// Ratio of root traces (no incoming parent) sampled (0.0 - 1.0)
+	SampleRatio float64 `yaml:"sample_ratio"`
+}
+
+// Parse trusted proxies into networks. A bare address matches only itself
+func (c Server) TrustedProxyNets() ([]*net.IPNet, error) {
+	nets := make([]*net.IPNet, 0, len(c.TrustedProxies))
+	for _, p := range c.TrustedProxies {
+		if !strings.Contains(p, "/") {
+			ip := net.ParseIP(p)
+			if ip == nil {
+				return nil, fmt.Errorf("%q is not an IP address or CIDR", p)
+			}
+			bits := 8 * net.IPv6len
+			if ip4 := ip.To4(); ip4 != nil {
+				ip, bits = ip4, 8*net.IPv4len
+			}
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, n, err := net.ParseCIDR(p)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not an IP address or CIDR", p)
+		}
+		nets = append(nets, n)
+	}
+	return nets, nil
+}
+
+// Report whether TLS is configured
+func (c TLS) Enabled() bool {
+	return c.CertFile != "" && c.KeyFile != ""
+}
+
+// Build configuration filled with default values
+func Default() *Config {
+	return &Config{
+		Server: Server{
+			Port:              defaultPort,
+			PublicURL:         fmt.Sprintf("http://localhost:%d", defaultPort),
+			ReadTimeout:       15 * time.Second,
+			ReadHeaderTimeout: 5 * time.Second,
+			// ハンドラのタイムアウトより長くしないとレスポンスを返す前に切断される
+			WriteTimeout:    65 * time.Second,
+			IdleTimeout:     120 * time.Second,
+			HandlerTimeout:  60 * time.Second,
+			ShutdownTimeout: 30 * time.Second,
+			TLS: TLS{
+				ReloadInterval: time.Minute,
+			},
+		},
+		Health: Health{
+			CheckTimeout: 2 * time.Second,
+		},
+		Metrics: Metrics{
+			Enabled: true,
+		},
+		Tracing: Tracing{
+			Exporter:    TracingExporterNone,
+			ServiceName: "yatter-backend-go",
+			SampleRatio: 1,
+		},
+		Log: Log{
+			Level:  "info",
+			Format: LogFormatJSON,
+		},
+		RateLimit: RateLimit{
+			Enabled: true,
+			Policies: map[string]RateLimitPolicy{
+				RateLimitAccountsCreate: {Limit: 5, Window: 30 * time.Minute},
+				RateLimitStatusesCreate: {Limit: 300, Window: 3 * time.Hour},
+				RateLimitExportsCreate:  {Limit: 1, Window: 24 * time.Hour},
+				RateLimitImportsCreate:  {Limit: 10, Window: time.Hour},
+			},
+		},
+		Accounts: Accounts{
+			DeletionGracePeriod: 30 * 24 * time.Hour,
+		},
+		Export: Export{
+			Dir:          filepath.Join(os.TempDir(), "yatter-exports"),
+			LinkTTL:      7 * 24 * time.Hour,
+			ClaimTimeout: time.Hour,
+		},
+		Trends: Trends{
+			Interval:    10 * time.Minute,
+			HalfLife:    6 * time.Hour,
+			MinAccounts: 2,
+		},
+	}
+}
+
+// Load configuration from file, environment variables and command line flags
+//
+// 設定ファイルのパスは `-config` フラグか CONFIG_FILE 環境変数で指定する。
+// 読み込み・検証で見つかった問題はすべてまとめて ValidationError として返す。
+func Load(args []string) (*Config, error) {
+	// フラグは既定値を元にした別の Config に読み込み、ファイルと環境変数を反映した後で指定されたものだけを写す
+	flagged := Default()
+	fs := flag.NewFlagSet("yatter-backend-go", flag.ContinueOnError)
+	path := fs.String("config", os.Getenv(configFileKey), fmt.Sprintf("path to YAML config file (env %s)", configFileKey))
+	for _, b := range bindings {
+		usage := fmt.Sprintf("%s (env %s)", b.usage, b.env)
+		// 型の分かるものは標準のフラグとして登録し、使い方の表示やパースを揃える
+		switch v := b.value(flagged).(type) {
+		case *stringValue:
+			fs.StringVar((*string)(v), b.flag, string(*v), usage)
+		case *intValue:
+			fs.IntVar((*int)(v), b.flag, int(*v), usage)
+		case *floatValue:
+			fs.Float64Var((*float64)(v), b.flag, float64(*v), usage)
+		case *boolValue:
+			fs.BoolVar((*bool)(v), b.flag, bool(*v), usage)
+		case *durationValue:
+			fs.DurationVar((*time.Duration)(v), b.flag, time.Duration(*v), usage)
+		default:
+			fs.Var(v, b.flag, usage)
+		}
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	cfg := Default()
+	if *path != "" {
+		if err := cfg.loadFile(*path); err != nil {
+			return nil, err
+		}
+	}
+
+	var errs ValidationError
+	for _, b := range bindings {
+		if v, ok := os.LookupEnv(b.env); ok && v != "" {
+			if err := b.value(cfg).Set(v); err != nil {
+				errs = append(errs, fmt.Errorf("env %s: %w", b.env, err))
+			}
+		}
+	}
+
+	// 明示的に指定されたフラグだけで上書きする
+	visited := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { visited[f.Name] = true })
+	for _, b := range bindings {
+		if !visited[b.flag] {
+			continue
+		}
+		if err := b.value(cfg).Set(b.value(flagged).String()); err != nil {
+			errs = append(errs, fmt.Errorf("flag -%s: %w", b.flag, err))
+		}
+	}
+
+	errs = append(errs, cfg.validate()...)
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return cfg, nil
+}
+
+// Check every value and report all problems at once
+func (c *Config) Validate() error {
+	if errs := c.validate(); len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func (c *Config) validate() ValidationError {
+	var errs ValidationError
+	errs = append(errs, c.Server.validate()...)
+	errs = append(errs, c.MySQL.validate()...)
+	if c.Health.CheckTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("health.check_timeout must be positive"))
+	}
+	errs = append(errs, c.Tracing.validate()...)
+	errs = append(errs, c.Log.validate()...)
+	for name, p := range c.RateLimit.Policies {
+		if p.Limit <= 0 || p.Window <= 0 {
+			errs = append(errs, fmt.Errorf("rate_limit.policies.%s: limit and window must be positive", name))
+		}
+	}
+	if c.Accounts.DeletionGracePeriod < 0 {
+		errs = append(errs, fmt.Errorf("accounts.deletion_grace_period must not be negative"))
+	}
+	if c.Export.Dir == "" {
+		errs = append(errs, fmt.Errorf("export.dir is required"))
+	}
+	if c.Export.LinkTTL <= 0 {
+		errs = append(errs, fmt.Errorf("export.link_ttl must be positive"))
+	}
+	if c.Export.ClaimTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("export.claim_timeout must be positive"))
+	}
+	if c.Trends.Interval <= 0 || c.Trends.HalfLife <= 0 {
+		errs = append(errs, fmt.Errorf("trends.interval and trends.half_life must be positive"))
+	}
+	if c.Trends.MinAccounts < 1 {
+		errs = append(errs, fmt.Errorf("trends.min_accounts must be at least 1"))
+	}
+	return errs
+}
+
+func (c Server) validate() []error {
+	var errs []error
+	if c.Port <= 0 || c.Port > 65535 {
+		errs = append(errs, fmt.Errorf("server.port: %d is out of range", c.Port))
+	}
+	if u, err := url.Parse(c.PublicURL); err != nil || u.Scheme == "" || u.Host == "" {
+		errs = append(errs, fmt.Errorf("server.public_url: %q is not an absolute URL", c.PublicURL))
+	}
+	durations := []struct {
+		key   string
+		value time.Duration
+	}{
+		{"server.read_timeout", c.ReadTimeout},
+		{"server.read_header_timeout", c.ReadHeaderTimeout},
+		{"server.write_timeout", c.WriteTimeout},
+		{"server.idle_timeout", c.IdleTimeout},
+		{"server.handler_timeout", c.HandlerTimeout},
+		{"server.shutdown_timeout", c.ShutdownTimeout},
+	}
+	for _, d := range durations {
+		if d.value <= 0 {
+			errs = append(errs, fmt.Errorf("%s must be positive", d.key))
+		}
+	}
+	if c.WriteTimeout > 0 && c.HandlerTimeout >= c.WriteTimeout {
+		errs = append(errs, fmt.Errorf("server.handler_timeout (%s) must be shorter than server.write_timeout (%s)", c.HandlerTimeout, c.WriteTimeout))
+	}
+	if (c.TLS.CertFile == "") != (c.TLS.KeyFile == "") {
+		errs = append(errs, fmt.Errorf("server.tls: both cert_file and key_file are required"))
+	}
+	if c.TLS.Enabled() && c.TLS.ReloadInterval <= 0 {
+		errs = append(errs, fmt.Errorf("server.tls.reload_interval must be positive"))
+	}
+	if _, err := c.TrustedProxyNets(); err != nil {
+		errs = append(errs, fmt.Errorf("server.trusted_proxies: %w", err))
+	}
+	return errs
+}
+
+func (c Log) validate() []error {
+	var errs []error
+	switch c.Level {
+	case "debug", "info", "warn", "error":
+	default:
+		errs = append(errs, fmt.Errorf("log.level: unknown level %q", c.Level))
+	}
+	switch c.Format {
+	case LogFormatJSON, LogFormatConsole:
+	default:
+		errs = append(errs, fmt.Errorf("log.format: unknown format %q", c.Format))
+	}
+	return errs
+}
+
+func (c Tracing) validate() []error {
+	var errs []error
+	switch c.Exporter {
+	case TracingExporterNone, TracingExporterStdout:
+	case TracingExporterOTLP:
+		if c.Endpoint == "" {
+			errs = append(errs, fmt.Errorf("tracing.endpoint is required for otlp exporter"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("tracing.exporter: unknown exporter %q", c.Exporter))
+	}
+	if c.SampleRatio < 0 || c.SampleRatio > 1 {
+		errs = append(errs, fmt.Errorf("tracing.sample_ratio: %v is out of range", c.SampleRatio))
+	}
+	if c.ServiceName == "" {
+		errs = append(errs, fmt.Errorf("tracing.service_name is required"))
+	}
+	return errs
+}
+
+func (c *Config) loadFile(path string) error {
+	f, err := os.Open(path)
 	if err != nil {
-		return defaultPort
+		return fmt.Errorf("config: open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	// YAML はマップの値を丸ごと置き換えるので、既存のポリシーを控えておき省略された項目を補う
+	policies := make(map[string]RateLimitPolicy, len(c.RateLimit.Policies))
+	for name, p := range c.RateLimit.Policies {
+		policies[name] = p
 	}
-	return num
+
+	dec := yaml.NewDecoder(f)
+	dec.KnownFields(true)
+	if err := dec.Decode(c); err != nil {
+		return fmt.Errorf("config: parse %s: %w", path, err)
+	}
+
+	for name, p := range c.RateLimit.Policies {
+		base, ok := policies[name]
+		if !ok {
+			continue
+		}
+		if p.Limit == 0 {
+			p.Limit = base.Limit
+		}
+		if p.Window == 0 {
+			p.Window = base.Window
+		}
+		c.RateLimit.Policies[name] = p
+	}
+	return nil
 }
 
-func getInt(key string) (int, error) {
-	v := os.Getenv(key)
-	if v == "" {
-		return 0, fmt.Errorf("config:[%s] not found", key)
+// Every problem found while loading configuration
+type ValidationError []error
+
+func (e ValidationError) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, err := range e {
+		msgs = append(msgs, err.Error())
 	}
-	num, err := strconv.Atoi(v)
+	return "invalid config: " + strings.Join(msgs, "; ")
+}
+
+// Mapping between a config value, its environment variable and its flag
+type binding struct {
+	flag  string
+	env   string
+	usage string
+	// c の設定値を、型に合った flag.Value として返す
+	value func(c *Config) flag.Value
+}
+
+var bindings = []binding{
+	{"port", "PORT", "port to listen on", func(c *Config) flag.Value { return (*intValue)(&c.Server.Port) }},
+	{"public-url", "PUBLIC_URL", "URL the server is reachable at", func(c *Config) flag.Value { return (*stringValue)(&c.Server.PublicURL) }},
+	{"read-timeout", "SERVER_READ_TIMEOUT", "max duration for reading the request", func(c *Config) flag.Value { return (*durationValue)(&c.Server.ReadTimeout) }},
+	{"read-header-timeout", "SERVER_READ_HEADER_TIMEOUT", "max duration for reading the request headers", func(c *Config) flag.Value { return (*durationValue)(&c.Server.ReadHeaderTimeout) }},
+	{"write-timeout", "SERVER_WRITE_TIMEOUT", "max duration for writing the response", func(c *Config) flag.Value { return (*durationValue)(&c.Server.WriteTimeout) }},
+	{"idle-timeout", "SERVER_IDLE_TIMEOUT", "max duration of idle keep-alive connections", func(c *Config) flag.Value { return (*durationValue)(&c.Server.IdleTimeout) }},
+	{"handler-timeout", "SERVER_HANDLER_TIMEOUT", "timeout of each request handler", func(c *Config) flag.Value { return (*durationValue)(&c.Server.HandlerTimeout) }},
+	{"shutdown-timeout", "SERVER_SHUTDOWN_TIMEOUT", "max duration to drain requests on shutdown", func(c *Config) flag.Value { return (*durationValue)(&c.Server.ShutdownTimeout) }},
+	{"tls-cert", "TLS_CERT_FILE", "path to TLS certificate", func(c *Config) flag.Value { return (*stringValue)(&c.Server.TLS.CertFile) }},
+	{"tls-key", "TLS_KEY_FILE", "path to TLS private key", func(c *Config) flag.Value { return (*stringValue)(&c.Server.TLS.KeyFile) }},
+	{"tls-reload-interval", "TLS_RELOAD_INTERVAL", "interval to reload TLS certificate", func(c *Config) flag.Value { return (*durationValue)(&c.Server.TLS.ReloadInterval) }},
+	{"trusted-proxies", "SERVER_TRUSTED_PROXIES", "comma separated addresses or CIDRs of trusted reverse proxies", func(c *Config) flag.Value { return (*stringsValue)(&c.Server.TrustedProxies) }},
+	{"health-check-timeout", "HEALTH_CHECK_TIMEOUT", "timeout of each readiness check", func(c *Config) flag.Value { return (*durationValue)(&c.Health.CheckTimeout) }},
+	{"metrics", "METRICS_ENABLED", "expose /metrics endpoint", func(c *Config) flag.Value { return (*boolValue)(&c.Metrics.Enabled) }},
+	{"tracing-exporter", "TRACING_EXPORTER", "trace exporter (none, stdout, otlp)", func(c *Config) flag.Value { return (*stringValue)(&c.Tracing.Exporter) }},
+	{"tracing-endpoint", "TRACING_ENDPOINT", "OTLP/HTTP collector endpoint", func(c *Config) flag.Value { return (*stringValue)(&c.Tracing.Endpoint) }},
+	{"tracing-insecure", "TRACING_INSECURE", "send OTLP without TLS", func(c *Config) flag.Value { return (*boolValue)(&c.Tracing.Insecure) }},
+	{"tracing-service-name", "OTEL_SERVICE_NAME", "service name of spans", func(c *Config) flag.Value { return (*stringValue)(&c.Tracing.ServiceName) }},
+	{"tracing-sample-ratio", "TRACING_SAMPLE_RATIO", "ratio of sampled root traces", func(c *Config) flag.Value { return (*floatValue)(&c.Tracing.SampleRatio) }},
+	{"log-level", "LOG_LEVEL", "log level (debug, info, warn, error)", func(c *Config) flag.Value { return (*stringValue)(&c.Log.Level) }},
+	{"log-format", "LOG_FORMAT", "log format (json, console)", func(c *Config) flag.Value { return (*stringValue)(&c.Log.Format) }},
+	{"rate-limit", "RATE_LIMIT_ENABLED", "apply rate limiting", func(c *Config) flag.Value { return (*boolValue)(&c.RateLimit.Enabled) }},
+	{"account-deletion-grace-period", "ACCOUNT_DELETION_GRACE_PERIOD", "grace period before deleting accounts", func(c *Config) flag.Value { return (*durationValue)(&c.Accounts.DeletionGracePeriod) }},
+	{"export-dir", "EXPORT_DIR", "directory to store data exports", func(c *Config) flag.Value { return (*stringValue)(&c.Export.Dir) }},
+	{"export-link-ttl", "EXPORT_LINK_TTL", "validity of export download links", func(c *Config) flag.Value { return (*durationValue)(&c.Export.LinkTTL) }},
+	{"export-claim-timeout", "EXPORT_CLAIM_TIMEOUT", "duration after which a running export is retried", func(c *Config) flag.Value { return (*durationValue)(&c.Export.ClaimTimeout) }},
+	{"trends-interval", "TRENDS_INTERVAL", "interval to recompute trends", func(c *Config) flag.Value { return (*durationValue)(&c.Trends.Interval) }},
+	{"admin-usernames", "ADMIN_USERNAMES", "comma separated usernames which always have the admin role", func(c *Config) flag.Value { return (*stringsValue)(&c.Admin.Usernames) }},
+	{"mysql-host", "MYSQL_HOST", "MySQL host", func(c *Config) flag.Value { return (*stringValue)(&c.MySQL.Host) }},
+	{"mysql-test-host", "TEST_MYSQL_HOST", "MySQL host for tests", func(c *Config) flag.Value { return (*stringValue)(&c.MySQL.TestHost) }},
+	{"mysql-user", "MYSQL_USER", "MySQL user", func(c *Config) flag.Value { return (*stringValue)(&c.MySQL.User) }},
+	{"mysql-password", "MYSQL_PASSWORD", "MySQL password", func(c *Config) flag.Value { return (*stringValue)(&c.MySQL.Password) }},
+	{"mysql-database", "MYSQL_DATABASE", "MySQL database name", func(c *Config) flag.Value { return (*stringValue)(&c.MySQL.Database) }},
+	{"mysql-tz", "MYSQL_TZ", "timezone for MySQL", func(c *Config) flag.Value { return (*stringValue)(&c.MySQL.TZ) }},
+}
+
+// 設定値の型ごとの flag.Value。標準の flag パッケージと同じく、値へのポインタを変換して使う
+type stringValue string
+
+func (v *stringValue) Set(s string) error {
+	*v = stringValue(s)
+	return nil
+}
+
+func (v *stringValue) String() string { return string(*v) }
+
+type intValue int
+
+func (v *intValue) Set(s string) error {
+	num, err := strconv.Atoi(s)
 	if err != nil {
-		return 0, fmt.Errorf("config:[%s] should number", key)
+		return fmt.Errorf("%q should be number", s)
 	}
-	return num, nil
+	*v = intValue(num)
+	return nil
 }
 
-func getString(key string) (string, error) {
-	v := os.Getenv(key)
-	if v == "" {
-		return "", fmt.Errorf("config:[%s] not found", key)
+func (v *intValue) String() string { return strconv.Itoa(int(*v)) }
+
+type floatValue float64
+
+func (v *floatValue) Set(s string) error {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return fmt.Errorf("%q should be number", s)
 	}
-	return v, nil
+	*v = floatValue(f)
+	return nil
 }
+
+func (v *floatValue) String() string { return strconv.FormatFloat(float64(*v), 'g', -1, 64) }
+
+// カンマ区切りの文字列。空の要素は無視する
+type stringsValue []string
+
+func (v *stringsValue) Set(s string) error {
+	var values []string
+	for _, e := range strings.Split(s, ",") {
+		if e = strings.TrimSpace(e); e != "" {
+			values = append(values, e)
+		}
+	}
+	*v = values
+	return nil
+}
+
+func (v *stringsValue) String() string { return strings.Join(*v, ",") }
+
+type boolValue bool
+
+func (v *boolValue) Set(s string) error {
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return fmt.Errorf("%q should be boolean", s)
+	}
+	*v = boolValue(b)
+	return nil
+}
+
+func (v *boolValue) String() string { return strconv.FormatBool(bool(*v)) }
+
+type durationValue time.Duration
+
+func (v *durationValue) Set(s string) error {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return fmt.Errorf("%q should be duration (e.g. 30s)", s)
+	}
+	*v = durationValue(d)
+	return nil
+}
+
+func (v *durationValue) String() string { return time.Duration(*v).String() }
@@ -0,0 +1,127 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoad(t *testing.T) {
+	t.Run("precedence", func(t *testing.T) {
+		path := writeFile(t, `
+server:
+  port: 9000
+mysql:
+  host: file:3306
+  user: file-user
+  password: file-pass
+  database: file-db
+`)
+		clearEnv(t)
+		os.Setenv("MYSQL_USER", "env-user")
+		os.Setenv("MYSQL_DATABASE", "env-db")
+
+		cfg, err := Load([]string{"-config", path, "-mysql-database", "flag-db"})
+		assert.NoError(t, err)
+		assert.Equal(t, 9000, cfg.Server.Port)
+		assert.Equal(t, "file:3306", cfg.MySQL.Host)
+		assert.Equal(t, "env-user", cfg.MySQL.User)
+		assert.Equal(t, "file-pass", cfg.MySQL.Password)
+		assert.Equal(t, "flag-db", cfg.MySQL.Database)
+	})
+
+	t.Run("all errors are reported", func(t *testing.T) {
+		clearEnv(t)
+		os.Setenv("PORT", "abc")
+
+		_, err := Load([]string{"-mysql-tz", "Nowhere/City"})
+		assert.Error(t, err)
+
+		verr, ok := err.(ValidationError)
+		assert.True(t, ok)
+		// PORT, mysql.host, mysql.user, mysql.password, mysql.database, mysql.tz
+		assert.Len(t, verr, 6)
+	})
+
+	t.Run("typed flags", func(t *testing.T) {
+		clearEnv(t)
+		path := writeFile(t, "mysql:\n  host: db:3306\n  user: u\n  password: p\n  database: d\nmetrics:\n  enabled: false\n")
+
+		// 真偽値のフラグは値を省略できる
+		cfg, err := Load([]string{"-config", path, "-metrics", "-handler-timeout", "5s", "-admin-usernames", "alice, bob"})
+		assert.NoError(t, err)
+		assert.True(t, cfg.Metrics.Enabled)
+		assert.Equal(t, 5*time.Second, cfg.Server.HandlerTimeout)
+		assert.Equal(t, []string{"alice", "bob"}, cfg.Admin.Usernames)
+
+		// 型に合わない値はパース時にエラーになる
+		_, err = Load([]string{"-config", path, "-port", "abc"})
+		assert.Error(t, err)
+	})
+
+	t.Run("partial rate limit policy", func(t *testing.T) {
+		clearEnv(t)
+		path := writeFile(t, "mysql:\n  host: db:3306\n  user: u\n  password: p\n  database: d\nrate_limit:\n  policies:\n    statuses.create:\n      limit: 10\n")
+
+		// 省略した window は既定値のまま残る
+		cfg, err := Load([]string{"-config", path})
+		assert.NoError(t, err)
+		assert.Equal(t, RateLimitPolicy{Limit: 10, Window: 3 * time.Hour}, cfg.RateLimit.Policies[RateLimitStatusesCreate])
+		assert.Equal(t, Default().RateLimit.Policies[RateLimitAccountsCreate], cfg.RateLimit.Policies[RateLimitAccountsCreate])
+	})
+
+	t.Run("trusted proxies", func(t *testing.T) {
+		clearEnv(t)
+		path := writeFile(t, "mysql:\n  host: db:3306\n  user: u\n  password: p\n  database: d\n")
+
+		cfg, err := Load([]string{"-config", path, "-trusted-proxies", "10.0.0.0/8, 192.168.1.1"})
+		assert.NoError(t, err)
+		nets, err := cfg.Server.TrustedProxyNets()
+		assert.NoError(t, err)
+		if assert.Len(t, nets, 2) {
+			assert.Equal(t, "10.0.0.0/8", nets[0].String())
+			assert.Equal(t, "192.168.1.1/32", nets[1].String())
+		}
+
+		_, err = Load([]string{"-config", path, "-trusted-proxies", "proxy.local"})
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown key in file", func(t *testing.T) {
+		clearEnv(t)
+		path := writeFile(t, "server:\n  prot: 9000\n")
+
+		_, err := Load([]string{"-config", path})
+		assert.Error(t, err)
+	})
+}
+
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// 既存の環境変数の影響を受けないように、関係する変数を空にしてテスト後に元に戻す
+func clearEnv(t *testing.T) {
+	t.Helper()
+	keys := []string{configFileKey}
+	for _, b := range bindings {
+		keys = append(keys, b.env)
+	}
+	for _, key := range keys {
+		key := key
+		if v, ok := os.LookupEnv(key); ok {
+			t.Cleanup(func() { os.Setenv(key, v) })
+		} else {
+			t.Cleanup(func() { os.Unsetenv(key) })
+		}
+		os.Unsetenv(key)
+	}
+}
@@ -1,106 +1,93 @@
 package config
 
 import (
+	"errors"
 	"fmt"
-	"log"
 	"time"
 
 	"github.com/go-sql-driver/mysql"
 )
 
-// accessor namespace
-// MYSQL変数を使用して、_mysqlに定義されたメソッドにアクセスできる
-var MySQL _mysql
+// Configuration of the MySQL connection
+type MySQL struct {
+	// MySQL host (host:port)
+	Host string `yaml:"host"`
 
-type _mysql struct{}
+	// MySQL host used by tests
+	TestHost string `yaml:"test_host"`
 
-// Read MySQL host
-func (_mysql) Host() string {
-	v, err := getString("MYSQL_HOST")
-	if err != nil {
-		log.Fatal(err)
-	}
-	return v
-}
+	// MySQL user
+	User string `yaml:"user"`
 
-// Read MySQL host
-func (_mysql) TestHost() string {
-	v, err := getString("TEST_MYSQL_HOST")
-	if err != nil {
-		log.Fatal(err)
-	}
-	return v
-}
+	// MySQL password
+	Password string `yaml:"password"`
 
-// Read MySQL user
-func (_mysql) User() string {
-	v, err := getString("MYSQL_USER")
-	if err != nil {
-		log.Fatal(err)
-	}
-	return v
-}
+	// MySQL database name
+	Database string `yaml:"database"`
 
-// Read MySQL password
-func (_mysql) Password() string {
-	v, err := getString("MYSQL_PASSWORD")
-	if err != nil {
-		log.Fatal(err)
-	}
-	return v
+	// Timezone for MySQL (default: Asia/Tokyo)
+	TZ string `yaml:"tz"`
 }
 
-// Read MySQL database name
-func (_mysql) Database() string {
-	v, err := getString("MYSQL_DATABASE")
-	if err != nil {
-		log.Fatal(err)
+// Return copy of the configuration pointing to the test database
+func (c MySQL) ForTest() (MySQL, error) {
+	if c.TestHost == "" {
+		return c, errors.New("config: mysql.test_host is required for tests")
 	}
-	return v
+	c.Host = c.TestHost
+	return c, nil
 }
 
 // Read Timezone for MySQL
-func (_mysql) Location() *time.Location {
-	tz, err := getString("MYSQL_TZ")
-	if err != nil {
+func (c MySQL) Location() *time.Location {
+	if c.TZ == "" {
 		return time.FixedZone("Asia/Tokyo", 9*60*60)
 	}
-	loc, err := time.LoadLocation(tz)
+	loc, err := time.LoadLocation(c.TZ)
 	if err != nil {
-		log.Fatal(fmt.Errorf("Invalid timezone %+v", tz))
+		// Validate で検証済み
+		return time.FixedZone("Asia/Tokyo", 9*60*60)
 	}
 	return loc
 }
 
 // Build mysql.Config
-func MySQLConfig() *mysql.Config {
+func (c MySQL) DriverConfig() *mysql.Config {
 	cfg := mysql.NewConfig()
 
 	cfg.ParseTime = true
-	cfg.Loc = MySQL.Location()
-	if host := MySQL.Host(); host != "" {
+	cfg.Loc = c.Location()
+	if c.Host != "" {
 		cfg.Net = "tcp"
-		cfg.Addr = host
+		cfg.Addr = c.Host
 	}
-	cfg.User = MySQL.User()
-	cfg.Passwd = MySQL.Password()
-	cfg.DBName = MySQL.Database()
+	cfg.User = c.User
+	cfg.Passwd = c.Password
+	cfg.DBName = c.Database
 
 	return cfg
 }
 
-func MySQLTestConfig() *mysql.Config {
-	cfg := mysql.NewConfig()
-
-	cfg.ParseTime = true
-	cfg.Loc = MySQL.Location()
-	if host := MySQL.TestHost(); host != "" {
-		cfg.Net = "tcp"
-		cfg.Addr = host
+func (c MySQL) validate() []error {
+	var errs []error
+	required := []struct {
+		key   string
+		value string
+	}{
+		{"mysql.host", c.Host},
+		{"mysql.user", c.User},
+		{"mysql.password", c.Password},
+		{"mysql.database", c.Database},
 	}
-	cfg.User = MySQL.User()
-	cfg.Passwd = MySQL.Password()
-	cfg.DBName = MySQL.Database()
-
-	return cfg
+	for _, r := range required {
+		if r.value == "" {
+			errs = append(errs, fmt.Errorf("%s is required", r.key))
+		}
+	}
+	if c.TZ != "" {
+		if _, err := time.LoadLocation(c.TZ); err != nil {
+			errs = append(errs, fmt.Errorf("mysql.tz: invalid timezone %q", c.TZ))
+		}
+	}
+	return errs
 }
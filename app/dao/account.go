@@ -5,6 +5,10 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"yatter-backend-go/app/domain/customerror"
 	"yatter-backend-go/app/domain/object"
 	"yatter-backend-go/app/domain/repository"
 
@@ -24,9 +28,32 @@ func NewAccount(db *sqlx.DB) repository.Account {
 }
 
 // FindByUsername : ユーザ名からユーザを取得
-func (r *account) FindByUsername(ctx context.Context, username string) (*object.Account, error) {
+func (r *account) FindByUsername(ctx context.Context, username string) (_ *object.Account, err error) {
+	query := "select * from account where username = ?"
+	ctx, span := startSpan(ctx, "account.FindByUsername", query)
+	defer func() { endSpan(span, err) }()
+
+	entity := new(object.Account)
+	err = r.db.QueryRowxContext(ctx, query, username).StructScan(entity)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	return entity, nil
+}
+
+// FindByID : ID からユーザを取得
+func (r *account) FindByID(ctx context.Context, id object.AccountID) (_ *object.Account, err error) {
+	query := "select * from account where id = ?"
+	ctx, span := startSpan(ctx, "account.FindByID", query)
+	defer func() { endSpan(span, err) }()
+
 	entity := new(object.Account)
-	err := r.db.QueryRowxContext(ctx, "select * from account where username = ?", username).StructScan(entity)
+	err = r.db.QueryRowxContext(ctx, query, id).StructScan(entity)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
@@ -38,12 +65,58 @@ func (r *account) FindByUsername(ctx context.Context, username string) (*object.
 	return entity, nil
 }
 
+// FindByPrefix : ユーザ名か表示名が前方一致するアカウントを取得する
+// 入力のたびに呼ばれるので、それぞれのインデックスを使えるように OR ではなく UNION にしている。
+// 閲覧者がフォローしているアカウントを先に返す。凍結されたアカウントは補完に出さない
+func (r *account) FindByPrefix(ctx context.Context, prefix string, opts repository.AccountPrefixOptions) (_ []*object.Account, err error) {
+	query := `
+	SELECT a.*
+	FROM account a
+	INNER JOIN (
+		SELECT id FROM account WHERE username LIKE ?
+		UNION
+		SELECT id FROM account WHERE display_name LIKE ?
+	) m ON m.id = a.id
+	LEFT JOIN follow f ON f.account_id = ? AND f.target_account_id = a.id
+	`
+	pattern := likePrefix(prefix)
+	args := []interface{}{pattern, pattern, opts.ViewerID}
+
+	whereClauses := []string{"a.suspended_at IS NULL"}
+	if opts.Following {
+		whereClauses = append(whereClauses, "f.account_id IS NOT NULL")
+	}
+	if opts.ViewerID > 0 {
+		whereClauses = append(whereClauses,
+			"a.id NOT IN (SELECT target_account_id FROM block WHERE account_id = ?)",
+			"a.id NOT IN (SELECT account_id FROM block WHERE target_account_id = ?)",
+		)
+		args = append(args, opts.ViewerID, opts.ViewerID)
+	}
+	query += " WHERE " + strings.Join(whereClauses, " AND ")
+	query += " ORDER BY f.account_id IS NULL, CHAR_LENGTH(a.username), a.username LIMIT ?"
+	args = append(args, opts.Limit)
+
+	ctx, span := startSpan(ctx, "account.FindByPrefix", query)
+	defer func() { endSpan(span, err) }()
+
+	accounts := make([]*object.Account, 0)
+	if err := r.db.SelectContext(ctx, &accounts, query, args...); err != nil {
+		return nil, err
+	}
+	setRowCount(span, int64(len(accounts)))
+
+	return accounts, nil
+}
+
 // Add : 新規ユーザ作成
-func (r *account) Add(ctx context.Context, account *object.Account) (object.AccountID, error) {
+func (r *account) Add(ctx context.Context, account *object.Account) (_ object.AccountID, err error) {
 	query := `
 		INSERT INTO account (username, password_hash, display_name, avatar, header, note)
 		VALUES (?, ?, ?, ?, ?, ?)
 	`
+	ctx, span := startSpan(ctx, "account.Add", query)
+	defer func() { endSpan(span, err) }()
 
 	result, err := r.db.ExecContext(ctx, query,
 		account.Username,
@@ -64,3 +137,321 @@ func (r *account) Add(ctx context.Context, account *object.Account) (object.Acco
 	}
 	return id, nil
 }
+
+// Update : プロフィールを更新する
+func (r *account) Update(ctx context.Context, account *object.Account) (err error) {
+	query := `
+		UPDATE account
+		SET display_name = ?, avatar = ?, header = ?, note = ?, locked = ?
+		WHERE id = ?
+	`
+	ctx, span := startSpan(ctx, "account.Update", query)
+	defer func() { endSpan(span, err) }()
+
+	_, err = r.db.ExecContext(ctx, query,
+		account.DisplayName,
+		account.Avatar,
+		account.Header,
+		account.Note,
+		account.Locked,
+		account.ID,
+	)
+	return err
+}
+
+// UpdatePreferences : 閲覧時の設定を更新する
+func (r *account) UpdatePreferences(ctx context.Context, id object.AccountID, prefs *object.Preferences) (err error) {
+	query := "UPDATE account SET expand_spoilers = ?, expand_media = ? WHERE id = ?"
+	ctx, span := startSpan(ctx, "account.UpdatePreferences", query)
+	defer func() { endSpan(span, err) }()
+
+	_, err = r.db.ExecContext(ctx, query, prefs.ExpandSpoilers, prefs.ExpandMedia, id)
+	return err
+}
+
+// ScheduleDeletion : アカウントの削除を予約する
+func (r *account) ScheduleDeletion(ctx context.Context, id object.AccountID, at time.Time) (err error) {
+	query := "UPDATE account SET delete_scheduled_at = ? WHERE id = ?"
+	ctx, span := startSpan(ctx, "account.ScheduleDeletion", query)
+	defer func() { endSpan(span, err) }()
+
+	_, err = r.db.ExecContext(ctx, query, at, id)
+	return err
+}
+
+// CancelDeletion : アカウントの削除予約を取り消す
+func (r *account) CancelDeletion(ctx context.Context, id object.AccountID) (err error) {
+	query := "UPDATE account SET delete_scheduled_at = NULL WHERE id = ?"
+	ctx, span := startSpan(ctx, "account.CancelDeletion", query)
+	defer func() { endSpan(span, err) }()
+
+	_, err = r.db.ExecContext(ctx, query, id)
+	return err
+}
+
+// アカウントとそれに紐づくデータの削除。外部キーがあるので account は最後に消す
+var accountDependents = []string{
+	"DELETE FROM notification WHERE account_id = :id OR from_account_id = :id",
+	// 投票を消す前に、投票先の集計から差し引く
+	`UPDATE poll_option o
+	INNER JOIN (SELECT poll_id, choice, COUNT(*) AS votes FROM poll_vote WHERE account_id = :id GROUP BY poll_id, choice) v
+		ON o.poll_id = v.poll_id AND o.position = v.choice
+	SET o.votes_count = o.votes_count - v.votes`,
+	`UPDATE poll p
+	INNER JOIN (SELECT poll_id, COUNT(*) AS votes FROM poll_vote WHERE account_id = :id GROUP BY poll_id) v
+		ON p.id = v.poll_id
+	SET p.votes_count = p.votes_count - v.votes, p.voters_count = p.voters_count - 1`,
+	"DELETE FROM poll_vote WHERE account_id = :id",
+	"DELETE FROM scheduled_status WHERE account_id = :id",
+	"DELETE FROM bookmark WHERE account_id = :id",
+	"DELETE FROM status_pin WHERE account_id = :id",
+	"DELETE FROM status_mention WHERE account_id = :id",
+	"DELETE FROM conversation_account WHERE account_id = :id",
+	"DELETE FROM conversation_participant WHERE account_id = :id",
+	"DELETE FROM list_account WHERE account_id = :id",
+	// 自分のリストのメンバーは ON DELETE CASCADE で消える
+	"DELETE FROM list WHERE account_id = :id",
+	// キーワードは ON DELETE CASCADE で消える
+	"DELETE FROM filter WHERE account_id = :id",
+	"DELETE FROM account_ip WHERE account_id = :id",
+	"DELETE FROM account_warning WHERE account_id = :id",
+	// status_tag, poll と他のアカウントのブックマークは ON DELETE CASCADE で消える
+	"DELETE FROM status WHERE account_id = :id",
+	"DELETE FROM follow WHERE account_id = :id OR target_account_id = :id",
+	"DELETE FROM follow_request WHERE account_id = :id OR target_account_id = :id",
+	"DELETE FROM block WHERE account_id = :id OR target_account_id = :id",
+	"DELETE FROM mute WHERE account_id = :id OR target_account_id = :id",
+	// 自分が送った通報だけ消す。report_status は ON DELETE CASCADE で消える。
+	// 自分が対象の通報はモデレーションの記録として残し、対象・担当者・対応者としての参照は NULL になる
+	"DELETE FROM report WHERE account_id = :id",
+	// アーカイブのファイルは期限切れ後に export.cleanup が消す
+	"DELETE FROM export WHERE account_id = :id",
+	"DELETE e FROM account_import_error e INNER JOIN account_import i ON e.import_id = i.id WHERE i.account_id = :id",
+	"DELETE FROM account_import WHERE account_id = :id",
+	"DELETE FROM account WHERE id = :id",
+}
+
+// DeleteScheduled : 削除予定時刻を過ぎたアカウントを関連データごと削除する
+func (r *account) DeleteScheduled(ctx context.Context) (_ int64, err error) {
+	query := `
+	SELECT id FROM account
+	WHERE delete_scheduled_at IS NOT NULL AND delete_scheduled_at <= NOW()
+	`
+	ctx, span := startSpan(ctx, "account.DeleteScheduled", query)
+	defer func() { endSpan(span, err) }()
+
+	var ids []object.AccountID
+	if err := r.db.SelectContext(ctx, &ids, query); err != nil {
+		return 0, err
+	}
+
+	var n int64
+	for _, id := range ids {
+		deleted, err := r.deleteScheduled(ctx, id)
+		if err != nil {
+			return n, fmt.Errorf("delete account %d: %w", id, err)
+		}
+		if deleted {
+			n++
+		}
+	}
+	setRowCount(span, n)
+
+	return n, nil
+}
+
+// 1 アカウント分を 1 トランザクションで削除する。
+// 取得してから削除するまでの間に予約が取り消された場合は何もしない
+func (r *account) deleteScheduled(ctx context.Context, id object.AccountID) (deleted bool, err error) {
+	err = withTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		var locked object.AccountID
+		err := tx.QueryRowxContext(ctx, `
+		SELECT id FROM account
+		WHERE id = ? AND delete_scheduled_at IS NOT NULL AND delete_scheduled_at <= NOW()
+		FOR UPDATE
+		`, id).Scan(&locked)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil
+			}
+			return err
+		}
+
+		for _, q := range accountDependents {
+			if _, err := tx.NamedExecContext(ctx, q, map[string]interface{}{"id": id}); err != nil {
+				return err
+			}
+		}
+		deleted = true
+		return nil
+	})
+	return deleted, err
+}
+
+// Find : モデレーター向けにアカウントを新しい順に取得する
+func (r *account) Find(ctx context.Context, opts repository.AccountOptions) (_ []*object.Account, err error) {
+	whereClauses := make([]string, 0)
+	args := make([]interface{}, 0)
+	if opts.Username != "" {
+		whereClauses = append(whereClauses, "username LIKE ?")
+		args = append(args, likePrefix(opts.Username))
+	}
+	if opts.Role != "" {
+		whereClauses = append(whereClauses, "role = ?")
+		args = append(args, opts.Role)
+	}
+	switch opts.Status {
+	case object.AccountStatusActive:
+		whereClauses = append(whereClauses, "silenced_at IS NULL", "suspended_at IS NULL")
+	case object.AccountStatusSilenced:
+		whereClauses = append(whereClauses, "silenced_at IS NOT NULL", "suspended_at IS NULL")
+	case object.AccountStatusSuspended:
+		whereClauses = append(whereClauses, "suspended_at IS NOT NULL")
+	}
+	if opts.IP != "" {
+		whereClauses = append(whereClauses, "id IN (SELECT account_id FROM account_ip WHERE ip = ?)")
+		args = append(args, opts.IP)
+	}
+	if opts.MaxID > 0 {
+		whereClauses = append(whereClauses, "id <= ?")
+		args = append(args, opts.MaxID)
+	}
+	if opts.SinceID > 0 {
+		whereClauses = append(whereClauses, "id >= ?")
+		args = append(args, opts.SinceID)
+	}
+	args = append(args, opts.Limit)
+
+	query := "SELECT * FROM account"
+	if len(whereClauses) > 0 {
+		query += " WHERE " + strings.Join(whereClauses, " AND ")
+	}
+	query += " ORDER BY id DESC LIMIT ?"
+	ctx, span := startSpan(ctx, "account.Find", query)
+	defer func() { endSpan(span, err) }()
+
+	accounts := make([]*object.Account, 0)
+	if err := r.db.SelectContext(ctx, &accounts, query, args...); err != nil {
+		return nil, err
+	}
+	setRowCount(span, int64(len(accounts)))
+
+	return accounts, nil
+}
+
+// SetRole : アカウントの権限を変更する
+func (r *account) SetRole(ctx context.Context, id object.AccountID, role object.Role, actorID object.AccountID) (err error) {
+	query := "UPDATE account SET role = ? WHERE id = ? AND role <> ?"
+	ctx, span := startSpan(ctx, "account.SetRole", query)
+	defer func() { endSpan(span, err) }()
+
+	return withTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		changed, err := moderateAccount(ctx, tx, id, query, role, id, role)
+		if err != nil || !changed {
+			return err
+		}
+		return addModerationLog(ctx, tx, actorID, object.ModerationActionChangeRole, object.ModerationTargetAccount, strconv.FormatInt(id, 10))
+	})
+}
+
+// モデレーターの操作ごとの更新。すでにその状態なら何も更新されない
+var accountActionQueries = map[object.AccountActionType]string{
+	object.AccountActionWarn:      "",
+	object.AccountActionSilence:   "UPDATE account SET silenced_at = NOW() WHERE id = ? AND silenced_at IS NULL",
+	object.AccountActionUnsilence: "UPDATE account SET silenced_at = NULL WHERE id = ? AND silenced_at IS NOT NULL",
+	object.AccountActionSuspend:   "UPDATE account SET suspended_at = NOW() WHERE id = ? AND suspended_at IS NULL",
+	object.AccountActionUnsuspend: "UPDATE account SET suspended_at = NULL WHERE id = ? AND suspended_at IS NOT NULL",
+	// 削除までの間にログインして削除を取り消せないよう、凍結もする
+	object.AccountActionDelete: "UPDATE account SET suspended_at = COALESCE(suspended_at, NOW()), delete_scheduled_at = NOW() WHERE id = ?",
+}
+
+// TakeAction : モデレーターの操作をアカウントに適用し、変化があれば記録する
+func (r *account) TakeAction(ctx context.Context, action *object.AccountAction) (err error) {
+	query, ok := accountActionQueries[action.Type]
+	if !ok {
+		return fmt.Errorf("unknown account action: %s", action.Type)
+	}
+	ctx, span := startSpan(ctx, "account.TakeAction", query)
+	defer func() { endSpan(span, err) }()
+
+	return withTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		changed, err := moderateAccount(ctx, tx, action.TargetAccountID, query, action.TargetAccountID)
+		if err != nil || !changed {
+			return err
+		}
+		if action.Warns() {
+			if _, err := tx.ExecContext(ctx,
+				"INSERT INTO account_warning (account_id, action, text) VALUES (?, ?, ?)",
+				action.TargetAccountID, action.Type, action.Text); err != nil {
+				return err
+			}
+		}
+		// 記録する操作名は "suspend_account" のように対象の種類を付ける
+		return addModerationLog(ctx, tx, action.ActorID, action.Type+"_account", object.ModerationTargetAccount, strconv.FormatInt(action.TargetAccountID, 10))
+	})
+}
+
+// アカウントを行ロックしてから更新し、変化があったかを返す。query が空なら更新せずに変化ありとする
+// 同じ値で更新すると RowsAffected が 0 になるので、存在は行ロックで確かめる
+func moderateAccount(ctx context.Context, tx *sqlx.Tx, id object.AccountID, query string, args ...interface{}) (bool, error) {
+	var locked object.AccountID
+	if err := tx.QueryRowxContext(ctx, "SELECT id FROM account WHERE id = ? FOR UPDATE", id).Scan(&locked); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, customerror.ErrNotFound
+		}
+		return false, err
+	}
+	if query == "" {
+		return true, nil
+	}
+
+	result, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// FindWarnings : アカウントへの警告を新しい順に取得する
+func (r *account) FindWarnings(ctx context.Context, id object.AccountID) (_ []*object.AccountWarning, err error) {
+	query := "SELECT * FROM account_warning WHERE account_id = ? ORDER BY id DESC"
+	ctx, span := startSpan(ctx, "account.FindWarnings", query)
+	defer func() { endSpan(span, err) }()
+
+	warnings := make([]*object.AccountWarning, 0)
+	if err := r.db.SelectContext(ctx, &warnings, query, id); err != nil {
+		return nil, err
+	}
+	setRowCount(span, int64(len(warnings)))
+
+	return warnings, nil
+}
+
+// AddIP : アカウントが使った IP アドレスを記録する
+func (r *account) AddIP(ctx context.Context, id object.AccountID, ip string) (err error) {
+	query := "INSERT INTO account_ip (account_id, ip) VALUES (?, ?) ON DUPLICATE KEY UPDATE last_used_at = NOW()"
+	ctx, span := startSpan(ctx, "account.AddIP", query)
+	defer func() { endSpan(span, err) }()
+
+	_, err = r.db.ExecContext(ctx, query, id, ip)
+	return err
+}
+
+// FindIPs : アカウントが使った IP アドレスを最近使った順に取得する
+func (r *account) FindIPs(ctx context.Context, id object.AccountID) (_ []*object.AccountIP, err error) {
+	query := "SELECT * FROM account_ip WHERE account_id = ? ORDER BY last_used_at DESC"
+	ctx, span := startSpan(ctx, "account.FindIPs", query)
+	defer func() { endSpan(span, err) }()
+
+	ips := make([]*object.AccountIP, 0)
+	if err := r.db.SelectContext(ctx, &ips, query, id); err != nil {
+		return nil, err
+	}
+	setRowCount(span, int64(len(ips)))
+
+	return ips, nil
+}
@@ -0,0 +1,117 @@
+package dao
+
+import (
+	"context"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/domain/repository"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type (
+	// Implementation for repository.Block
+	block struct {
+		db *sqlx.DB
+	}
+)
+
+// Create block repository
+func NewBlock(db *sqlx.DB) repository.Block {
+	return &block{db: db}
+}
+
+// Add : ブロックする。すでにブロックしている場合は何もしない
+// お互いのフォローとフォローリクエストも解除する
+func (r *block) Add(ctx context.Context, accountID, targetID object.AccountID) (err error) {
+	query := `
+	INSERT IGNORE INTO block (account_id, target_account_id)
+	VALUES (?, ?)
+	`
+	ctx, span := startSpan(ctx, "block.Add", query)
+	defer func() { endSpan(span, err) }()
+
+	return withTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		if _, err := tx.ExecContext(ctx, query, accountID, targetID); err != nil {
+			return err
+		}
+		for _, table := range []string{"follow", "follow_request"} {
+			_, err := tx.ExecContext(ctx,
+				"DELETE FROM "+table+" WHERE (account_id = ? AND target_account_id = ?) OR (account_id = ? AND target_account_id = ?)",
+				accountID, targetID, targetID, accountID,
+			)
+			if err != nil {
+				return err
+			}
+		}
+		// フォローが外れるので、お互いのリストからも外す
+		if err := removeFromLists(ctx, tx, accountID, targetID); err != nil {
+			return err
+		}
+		return removeFromLists(ctx, tx, targetID, accountID)
+	})
+}
+
+// Delete : ブロックを解除する
+func (r *block) Delete(ctx context.Context, accountID, targetID object.AccountID) (err error) {
+	query := `
+	DELETE FROM block
+	WHERE account_id = ? AND target_account_id = ?
+	`
+	ctx, span := startSpan(ctx, "block.Delete", query)
+	defer func() { endSpan(span, err) }()
+
+	_, err = r.db.ExecContext(ctx, query, accountID, targetID)
+	return err
+}
+
+// Exists : ブロックしているかを確認する
+func (r *block) Exists(ctx context.Context, accountID, targetID object.AccountID) (_ bool, err error) {
+	query := `
+	SELECT EXISTS(SELECT 1 FROM block WHERE account_id = ? AND target_account_id = ?)
+	`
+	ctx, span := startSpan(ctx, "block.Exists", query)
+	defer func() { endSpan(span, err) }()
+
+	var exists bool
+	if err := r.db.QueryRowxContext(ctx, query, accountID, targetID).Scan(&exists); err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+// FindBlockedAccounts : ブロックしているアカウントを新しい順に取得する
+func (r *block) FindBlockedAccounts(ctx context.Context, accountID object.AccountID, limit int64) (_ []*object.Account, err error) {
+	query := `
+	SELECT a.*
+	FROM block b
+	INNER JOIN account a ON b.target_account_id = a.id
+	WHERE b.account_id = ?
+	ORDER BY b.create_at DESC
+	LIMIT ?
+	`
+	ctx, span := startSpan(ctx, "block.FindBlockedAccounts", query)
+	defer func() { endSpan(span, err) }()
+
+	accounts := make([]*object.Account, 0)
+	if err := r.db.SelectContext(ctx, &accounts, query, accountID, limit); err != nil {
+		return nil, err
+	}
+	setRowCount(span, int64(len(accounts)))
+
+	return accounts, nil
+}
+
+// EachBlocked : ブロックしているアカウントを古い順に 1 件ずつ fn に渡す
+func (r *block) EachBlocked(ctx context.Context, accountID object.AccountID, fn func(*object.Account) error) (err error) {
+	query := `
+	SELECT a.*
+	FROM block b
+	INNER JOIN account a ON b.target_account_id = a.id
+	WHERE b.account_id = ?
+	ORDER BY b.create_at
+	`
+	ctx, span := startSpan(ctx, "block.EachBlocked", query)
+	defer func() { endSpan(span, err) }()
+
+	return eachAccount(ctx, span, r.db, query, accountID, fn)
+}
@@ -0,0 +1,105 @@
+package dao
+
+import (
+	"context"
+	"strings"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/domain/repository"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type (
+	// Implementation for repository.Bookmark
+	bookmark struct {
+		db *sqlx.DB
+	}
+)
+
+// Create bookmark repository
+func NewBookmark(db *sqlx.DB) repository.Bookmark {
+	return &bookmark{db: db}
+}
+
+// Add : ブックマークする。すでにブックマークしている場合は何もしない
+func (r *bookmark) Add(ctx context.Context, accountID object.AccountID, statusID object.StatusID) (err error) {
+	query := "INSERT IGNORE INTO bookmark (account_id, status_id) VALUES (?, ?)"
+	ctx, span := startSpan(ctx, "bookmark.Add", query)
+	defer func() { endSpan(span, err) }()
+
+	_, err = r.db.ExecContext(ctx, query, accountID, statusID)
+	return err
+}
+
+// Delete : ブックマークを外す
+func (r *bookmark) Delete(ctx context.Context, accountID object.AccountID, statusID object.StatusID) (err error) {
+	query := "DELETE FROM bookmark WHERE account_id = ? AND status_id = ?"
+	ctx, span := startSpan(ctx, "bookmark.Delete", query)
+	defer func() { endSpan(span, err) }()
+
+	_, err = r.db.ExecContext(ctx, query, accountID, statusID)
+	return err
+}
+
+// FindStatuses : ブックマークしたステータスを新しくブックマークした順に取得する
+// フォローを外して見られなくなった非公開の投稿や、ブロックしたアカウント・凍結されたアカウントの投稿は除く
+func (r *bookmark) FindStatuses(ctx context.Context, accountID object.AccountID, limit int64) (_ []*object.Status, err error) {
+	clause, visibleArgs := visibleCondition(accountID)
+	clauses, viewerArgs := blockFilter(accountID)
+	query := `
+	SELECT ` + statusColumns + `
+	FROM bookmark b
+	INNER JOIN status s ON b.status_id = s.id
+	INNER JOIN account a ON s.account_id = a.id
+	WHERE b.account_id = ? AND a.suspended_at IS NULL AND ` + clause + ` AND ` + strings.Join(clauses, " AND ") + `
+	ORDER BY b.id DESC
+	LIMIT ?
+	`
+	ctx, span := startSpan(ctx, "bookmark.FindStatuses", query)
+	defer func() { endSpan(span, err) }()
+
+	args := []interface{}{accountID}
+	args = append(args, visibleArgs...)
+	args = append(args, viewerArgs...)
+	args = append(args, limit)
+
+	rows, err := r.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	statuses, err := scanStatuses(rows)
+	if err != nil {
+		return nil, err
+	}
+	setRowCount(span, int64(len(statuses)))
+
+	return statuses, nil
+}
+
+// EachBookmarked : ブックマークしたステータスの ID を古い順に 1 件ずつ fn に渡す
+func (r *bookmark) EachBookmarked(ctx context.Context, accountID object.AccountID, fn func(object.StatusID) error) (err error) {
+	query := "SELECT status_id FROM bookmark WHERE account_id = ? ORDER BY id"
+	ctx, span := startSpan(ctx, "bookmark.EachBookmarked", query)
+	defer func() { endSpan(span, err) }()
+
+	rows, err := r.db.QueryxContext(ctx, query, accountID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var n int64
+	for rows.Next() {
+		var id object.StatusID
+		if err := rows.Scan(&id); err != nil {
+			return err
+		}
+		if err := fn(id); err != nil {
+			return err
+		}
+		n++
+	}
+	setRowCount(span, n)
+
+	return rows.Err()
+}
@@ -0,0 +1,121 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"yatter-backend-go/app/domain/customerror"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/domain/repository"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type (
+	// Implementation for repository.ContentRule
+	contentRule struct {
+		db *sqlx.DB
+	}
+)
+
+// Create content rule repository
+func NewContentRule(db *sqlx.DB) repository.ContentRule {
+	return &contentRule{db: db}
+}
+
+// Add : ルールを作成する
+func (r *contentRule) Add(ctx context.Context, rule *object.ContentRule, actorID object.AccountID) (_ object.ContentRuleID, err error) {
+	query := "INSERT INTO content_rule (type, value, action, max_account_age) VALUES (?, ?, ?, ?)"
+	ctx, span := startSpan(ctx, "contentRule.Add", query)
+	defer func() { endSpan(span, err) }()
+
+	var id object.ContentRuleID
+	err = withTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		result, err := tx.ExecContext(ctx, query, rule.Type, rule.Value, rule.Action, rule.MaxAccountAge)
+		if err != nil {
+			return err
+		}
+		if id, err = result.LastInsertId(); err != nil {
+			return err
+		}
+		return addModerationLog(ctx, tx, actorID, object.ModerationActionCreateContentRule, object.ModerationTargetContentRule, strconv.FormatInt(id, 10))
+	})
+	if err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// FindByID : ルールを取得する
+func (r *contentRule) FindByID(ctx context.Context, id object.ContentRuleID) (_ *object.ContentRule, err error) {
+	query := "SELECT * FROM content_rule WHERE id = ?"
+	ctx, span := startSpan(ctx, "contentRule.FindByID", query)
+	defer func() { endSpan(span, err) }()
+
+	rule := new(object.ContentRule)
+	if err := r.db.QueryRowxContext(ctx, query, id).StructScan(rule); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return rule, nil
+}
+
+// FindAll : すべてのルールを作成順に取得する
+func (r *contentRule) FindAll(ctx context.Context) (_ []*object.ContentRule, err error) {
+	query := "SELECT * FROM content_rule ORDER BY id"
+	ctx, span := startSpan(ctx, "contentRule.FindAll", query)
+	defer func() { endSpan(span, err) }()
+
+	rules := make([]*object.ContentRule, 0)
+	if err := r.db.SelectContext(ctx, &rules, query); err != nil {
+		return nil, err
+	}
+	setRowCount(span, int64(len(rules)))
+
+	return rules, nil
+}
+
+// Update : ルールを更新する
+func (r *contentRule) Update(ctx context.Context, rule *object.ContentRule, actorID object.AccountID) (err error) {
+	query := "UPDATE content_rule SET type = ?, value = ?, action = ?, max_account_age = ? WHERE id = ?"
+	ctx, span := startSpan(ctx, "contentRule.Update", query)
+	defer func() { endSpan(span, err) }()
+
+	return withTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		// 同じ値で更新すると RowsAffected が 0 になるので、存在は行ロックで確かめる
+		var locked object.ContentRuleID
+		if err := tx.QueryRowxContext(ctx, "SELECT id FROM content_rule WHERE id = ? FOR UPDATE", rule.ID).Scan(&locked); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return customerror.ErrNotFound
+			}
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, query, rule.Type, rule.Value, rule.Action, rule.MaxAccountAge, rule.ID); err != nil {
+			return err
+		}
+		return addModerationLog(ctx, tx, actorID, object.ModerationActionUpdateContentRule, object.ModerationTargetContentRule, strconv.FormatInt(rule.ID, 10))
+	})
+}
+
+// Delete : ルールを削除する。ルールによる通報の rule_ids はそのまま残す
+func (r *contentRule) Delete(ctx context.Context, id object.ContentRuleID, actorID object.AccountID) (err error) {
+	query := "DELETE FROM content_rule WHERE id = ?"
+	ctx, span := startSpan(ctx, "contentRule.Delete", query)
+	defer func() { endSpan(span, err) }()
+
+	return withTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		result, err := tx.ExecContext(ctx, query, id)
+		if err != nil {
+			return err
+		}
+		if affected, err := result.RowsAffected(); err != nil {
+			return err
+		} else if affected == 0 {
+			return customerror.ErrNotFound
+		}
+		return addModerationLog(ctx, tx, actorID, object.ModerationActionDeleteContentRule, object.ModerationTargetContentRule, strconv.FormatInt(id, 10))
+	})
+}
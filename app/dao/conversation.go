@@ -0,0 +1,231 @@
+package dao
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strconv"
+	"strings"
+	"yatter-backend-go/app/domain/customerror"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/domain/repository"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type (
+	// Implementation for repository.Conversation
+	conversation struct {
+		db *sqlx.DB
+	}
+)
+
+// Create conversation repository
+func NewConversation(db *sqlx.DB) repository.Conversation {
+	return &conversation{db: db}
+}
+
+// 会話の一覧の列。最後のステータスは削除されることがあるので毎回求める
+const conversationQuery = `
+	SELECT ca.conversation_id, ca.unread, MAX(s.id) AS last_status_id
+	FROM conversation_account ca
+	INNER JOIN status s ON s.conversation_id = ca.conversation_id
+	`
+
+// FindByAccount : アカウントの会話を新しいステータスがある順に取得する
+func (r *conversation) FindByAccount(ctx context.Context, accountID object.AccountID, opts repository.ConversationOptions) (_ []*object.Conversation, err error) {
+	having := make([]string, 0)
+	args := []interface{}{accountID}
+	if opts.MaxID > 0 {
+		having = append(having, "last_status_id <= ?")
+		args = append(args, opts.MaxID)
+	}
+	if opts.SinceID > 0 {
+		having = append(having, "last_status_id >= ?")
+		args = append(args, opts.SinceID)
+	}
+	args = append(args, opts.Limit)
+
+	query := conversationQuery + `
+	WHERE ca.account_id = ?
+	GROUP BY ca.conversation_id, ca.unread
+	`
+	if len(having) > 0 {
+		query += " HAVING " + strings.Join(having, " AND ")
+	}
+	query += " ORDER BY last_status_id DESC LIMIT ?"
+	ctx, span := startSpan(ctx, "conversation.FindByAccount", query)
+	defer func() { endSpan(span, err) }()
+
+	conversations := make([]*object.Conversation, 0)
+	if err := r.db.SelectContext(ctx, &conversations, query, args...); err != nil {
+		return nil, err
+	}
+	setRowCount(span, int64(len(conversations)))
+
+	if err := r.fill(ctx, conversations, accountID); err != nil {
+		return nil, err
+	}
+	return conversations, nil
+}
+
+// FindByID : アカウントの会話を取得する
+func (r *conversation) FindByID(ctx context.Context, id object.ConversationID, accountID object.AccountID) (_ *object.Conversation, err error) {
+	query := conversationQuery + `
+	WHERE ca.conversation_id = ? AND ca.account_id = ?
+	GROUP BY ca.conversation_id, ca.unread
+	`
+	ctx, span := startSpan(ctx, "conversation.FindByID", query)
+	defer func() { endSpan(span, err) }()
+
+	conversations := make([]*object.Conversation, 0)
+	if err := r.db.SelectContext(ctx, &conversations, query, id, accountID); err != nil {
+		return nil, err
+	}
+	if len(conversations) == 0 {
+		return nil, nil
+	}
+	if err := r.fill(ctx, conversations, accountID); err != nil {
+		return nil, err
+	}
+	return conversations[0], nil
+}
+
+// 会話に自分以外の参加者と最後のステータスを付ける
+func (r *conversation) fill(ctx context.Context, conversations []*object.Conversation, accountID object.AccountID) error {
+	if len(conversations) == 0 {
+		return nil
+	}
+	ids := make([]object.ConversationID, 0, len(conversations))
+	statusIDs := make([]object.StatusID, 0, len(conversations))
+	byID := make(map[object.ConversationID]*object.Conversation, len(conversations))
+	for _, c := range conversations {
+		ids = append(ids, c.ID)
+		statusIDs = append(statusIDs, c.LastStatusID)
+		byID[c.ID] = c
+		c.Accounts = make([]*object.Account, 0)
+	}
+
+	query, args, err := sqlx.In(`
+	SELECT cp.conversation_id, a.*
+	FROM conversation_participant cp
+	INNER JOIN account a ON cp.account_id = a.id
+	WHERE cp.conversation_id IN (?) AND cp.account_id <> ?
+	ORDER BY cp.conversation_id, a.id
+	`, ids, accountID)
+	if err != nil {
+		return err
+	}
+	var participants []struct {
+		ConversationID object.ConversationID `db:"conversation_id"`
+		object.Account
+	}
+	if err := r.db.SelectContext(ctx, &participants, query, args...); err != nil {
+		return err
+	}
+	for i := range participants {
+		p := &participants[i]
+		byID[p.ConversationID].Accounts = append(byID[p.ConversationID].Accounts, &p.Account)
+	}
+
+	// 凍結されたアカウントのステータスは最後のステータスとして見せない
+	query, args, err = sqlx.In(`
+	SELECT `+statusColumns+`
+	FROM status s
+	INNER JOIN account a ON s.account_id = a.id
+	WHERE s.id IN (?) AND a.suspended_at IS NULL
+	`, statusIDs)
+	if err != nil {
+		return err
+	}
+	rows, err := r.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	statuses, err := scanStatuses(rows)
+	if err != nil {
+		return err
+	}
+	byStatusID := make(map[object.StatusID]*object.Status, len(statuses))
+	for _, s := range statuses {
+		byStatusID[s.ID] = s
+	}
+	for _, c := range conversations {
+		c.LastStatus = byStatusID[c.LastStatusID]
+	}
+	return nil
+}
+
+// MarkAsRead : 会話を既読にする
+func (r *conversation) MarkAsRead(ctx context.Context, id object.ConversationID, accountID object.AccountID) (err error) {
+	query := "UPDATE conversation_account SET unread = FALSE WHERE conversation_id = ? AND account_id = ?"
+	ctx, span := startSpan(ctx, "conversation.MarkAsRead", query)
+	defer func() { endSpan(span, err) }()
+
+	_, err = r.db.ExecContext(ctx, query, id, accountID)
+	return err
+}
+
+// Delete : 会話を一覧から消す。他の参加者には影響せず、新しいステータスが届くと再び表示される
+func (r *conversation) Delete(ctx context.Context, id object.ConversationID, accountID object.AccountID) (err error) {
+	query := "DELETE FROM conversation_account WHERE conversation_id = ? AND account_id = ?"
+	ctx, span := startSpan(ctx, "conversation.Delete", query)
+	defer func() { endSpan(span, err) }()
+
+	result, err := r.db.ExecContext(ctx, query, id, accountID)
+	if err != nil {
+		return err
+	}
+	if affected, err := result.RowsAffected(); err != nil {
+		return err
+	} else if affected == 0 {
+		return customerror.ErrNotFound
+	}
+	return nil
+}
+
+// ダイレクトのステータスを投稿者と宛先が同じ会話に入れる
+// 投稿者は既読、宛先は未読にし、一覧から消していた参加者にも再び表示する
+func addToConversation(ctx context.Context, tx *sqlx.Tx, statusID object.StatusID, authorID object.AccountID, recipients []object.AccountID) error {
+	participants := append([]object.AccountID{authorID}, recipients...)
+	sort.Slice(participants, func(i, j int) bool { return participants[i] < participants[j] })
+
+	result, err := tx.ExecContext(ctx,
+		"INSERT INTO conversation (participant_key) VALUES (?) ON DUPLICATE KEY UPDATE id = LAST_INSERT_ID(id)",
+		participantKey(participants))
+	if err != nil {
+		return err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, "UPDATE status SET conversation_id = ? WHERE id = ?", id, statusID); err != nil {
+		return err
+	}
+	for _, p := range participants {
+		if _, err := tx.ExecContext(ctx,
+			"INSERT IGNORE INTO conversation_participant (conversation_id, account_id) VALUES (?, ?)", id, p); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO conversation_account (conversation_id, account_id, unread) VALUES (?, ?, ?)
+			ON DUPLICATE KEY UPDATE unread = VALUES(unread)
+			`, id, p, p != authorID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// 参加者の組を一意に表すキー。参加者は ID の昇順に並んでいること
+func participantKey(participants []object.AccountID) string {
+	ids := make([]string, 0, len(participants))
+	for _, p := range participants {
+		ids = append(ids, strconv.FormatInt(p, 10))
+	}
+	sum := sha256.Sum256([]byte(strings.Join(ids, ",")))
+	return hex.EncodeToString(sum[:])
+}
@@ -1,6 +1,8 @@
 package dao
 
 import (
+	"context"
+	"database/sql"
 	"fmt"
 	"io/ioutil"
 	"log"
@@ -19,11 +21,77 @@ type (
 		// Get status repository
 		Status() repository.Status
 
+		// Get block repository
+		Block() repository.Block
+
+		// Get mute repository
+		Mute() repository.Mute
+
+		// Get follow repository
+		Follow() repository.Follow
+
+		// Get follow request repository
+		FollowRequest() repository.FollowRequest
+
+		// Get export repository
+		Export() repository.Export
+
+		// Get import repository
+		Import() repository.Import
+
+		// Get search repository
+		Search() repository.Search
+
+		// Get trend repository
+		Trend() repository.Trend
+
+		// Get poll repository
+		Poll() repository.Poll
+
+		// Get notification repository
+		Notification() repository.Notification
+
+		// Get scheduled status repository
+		ScheduledStatus() repository.ScheduledStatus
+
+		// Get bookmark repository
+		Bookmark() repository.Bookmark
+
+		// Get pin repository
+		Pin() repository.Pin
+
+		// Get list repository
+		List() repository.List
+
+		// Get filter repository
+		Filter() repository.Filter
+
+		// Get conversation repository
+		Conversation() repository.Conversation
+
+		// Get report repository
+		Report() repository.Report
+
+		// Get moderation log repository
+		ModerationLog() repository.ModerationLog
+
+		// Get content rule repository
+		ContentRule() repository.ContentRule
+
 		// Clear all data in DB
 		InitAll() error
 
 		// Setup Test DB data
 		SetupTestDB() error
+
+		// Close DB connections
+		Close() error
+
+		// Check DB connection is alive
+		Ping(ctx context.Context) error
+
+		// Get connection pool statistics
+		Stats() sql.DBStats
 	}
 
 	// Implementation for DAO
@@ -50,6 +118,94 @@ func (d *dao) Status() repository.Status {
 	return NewStatus(d.db)
 }
 
+func (d *dao) Block() repository.Block {
+	return NewBlock(d.db)
+}
+
+func (d *dao) Mute() repository.Mute {
+	return NewMute(d.db)
+}
+
+func (d *dao) Follow() repository.Follow {
+	return NewFollow(d.db)
+}
+
+func (d *dao) FollowRequest() repository.FollowRequest {
+	return NewFollowRequest(d.db)
+}
+
+func (d *dao) Export() repository.Export {
+	return NewExport(d.db)
+}
+
+func (d *dao) Import() repository.Import {
+	return NewImport(d.db)
+}
+
+func (d *dao) Search() repository.Search {
+	return NewSearch(d.db)
+}
+
+func (d *dao) Trend() repository.Trend {
+	return NewTrend(d.db)
+}
+
+func (d *dao) Poll() repository.Poll {
+	return NewPoll(d.db)
+}
+
+func (d *dao) Notification() repository.Notification {
+	return NewNotification(d.db)
+}
+
+func (d *dao) ScheduledStatus() repository.ScheduledStatus {
+	return NewScheduledStatus(d.db)
+}
+
+func (d *dao) Bookmark() repository.Bookmark {
+	return NewBookmark(d.db)
+}
+
+func (d *dao) Pin() repository.Pin {
+	return NewPin(d.db)
+}
+
+func (d *dao) List() repository.List {
+	return NewList(d.db)
+}
+
+func (d *dao) Filter() repository.Filter {
+	return NewFilter(d.db)
+}
+
+func (d *dao) Conversation() repository.Conversation {
+	return NewConversation(d.db)
+}
+
+func (d *dao) Report() repository.Report {
+	return NewReport(d.db)
+}
+
+func (d *dao) ModerationLog() repository.ModerationLog {
+	return NewModerationLog(d.db)
+}
+
+func (d *dao) ContentRule() repository.ContentRule {
+	return NewContentRule(d.db)
+}
+
+func (d *dao) Ping(ctx context.Context) error {
+	return d.db.PingContext(ctx)
+}
+
+func (d *dao) Stats() sql.DBStats {
+	return d.db.Stats()
+}
+
+func (d *dao) Close() error {
+	return d.db.Close()
+}
+
 // 外部キー制約を無効にしてから、テーブルを削除してる
 func (d *dao) InitAll() error {
 	if err := d.exec("SET FOREIGN_KEY_CHECKS=0"); err != nil {
@@ -63,7 +219,7 @@ func (d *dao) InitAll() error {
 		}
 	}()
 
-	for _, table := range []string{"account", "status"} {
+	for _, table := range []string{"account", "status", "tag", "status_tag", "block", "mute", "follow", "follow_request", "export", "account_import", "account_import_error", "trend_tag", "trend_status", "poll", "poll_option", "poll_vote", "notification", "scheduled_status", "bookmark", "status_pin", "list", "list_account", "filter", "filter_keyword", "status_mention", "conversation", "conversation_participant", "conversation_account", "report", "report_status", "moderation_log", "account_ip", "account_warning", "content_rule"} {
 		if err := d.exec("TRUNCATE TABLE " + table); err != nil {
 			return fmt.Errorf("Can't truncate table "+table+": %w", err)
 		}
@@ -5,7 +5,9 @@ import (
 	"errors"
 	"testing"
 	"time"
+	"yatter-backend-go/app/domain/customerror"
 	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/domain/repository"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/jmoiron/sqlx"
@@ -115,6 +117,67 @@ func TestAccount_Add(t *testing.T) {
 	})
 }
 
+func TestAccount_DeleteScheduled(t *testing.T) {
+	db, mock := setup(t)
+	defer db.Close()
+
+	ctx := context.Background()
+
+	mock.ExpectQuery("(?s)SELECT id FROM account.+delete_scheduled_at <= NOW\\(\\)").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+
+	// 1 は削除される
+	mock.ExpectBegin()
+	mock.ExpectQuery("(?s)SELECT id FROM account.+WHERE id = \\?.+FOR UPDATE").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectExec("DELETE .*FROM notification ").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	// 投票の集計を差し引いてから投票を消す
+	mock.ExpectExec("(?s)UPDATE poll_option o.+FROM poll_vote WHERE account_id = \\?.+SET o.votes_count = o.votes_count - v.votes").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("(?s)UPDATE poll p.+FROM poll_vote WHERE account_id = \\?.+SET p.votes_count = p.votes_count - v.votes, p.voters_count = p.voters_count - 1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	for _, table := range []string{"poll_vote", "scheduled_status", "bookmark", "status_pin", "status_mention", "conversation_account", "conversation_participant", "list_account", "list", "filter", "account_ip", "account_warning", "status", "follow", "follow_request", "block", "mute", "report", "export", "account_import_error", "account_import", "account"} {
+		mock.ExpectExec("DELETE .*FROM " + table + " ").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+	mock.ExpectCommit()
+
+	// 2 は直前に取り消された
+	mock.ExpectBegin()
+	mock.ExpectQuery("(?s)SELECT id FROM account.+WHERE id = \\?.+FOR UPDATE").
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectCommit()
+
+	accountRepo := NewAccount(db)
+	n, err := accountRepo.DeleteScheduled(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAccount_FindByPrefix(t *testing.T) {
+	db, mock := setup(t)
+	defer db.Close()
+
+	ctx := context.Background()
+
+	rows := sqlmock.NewRows([]string{"id", "username", "password_hash", "display_name", "avatar", "header", "note", "create_at"}).
+		AddRow(2, "john", "passwordhash", nil, nil, nil, nil, time.Now())
+	// 凍結されたアカウントは補完に出さない
+	mock.ExpectQuery("(?s)SELECT id FROM account WHERE username LIKE \\?.+UNION.+SELECT id FROM account WHERE display_name LIKE \\?.+WHERE a.suspended_at IS NULL AND f.account_id IS NOT NULL.+ORDER BY f.account_id IS NULL").
+		WithArgs(`jo\%%`, `jo\%%`, 1, 1, 1, 5).
+		WillReturnRows(rows)
+
+	accountRepo := NewAccount(db)
+	accounts, err := accountRepo.FindByPrefix(ctx, "jo%", repository.AccountPrefixOptions{ViewerID: 1, Following: true, Limit: 5})
+	assert.NoError(t, err)
+	assert.Len(t, accounts, 1)
+	assert.Equal(t, "john", accounts[0].Username)
+}
+
 // Status
 func TestStatus_FindWithAccountByID(t *testing.T) {
 	t.Run("found", func(t *testing.T) {
@@ -139,10 +202,11 @@ func TestStatus_FindWithAccountByID(t *testing.T) {
 		statusCreatedAt, _ := time.Parse("2006-01-02 15:04:05", "2023-01-01 00:00:00")
 		accountCreatedAt, _ := time.Parse("2006-01-02 15:04:05", "2023-01-01 00:00:00")
 		// クエリ結果として返されるモック行をセットアップする
-		rows := sqlmock.NewRows([]string{"s.id", "s.content", "status_create_at", "a.id", "a.username", "a.password_hash", "a.display_name", "a.avatar", "a.header", "a.note", "account_create_at"}).
-			AddRow(expectedStatus.ID, expectedStatus.Content, statusCreatedAt, expectedStatus.Account.ID, expectedStatus.Account.Username, expectedStatus.Account.PasswordHash, *expectedStatus.Account.DisplayName, expectedStatus.Account.Avatar, expectedStatus.Account.Header, *expectedStatus.Account.Note, accountCreatedAt)
+		rows := sqlmock.NewRows([]string{"s.id", "s.content", "s.visibility", "s.spoiler_text", "s.sensitive", "status_create_at", "a.id", "a.username", "a.password_hash", "a.display_name", "a.avatar", "a.header", "a.note", "a.locked", "account_create_at"}).
+			AddRow(expectedStatus.ID, expectedStatus.Content, object.VisibilityPublic, "", false, statusCreatedAt, expectedStatus.Account.ID, expectedStatus.Account.Username, expectedStatus.Account.PasswordHash, *expectedStatus.Account.DisplayName, expectedStatus.Account.Avatar, expectedStatus.Account.Header, *expectedStatus.Account.Note, false, accountCreatedAt)
 
-		mock.ExpectQuery("SELECT (.+) FROM status s INNER JOIN account a ON s.account_id = a.id WHERE s.id = ?").
+		// 凍結されたアカウントのステータスは取得しない
+		mock.ExpectQuery("SELECT (.+) FROM status s INNER JOIN account a ON s.account_id = a.id WHERE s.id = \\? AND a.suspended_at IS NULL").
 			WithArgs(1).
 			WillReturnRows(rows)
 
@@ -176,6 +240,52 @@ func TestStatus_FindWithAccountByID(t *testing.T) {
 	})
 }
 
+func TestStatus_FindVisibleByID(t *testing.T) {
+	// statusColumns と同じ並び
+	columns := []string{"s.id", "s.content", "s.visibility", "s.spoiler_text", "s.sensitive", "s.create_at", "a.id", "a.username", "a.display_name", "a.avatar", "a.header", "a.note", "a.locked", "a.create_at"}
+	// 公開範囲に加えて、投稿者が凍結されているか、どちらかがブロックしている場合は取得できない
+	const query = "(?s)WHERE s.id = \\? AND .+s.visibility = 'direct'.+AND a.suspended_at IS NULL.+FROM block WHERE account_id = \\?.+FROM block WHERE target_account_id = \\?"
+
+	t.Run("found", func(t *testing.T) {
+		db, mock := setup(t)
+		defer db.Close()
+
+		createdAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+		mock.ExpectQuery(query).
+			WithArgs(int64(1), int64(2), int64(2), int64(2), int64(2), int64(2)).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(1, "Hello, world!", object.VisibilityPublic, "", false, createdAt, 3, "john", "John", nil, nil, nil, false, createdAt))
+
+		statusRepo := NewStatus(db)
+		status, err := statusRepo.FindVisibleByID(context.Background(), 1, 2)
+		assert.NoError(t, err)
+		if assert.NotNil(t, status) {
+			assert.Equal(t, object.StatusID(1), status.ID)
+			assert.Equal(t, "Hello, world!", status.Content)
+			assert.Equal(t, object.VisibilityPublic, status.Visibility)
+			assert.Equal(t, object.AccountID(3), status.Account.ID)
+			assert.Equal(t, "john", status.Account.Username)
+			assert.Equal(t, "John", *status.Account.DisplayName)
+		}
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("notfound", func(t *testing.T) {
+		db, mock := setup(t)
+		defer db.Close()
+
+		mock.ExpectQuery(query).
+			WithArgs(int64(1), int64(2), int64(2), int64(2), int64(2), int64(2)).
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		statusRepo := NewStatus(db)
+		status, err := statusRepo.FindVisibleByID(context.Background(), 1, 2)
+		assert.NoError(t, err)
+		assert.Nil(t, status)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
 func TestStatus_Add(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		db, mock := setup(t)
@@ -191,9 +301,11 @@ func TestStatus_Add(t *testing.T) {
 			},
 		}
 
-		mock.ExpectExec("(?i)INSERT INTO status \\(account_id, content\\) VALUES \\(\\?, \\?\\)").
-			WithArgs(expectedStatus.Account.ID, expectedStatus.Content).
+		mock.ExpectBegin()
+		mock.ExpectExec("(?i)INSERT INTO status \\(account_id, content, visibility, spoiler_text, sensitive\\) VALUES \\(\\?, \\?, \\?, \\?, \\?\\)").
+			WithArgs(expectedStatus.Account.ID, expectedStatus.Content, object.VisibilityPublic, "", false).
 			WillReturnResult(sqlmock.NewResult(expectedStatus.ID, 1))
+		mock.ExpectCommit()
 
 		statusRepo := NewStatus(db)
 
@@ -207,6 +319,45 @@ func TestStatus_Add(t *testing.T) {
 		assert.Equal(t, expectedStatus.ID, id)
 	})
 
+	t.Run("direct", func(t *testing.T) {
+		db, mock := setup(t)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		// メンションしたアカウントと同じ会話に入れ、宛先だけ未読にする
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO status").
+			WithArgs(2, "@john @nobody hi", object.VisibilityDirect, "", false).
+			WillReturnResult(sqlmock.NewResult(10, 1))
+		mock.ExpectQuery("(?s)SELECT id FROM account WHERE username IN \\(\\?, \\?\\) AND id <> \\?.+FROM block WHERE target_account_id = \\?").
+			WithArgs("john", "nobody", 2, 2).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		mock.ExpectExec("INSERT INTO status_mention").WithArgs(10, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO conversation \\(participant_key\\)").
+			WithArgs(participantKey([]object.AccountID{1, 2})).
+			WillReturnResult(sqlmock.NewResult(5, 1))
+		mock.ExpectExec("UPDATE status SET conversation_id = \\? WHERE id = \\?").WithArgs(5, 10).WillReturnResult(sqlmock.NewResult(0, 1))
+		for _, p := range []struct {
+			id     object.AccountID
+			unread bool
+		}{{1, true}, {2, false}} {
+			mock.ExpectExec("INSERT IGNORE INTO conversation_participant").WithArgs(5, p.id).WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectExec("INSERT INTO conversation_account").WithArgs(5, p.id, p.unread).WillReturnResult(sqlmock.NewResult(0, 1))
+		}
+		mock.ExpectCommit()
+
+		statusRepo := NewStatus(db)
+		id, err := statusRepo.Add(ctx, &object.Status{
+			Account:    &object.Account{ID: 2},
+			Content:    "@john @nobody hi",
+			Visibility: object.VisibilityDirect,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, object.StatusID(10), id)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
 	t.Run("query error", func(t *testing.T) {
 		db, mock := setup(t)
 		defer db.Close()
@@ -221,13 +372,46 @@ func TestStatus_Add(t *testing.T) {
 			},
 		}
 
-		mock.ExpectExec("(?i)INSERT INTO status \\(account_id, content\\) VALUES \\(\\?, \\?\\)").
-			WithArgs(status.Account.ID, status.Content).
+		mock.ExpectBegin()
+		mock.ExpectExec("(?i)INSERT INTO status \\(account_id, content, visibility, spoiler_text, sensitive\\) VALUES \\(\\?, \\?, \\?, \\?, \\?\\)").
+			WithArgs(status.Account.ID, status.Content, object.VisibilityPublic, "", false).
 			WillReturnError(errors.New("content is empty"))
+		mock.ExpectRollback()
 
 		id, err := statusRepo.Add(ctx, status)
 		assert.Error(t, err)
 		assert.Equal(t, int64(0), id)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("with hashtags", func(t *testing.T) {
+		db, mock := setup(t)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("(?i)INSERT INTO status").
+			WithArgs(1, "#Go と #ゴー", object.VisibilityPublic, "", false).
+			WillReturnResult(sqlmock.NewResult(5, 1))
+		for i, name := range []string{"go", "ゴー"} {
+			mock.ExpectExec("INSERT INTO tag \\(name\\) VALUES \\(\\?\\) ON DUPLICATE KEY UPDATE id = LAST_INSERT_ID\\(id\\)").
+				WithArgs(name).
+				WillReturnResult(sqlmock.NewResult(int64(10+i), 1))
+			mock.ExpectExec("INSERT IGNORE INTO status_tag \\(status_id, tag_id\\) VALUES \\(\\?, \\?\\)").
+				WithArgs(5, 10+i).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+		}
+		mock.ExpectCommit()
+
+		statusRepo := NewStatus(db)
+		id, err := statusRepo.Add(ctx, &object.Status{
+			Account: &object.Account{ID: 1},
+			Content: "#Go と #ゴー",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5), id)
+		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 }
 
@@ -267,13 +451,13 @@ func TestStatus_FindPublicTimelines(t *testing.T) {
 	}
 	statusCreatedAt, _ := time.Parse("2006-01-02 15:04:05", "2023-01-01 00:00:00")
 	accountCreatedAt, _ := time.Parse("2006-01-02 15:04:05", "2023-01-01 00:00:00")
-	rows := sqlmock.NewRows([]string{"s.id", "s.content", "status_create_at", "a.id", "a.username", "a.password_hash", "a.display_name", "a.avatar", "a.header", "a.note", "account_create_at"}).
-		AddRow(expectedStatus.ID, expectedStatus.Content, statusCreatedAt, expectedStatus.Account.ID, expectedStatus.Account.Username, expectedStatus.Account.PasswordHash, *expectedStatus.Account.DisplayName, expectedStatus.Account.Avatar, expectedStatus.Account.Header, *expectedStatus.Account.Note, accountCreatedAt)
+	rows := sqlmock.NewRows([]string{"s.id", "s.content", "s.visibility", "s.spoiler_text", "s.sensitive", "status_create_at", "a.id", "a.username", "a.password_hash", "a.display_name", "a.avatar", "a.header", "a.note", "a.locked", "account_create_at"}).
+		AddRow(expectedStatus.ID, expectedStatus.Content, object.VisibilityPublic, "", false, statusCreatedAt, expectedStatus.Account.ID, expectedStatus.Account.Username, expectedStatus.Account.PasswordHash, *expectedStatus.Account.DisplayName, expectedStatus.Account.Avatar, expectedStatus.Account.Header, *expectedStatus.Account.Note, false, accountCreatedAt)
 	mock.ExpectQuery("^SELECT (.+) FROM status s INNER JOIN account a ON s.account_id = a.id").
 		WithArgs(sqlmock.AnyArg()).
 		WillReturnRows(rows)
 
-	statuses, err := statusRepo.FindPublicTimelines(ctx, false, 0, 0, 40)
+	statuses, err := statusRepo.FindPublicTimelines(ctx, repository.TimelineOptions{Limit: 40})
 	assert.NoError(t, err)
 	assert.NotNil(t, statuses)
 	assert.Len(t, statuses, 1)
@@ -290,6 +474,139 @@ func TestStatus_FindPublicTimelines(t *testing.T) {
 	assert.Equal(t, *expectedStatus.Account.Note, *status.Account.Note)
 }
 
+func TestStatus_FindPublicTimelines_Tag(t *testing.T) {
+	db, mock := setup(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	statusRepo := NewStatus(db)
+
+	mock.ExpectQuery("(?s)FROM status s INNER JOIN account a ON s.account_id = a.id.+INNER JOIN tag t ON st.tag_id = t.id WHERE t.name = \\?").
+		WithArgs("golang", int64(40)).
+		WillReturnRows(sqlmock.NewRows([]string{"s.id", "s.content", "s.visibility", "s.spoiler_text", "s.sensitive", "status_create_at", "a.id", "a.username", "a.password_hash", "a.display_name", "a.avatar", "a.header", "a.note", "a.locked", "account_create_at"}).
+			AddRow(1, "#GoLang", object.VisibilityPublic, "ネタバレ", true, time.Now(), 1, "john", "passwordhash", nil, nil, nil, nil, false, time.Now()))
+
+	statuses, err := statusRepo.FindPublicTimelines(ctx, repository.TimelineOptions{Tag: "GoLang", Limit: 40})
+	assert.NoError(t, err)
+	assert.Len(t, statuses, 1)
+	assert.Equal(t, "ネタバレ", statuses[0].SpoilerText)
+	assert.True(t, statuses[0].Sensitive)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStatus_FindPublicTimelines_Viewer(t *testing.T) {
+	db, mock := setup(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	statusRepo := NewStatus(db)
+
+	// ブロック・ミュートしているアカウントを除外する条件が付く
+	mock.ExpectQuery("(?s)FROM status s INNER JOIN account a ON s.account_id = a.id.+FROM block.+FROM block.+FROM mute").
+		WithArgs(int64(1), int64(1), int64(1), int64(40)).
+		WillReturnRows(sqlmock.NewRows([]string{"s.id", "s.content", "s.visibility", "s.spoiler_text", "s.sensitive", "status_create_at", "a.id", "a.username", "a.password_hash", "a.display_name", "a.avatar", "a.header", "a.note", "a.locked", "account_create_at"}))
+
+	statuses, err := statusRepo.FindPublicTimelines(ctx, repository.TimelineOptions{ViewerID: 1, Limit: 40})
+	assert.NoError(t, err)
+	assert.Len(t, statuses, 0)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStatus_FindPublicTimelines_List(t *testing.T) {
+	db, mock := setup(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	statusRepo := NewStatus(db)
+
+	// 公開範囲は public に限らず、閲覧者が見られるものになる
+	mock.ExpectQuery("(?s)FROM status s INNER JOIN account a ON s.account_id = a.id WHERE \\(s.visibility IN \\('public', 'unlisted'\\).+FROM list_account WHERE list_id = \\?.+FROM block.+FROM block.+FROM mute").
+		WithArgs(int64(1), int64(1), int64(1), int64(3), int64(1), int64(1), int64(1), int64(40)).
+		WillReturnRows(sqlmock.NewRows([]string{"s.id", "s.content", "s.visibility", "s.spoiler_text", "s.sensitive", "status_create_at", "a.id", "a.username", "a.password_hash", "a.display_name", "a.avatar", "a.header", "a.note", "a.locked", "account_create_at"}).
+			AddRow(1, "followers only", object.VisibilityPrivate, "", false, time.Now(), 2, "john", "passwordhash", nil, nil, nil, nil, false, time.Now()))
+
+	statuses, err := statusRepo.FindPublicTimelines(ctx, repository.TimelineOptions{ViewerID: 1, ListID: 3, Limit: 40})
+	assert.NoError(t, err)
+	assert.Len(t, statuses, 1)
+	assert.Equal(t, object.VisibilityPrivate, statuses[0].Visibility)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// Block
+func TestBlock_Exists(t *testing.T) {
+	db, mock := setup(t)
+	defer db.Close()
+
+	ctx := context.Background()
+
+	mock.ExpectQuery("(?i)SELECT EXISTS\\(SELECT 1 FROM block WHERE account_id = \\? AND target_account_id = \\?\\)").
+		WithArgs(1, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+	blockRepo := NewBlock(db)
+	exists, err := blockRepo.Exists(ctx, 1, 2)
+	assert.NoError(t, err)
+	assert.True(t, exists)
+}
+
+func TestBlock_FindBlockedAccounts(t *testing.T) {
+	db, mock := setup(t)
+	defer db.Close()
+
+	ctx := context.Background()
+
+	rows := sqlmock.NewRows([]string{"id", "username", "password_hash", "display_name", "avatar", "header", "note", "create_at"}).
+		AddRow(2, "blocked", "passwordhash", nil, nil, nil, nil, time.Now())
+	mock.ExpectQuery("(?s)SELECT a.\\* FROM block b INNER JOIN account a ON b.target_account_id = a.id.+WHERE b.account_id = \\?").
+		WithArgs(1, 40).
+		WillReturnRows(rows)
+
+	blockRepo := NewBlock(db)
+	accounts, err := blockRepo.FindBlockedAccounts(ctx, 1, 40)
+	assert.NoError(t, err)
+	assert.Len(t, accounts, 1)
+	assert.Equal(t, "blocked", accounts[0].Username)
+}
+
+// Mute
+func TestMute_Find(t *testing.T) {
+	t.Run("found", func(t *testing.T) {
+		db, mock := setup(t)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		rows := sqlmock.NewRows([]string{"account_id", "target_account_id", "hide_notifications", "expires_at", "create_at"}).
+			AddRow(1, 2, true, nil, time.Now())
+		mock.ExpectQuery("(?s)SELECT m.\\* FROM mute m WHERE m.account_id = \\? AND m.target_account_id = \\? AND \\(m.expires_at IS NULL").
+			WithArgs(1, 2).
+			WillReturnRows(rows)
+
+		muteRepo := NewMute(db)
+		mute, err := muteRepo.Find(ctx, 1, 2)
+		assert.NoError(t, err)
+		assert.NotNil(t, mute)
+		assert.True(t, mute.HideNotifications)
+		assert.Nil(t, mute.ExpiresAt)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		db, mock := setup(t)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectQuery("(?s)SELECT m.\\* FROM mute m").
+			WithArgs(1, 2).
+			WillReturnRows(sqlmock.NewRows([]string{"account_id", "target_account_id", "hide_notifications", "expires_at", "create_at"}))
+
+		muteRepo := NewMute(db)
+		mute, err := muteRepo.Find(ctx, 1, 2)
+		assert.NoError(t, err)
+		assert.Nil(t, mute)
+	})
+}
+
 // Utils
 func setup(t *testing.T) (*sqlx.DB, sqlmock.Sqlmock) {
 	rawDb, mock, err := sqlmock.New()
@@ -304,3 +621,813 @@ func setup(t *testing.T) (*sqlx.DB, sqlmock.Sqlmock) {
 func toPtr(s string) *string {
 	return &s
 }
+
+// FollowRequest
+func TestFollowRequest_Authorize(t *testing.T) {
+	t.Run("authorized", func(t *testing.T) {
+		db, mock := setup(t)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("(?s)DELETE FROM follow_request.+WHERE account_id = \\? AND target_account_id = \\?").
+			WithArgs(1, 2).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT IGNORE INTO follow \\(account_id, target_account_id\\) VALUES \\(\\?, \\?\\)").
+			WithArgs(1, 2).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		followRequestRepo := NewFollowRequest(db)
+		err := followRequestRepo.Authorize(ctx, 1, 2)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not requested", func(t *testing.T) {
+		db, mock := setup(t)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("(?s)DELETE FROM follow_request").
+			WithArgs(1, 2).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		followRequestRepo := NewFollowRequest(db)
+		err := followRequestRepo.Authorize(ctx, 1, 2)
+		assert.ErrorIs(t, err, customerror.ErrNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+// Export
+func TestExport_ClaimPending(t *testing.T) {
+	columns := []string{"id", "account_id", "state", "claimed_at", "token", "path", "expires_at", "create_at"}
+
+	t.Run("claimed", func(t *testing.T) {
+		db, mock := setup(t)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		// 実行中のまま 1 時間を過ぎたものも対象にする
+		mock.ExpectQuery("SELECT \\* FROM export WHERE \\(state = \\? OR \\(state = \\? AND claimed_at <= NOW\\(\\) - INTERVAL \\? SECOND\\)\\) ORDER BY id LIMIT 1").
+			WithArgs("pending", "running", int64(3600)).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(3, 1, "running", time.Now().Add(-2*time.Hour), nil, nil, nil, time.Now()))
+		mock.ExpectExec("UPDATE export SET state = \\?, claimed_at = NOW\\(\\) WHERE id = \\? AND \\(state = \\? OR \\(state = \\? AND claimed_at <= NOW\\(\\) - INTERVAL \\? SECOND\\)\\)").
+			WithArgs("running", 3, "pending", "running", int64(3600)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		exportRepo := NewExport(db)
+		exp, err := exportRepo.ClaimPending(ctx, time.Hour)
+		assert.NoError(t, err)
+		assert.NotNil(t, exp)
+		assert.Equal(t, int64(3), exp.ID)
+		assert.Equal(t, object.ExportStateRunning, exp.State)
+	})
+
+	t.Run("taken by another worker", func(t *testing.T) {
+		db, mock := setup(t)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectQuery("SELECT \\* FROM export").
+			WithArgs("pending", "running", int64(3600)).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(3, 1, "pending", nil, nil, nil, nil, time.Now()))
+		mock.ExpectExec("UPDATE export SET state").
+			WithArgs("running", 3, "pending", "running", int64(3600)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		exportRepo := NewExport(db)
+		exp, err := exportRepo.ClaimPending(ctx, time.Hour)
+		assert.NoError(t, err)
+		assert.Nil(t, exp)
+	})
+}
+
+// Search
+func TestSearch_Statuses(t *testing.T) {
+	columns := []string{"id", "content", "visibility", "spoiler_text", "sensitive", "status_create_at", "account_id", "username", "display_name", "avatar", "header", "note", "locked", "account_create_at"}
+
+	t.Run("anonymous", func(t *testing.T) {
+		db, mock := setup(t)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectQuery("(?s)WHERE MATCH \\(s.content\\) AGAINST \\(\\? IN BOOLEAN MODE\\) AND a.suspended_at IS NULL AND s.visibility IN \\('public', 'unlisted'\\) ORDER BY s.id DESC LIMIT \\? OFFSET \\?").
+			WithArgs(`"ピタ ゴラ "`, 20, 0).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(1, "ピタ ゴラ スイッチ", "public", "", false, time.Now(), 2, "john", nil, nil, nil, nil, false, time.Now()))
+
+		searchRepo := NewSearch(db)
+		statuses, err := searchRepo.Statuses(ctx, `ピタ ゴラ"`, repository.SearchOptions{Limit: 20})
+		assert.NoError(t, err)
+		assert.Len(t, statuses, 1)
+		assert.Equal(t, "john", statuses[0].Account.Username)
+	})
+
+	t.Run("viewer", func(t *testing.T) {
+		db, mock := setup(t)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectQuery("(?s)s.visibility = 'private' AND s.account_id IN \\(SELECT target_account_id FROM follow WHERE account_id = \\?\\).+NOT IN \\(SELECT target_account_id FROM block WHERE account_id = \\?\\)").
+			WithArgs(`"go"`, 1, 1, 1, 1, 1, 1, 20, 40).
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		searchRepo := NewSearch(db)
+		statuses, err := searchRepo.Statuses(ctx, "go", repository.SearchOptions{ViewerID: 1, Offset: 40, Limit: 20})
+		assert.NoError(t, err)
+		assert.Empty(t, statuses)
+	})
+}
+
+func TestSearch_Hashtags(t *testing.T) {
+	db, mock := setup(t)
+	defer db.Close()
+
+	ctx := context.Background()
+
+	mock.ExpectQuery("(?s)SELECT id, name FROM tag.+WHERE name LIKE \\?").
+		WithArgs(`go\_l%`, 20, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "go_lang"))
+
+	searchRepo := NewSearch(db)
+	tags, err := searchRepo.Hashtags(ctx, "#Go_L", repository.SearchOptions{Limit: 20})
+	assert.NoError(t, err)
+	assert.Len(t, tags, 1)
+	assert.Equal(t, "go_lang", tags[0].Name)
+}
+
+// Trend
+func TestTrend_ReplaceTags(t *testing.T) {
+	db, mock := setup(t)
+	defer db.Close()
+
+	ctx := context.Background()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM trend_tag").WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec("INSERT INTO trend_tag").
+		WithArgs(1, 2.5, 1, 4, 3, 2, 0.5, 0, 2, 2).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	trendRepo := NewTrend(db)
+	err := trendRepo.ReplaceTags(ctx, []*object.TagTrend{
+		{TagID: 1, Name: "go", Score: 2.5, UsesHour: 1, UsesDay: 4, Accounts: 3},
+		{TagID: 2, Name: "mysql", Score: 0.5, UsesHour: 0, UsesDay: 2, Accounts: 2},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTrend_SetTagTrendable(t *testing.T) {
+	t.Run("found", func(t *testing.T) {
+		db, mock := setup(t)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT EXISTS\\(SELECT 1 FROM tag WHERE name = \\?\\)").
+			WithArgs("golang").
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		mock.ExpectExec("UPDATE tag SET trendable = \\? WHERE name = \\?").
+			WithArgs(true, "golang").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO moderation_log").
+			WithArgs(9, "approve_trend", "tag", "golang").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		trendRepo := NewTrend(db)
+		assert.NoError(t, trendRepo.SetTagTrendable(context.Background(), "GoLang", true, 9))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		db, mock := setup(t)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT EXISTS\\(SELECT 1 FROM tag WHERE name = \\?\\)").
+			WithArgs("golang").
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+		mock.ExpectRollback()
+
+		trendRepo := NewTrend(db)
+		err := trendRepo.SetTagTrendable(context.Background(), "golang", false, 9)
+		assert.ErrorIs(t, err, customerror.ErrNotFound)
+	})
+}
+
+// Poll
+func TestPoll_Vote(t *testing.T) {
+	lockQuery := "SELECT closed_at IS NOT NULL OR expires_at <= NOW\\(\\) FROM poll WHERE id = \\? FOR UPDATE"
+	votedQuery := "SELECT EXISTS\\(SELECT 1 FROM poll_vote WHERE poll_id = \\? AND account_id = \\?\\)"
+
+	t.Run("success", func(t *testing.T) {
+		db, mock := setup(t)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockQuery).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"expired"}).AddRow(false))
+		mock.ExpectQuery(votedQuery).WithArgs(1, 2).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+		for _, choice := range []int{0, 2} {
+			mock.ExpectExec("INSERT INTO poll_vote").WithArgs(1, 2, choice).WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectExec("UPDATE poll_option SET votes_count = votes_count \\+ 1").WithArgs(1, choice).WillReturnResult(sqlmock.NewResult(0, 1))
+		}
+		mock.ExpectExec("UPDATE poll SET votes_count = votes_count \\+ \\?, voters_count = voters_count \\+ 1").
+			WithArgs(2, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		pollRepo := NewPoll(db)
+		assert.NoError(t, pollRepo.Vote(context.Background(), 1, 2, []int{0, 2}))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("already voted", func(t *testing.T) {
+		db, mock := setup(t)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockQuery).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"expired"}).AddRow(false))
+		mock.ExpectQuery(votedQuery).WithArgs(1, 2).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		mock.ExpectRollback()
+
+		pollRepo := NewPoll(db)
+		err := pollRepo.Vote(context.Background(), 1, 2, []int{0})
+		assert.ErrorIs(t, err, customerror.ErrAlreadyVoted)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		db, mock := setup(t)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockQuery).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"expired"}).AddRow(true))
+		mock.ExpectRollback()
+
+		pollRepo := NewPoll(db)
+		err := pollRepo.Vote(context.Background(), 1, 2, []int{0})
+		assert.ErrorIs(t, err, customerror.ErrPollExpired)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPoll_CloseExpired(t *testing.T) {
+	db, mock := setup(t)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT id FROM poll WHERE closed_at IS NULL AND expires_at <= NOW\\(\\)").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+
+	// 1 件目は通知まで行い、2 件目は他のワーカーが先に終了させている
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE poll SET closed_at = NOW\\(\\) WHERE id = \\? AND closed_at IS NULL").
+		WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("(?s)INSERT INTO notification.+FROM poll_vote.+UNION ALL").
+		WithArgs(object.NotificationTypePoll, 1, object.NotificationTypePoll, 1).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE poll SET closed_at = NOW\\(\\) WHERE id = \\? AND closed_at IS NULL").
+		WithArgs(2).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	pollRepo := NewPoll(db)
+	n, err := pollRepo.CloseExpired(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// ScheduledStatus
+func TestScheduledStatus_PublishDue(t *testing.T) {
+	db, mock := setup(t)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT id FROM scheduled_status WHERE scheduled_at <= NOW\\(\\)").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+
+	lockQuery := "SELECT \\* FROM scheduled_status WHERE id = \\? AND scheduled_at <= NOW\\(\\) FOR UPDATE"
+
+	columns := []string{"id", "account_id", "scheduled_at", "params", "report_id", "create_at"}
+
+	// 1 件目を投稿する。予約時の通報 7 にステータスを結び付ける
+	mock.ExpectBegin()
+	mock.ExpectQuery(lockQuery).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(1, 3, time.Now(), []byte(`{"text":"#Go","visibility":"unlisted","spoiler_text":"","sensitive":false,"poll":null}`), 7, time.Now()))
+	mock.ExpectExec("INSERT INTO status").
+		WithArgs(3, "#Go", object.VisibilityUnlisted, "", false).
+		WillReturnResult(sqlmock.NewResult(10, 1))
+	mock.ExpectExec("INSERT INTO tag").WithArgs("go").WillReturnResult(sqlmock.NewResult(20, 1))
+	mock.ExpectExec("INSERT IGNORE INTO status_tag").WithArgs(10, 20).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT IGNORE INTO report_status \\(report_id, status_id\\) VALUES \\(\\?, \\?\\)").WithArgs(7, 10).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM scheduled_status WHERE id = \\?").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	// 2 件目は他のプロセスが先に投稿している
+	mock.ExpectBegin()
+	mock.ExpectQuery(lockQuery).
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows(columns))
+	mock.ExpectCommit()
+
+	scheduledRepo := NewScheduledStatus(db)
+	n, err := scheduledRepo.PublishDue(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestScheduledStatus_FindByAccount(t *testing.T) {
+	db, mock := setup(t)
+	defer db.Close()
+
+	columns := []string{"id", "account_id", "scheduled_at", "params", "report_id", "create_at"}
+	mock.ExpectQuery("SELECT \\* FROM scheduled_status WHERE account_id = \\? AND id <= \\? AND id >= \\? ORDER BY id DESC LIMIT \\?").
+		WithArgs(1, 30, 10, 20).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(30, 1, time.Now(), []byte(`{"text":"a"}`), nil, time.Now()).
+			AddRow(12, 1, time.Now(), []byte(`{"text":"b"}`), nil, time.Now()))
+
+	scheduledRepo := NewScheduledStatus(db)
+	statuses, err := scheduledRepo.FindByAccount(context.Background(), 1, repository.ScheduledStatusOptions{MaxID: 30, SinceID: 10, Limit: 20})
+	assert.NoError(t, err)
+	if assert.Len(t, statuses, 2) {
+		assert.Equal(t, object.ScheduledStatusID(30), statuses[0].ID)
+		assert.Equal(t, object.ScheduledStatusID(12), statuses[1].ID)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPin_Add(t *testing.T) {
+	lockQuery := "SELECT id FROM account WHERE id = \\? FOR UPDATE"
+	pinnedQuery := "SELECT EXISTS\\(SELECT 1 FROM status_pin WHERE status_id = \\?\\)"
+	countQuery := "SELECT COUNT\\(\\*\\) FROM status_pin WHERE account_id = \\?"
+
+	t.Run("success", func(t *testing.T) {
+		db, mock := setup(t)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockQuery).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		mock.ExpectQuery(pinnedQuery).WithArgs(2).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+		mock.ExpectQuery(countQuery).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(4))
+		mock.ExpectExec("INSERT IGNORE INTO status_pin").WithArgs(1, 2).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		pinRepo := NewPin(db)
+		assert.NoError(t, pinRepo.Add(context.Background(), 1, 2, 5))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("already pinned", func(t *testing.T) {
+		db, mock := setup(t)
+		defer db.Close()
+
+		// 上限に達していても、ピン留め済みのステータスはエラーにしない
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockQuery).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		mock.ExpectQuery(pinnedQuery).WithArgs(2).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		mock.ExpectCommit()
+
+		pinRepo := NewPin(db)
+		assert.NoError(t, pinRepo.Add(context.Background(), 1, 2, 5))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("too many pins", func(t *testing.T) {
+		db, mock := setup(t)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockQuery).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		mock.ExpectQuery(pinnedQuery).WithArgs(2).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+		mock.ExpectQuery(countQuery).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(5))
+		mock.ExpectRollback()
+
+		pinRepo := NewPin(db)
+		err := pinRepo.Add(context.Background(), 1, 2, 5)
+		assert.ErrorIs(t, err, customerror.ErrTooManyPins)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestStatus_SetViewerFlags(t *testing.T) {
+	db, mock := setup(t)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT status_id, 'bookmark' AS kind FROM bookmark").
+		WithArgs(1, 10, 11, 1, 10, 11).
+		WillReturnRows(sqlmock.NewRows([]string{"status_id", "kind"}).
+			AddRow(11, "bookmark").
+			AddRow(10, "pin"))
+
+	own := &object.Status{ID: 10, Account: &object.Account{ID: 1}}
+	other := &object.Status{ID: 11, Account: &object.Account{ID: 2}}
+
+	statusRepo := NewStatus(db)
+	err := statusRepo.SetViewerFlags(context.Background(), []*object.Status{own, other}, 1)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	assert.False(t, *own.Bookmarked)
+	assert.True(t, *own.Pinned)
+	assert.True(t, *other.Bookmarked)
+	// 他人のステータスのピン留めは返さない
+	assert.Nil(t, other.Pinned)
+}
+
+func TestFollow_Delete(t *testing.T) {
+	db, mock := setup(t)
+	defer db.Close()
+
+	// フォローを外したアカウントは自分のリストからも外れる
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM follow").WithArgs(1, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("(?s)DELETE la FROM list_account la.+WHERE l.account_id = \\? AND la.account_id = \\?").
+		WithArgs(1, 2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	followRepo := NewFollow(db)
+	assert.NoError(t, followRepo.Delete(context.Background(), 1, 2))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestList_AddAccounts(t *testing.T) {
+	db, mock := setup(t)
+	defer db.Close()
+
+	// フォローしているアカウントだけが挿入される
+	mock.ExpectExec("(?s)INSERT IGNORE INTO list_account \\(list_id, account_id\\).+FROM follow.+target_account_id IN \\(\\?, \\?\\)").
+		WithArgs(3, 1, 2, 4).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	listRepo := NewList(db)
+	err := listRepo.AddAccounts(context.Background(), &object.List{ID: 3, AccountID: 1}, []object.AccountID{2, 4})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFilter_Add(t *testing.T) {
+	db, mock := setup(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO filter \\(account_id, title, context, filter_action, expires_at\\)").
+		WithArgs(1, "spoilers", `["home","public"]`, object.FilterActionWarn, nil).
+		WillReturnResult(sqlmock.NewResult(5, 1))
+	mock.ExpectExec("INSERT INTO filter_keyword").
+		WithArgs(5, "ネタバレ", false, 5, "finale", true).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	filterRepo := NewFilter(db)
+	id, err := filterRepo.Add(context.Background(), &object.Filter{
+		AccountID:    1,
+		Title:        "spoilers",
+		Context:      object.FilterContexts{object.FilterContextHome, object.FilterContextPublic},
+		FilterAction: object.FilterActionWarn,
+		Keywords: []*object.FilterKeyword{
+			{Keyword: "ネタバレ", WholeWord: false},
+			{Keyword: "finale", WholeWord: true},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, object.FilterID(5), id)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFilter_FindActive(t *testing.T) {
+	db, mock := setup(t)
+	defer db.Close()
+
+	mock.ExpectQuery("(?s)SELECT \\* FROM filter.+JSON_CONTAINS\\(context, JSON_QUOTE\\(\\?\\)\\).+expires_at > NOW\\(\\)").
+		WithArgs(1, object.FilterContextPublic).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "title", "context", "filter_action", "expires_at", "create_at"}).
+			AddRow(5, 1, "spoilers", `["public"]`, object.FilterActionHide, nil, time.Now()))
+	mock.ExpectQuery("SELECT \\* FROM filter_keyword WHERE filter_id IN \\(\\?\\)").
+		WithArgs(5).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "filter_id", "keyword", "whole_word"}).
+			AddRow(7, 5, "finale", true))
+
+	filterRepo := NewFilter(db)
+	filters, err := filterRepo.FindActive(context.Background(), 1, object.FilterContextPublic)
+	assert.NoError(t, err)
+	assert.Len(t, filters, 1)
+	assert.Equal(t, object.FilterContexts{object.FilterContextPublic}, filters[0].Context)
+	assert.Nil(t, filters[0].ExpiresAt)
+	assert.Len(t, filters[0].Keywords, 1)
+	assert.Equal(t, "finale", filters[0].Keywords[0].Keyword)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestConversation_FindByID(t *testing.T) {
+	db, mock := setup(t)
+	defer db.Close()
+
+	mock.ExpectQuery("(?s)FROM conversation_account ca.+WHERE ca.conversation_id = \\? AND ca.account_id = \\?").
+		WithArgs(5, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"conversation_id", "unread", "last_status_id"}).AddRow(5, true, 10))
+	mock.ExpectQuery("(?s)FROM conversation_participant cp.+WHERE cp.conversation_id IN \\(\\?\\) AND cp.account_id <> \\?").
+		WithArgs(5, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"conversation_id", "id", "username", "password_hash", "display_name", "avatar", "header", "note", "create_at"}).
+			AddRow(5, 2, "john", "passwordhash", nil, nil, nil, nil, time.Now()))
+	// 最後のステータスの投稿者が凍結されていれば付けない
+	mock.ExpectQuery("(?s)FROM status s.+WHERE s.id IN \\(\\?\\) AND a.suspended_at IS NULL").
+		WithArgs(10).
+		WillReturnRows(sqlmock.NewRows([]string{"s.id", "s.content", "s.visibility", "s.spoiler_text", "s.sensitive", "s.create_at", "a.id", "a.username", "a.display_name", "a.avatar", "a.header", "a.note", "a.locked", "a.create_at"}))
+
+	conversationRepo := NewConversation(db)
+	conversation, err := conversationRepo.FindByID(context.Background(), 5, 1)
+	assert.NoError(t, err)
+	if assert.NotNil(t, conversation) {
+		assert.Len(t, conversation.Accounts, 1)
+		assert.Nil(t, conversation.LastStatus)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestConversation_Delete(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		db, mock := setup(t)
+		defer db.Close()
+
+		mock.ExpectExec("DELETE FROM conversation_account WHERE conversation_id = \\? AND account_id = \\?").
+			WithArgs(5, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		conversationRepo := NewConversation(db)
+		assert.NoError(t, conversationRepo.Delete(context.Background(), 5, 1))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		db, mock := setup(t)
+		defer db.Close()
+
+		mock.ExpectExec("DELETE FROM conversation_account").
+			WithArgs(5, 1).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		conversationRepo := NewConversation(db)
+		err := conversationRepo.Delete(context.Background(), 5, 1)
+		assert.ErrorIs(t, err, customerror.ErrNotFound)
+	})
+}
+
+// Bookmark
+func TestBookmark_FindStatuses(t *testing.T) {
+	db, mock := setup(t)
+	defer db.Close()
+
+	createdAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	// 凍結されたアカウントとブロックしている・されているアカウントのステータスは除く
+	mock.ExpectQuery("(?s)FROM bookmark b.+WHERE b.account_id = \\? AND a.suspended_at IS NULL AND .+FROM block WHERE account_id = \\?.+FROM block WHERE target_account_id = \\?.+ORDER BY b.id DESC").
+		WithArgs(1, 1, 1, 1, 1, 1, 20).
+		WillReturnRows(sqlmock.NewRows([]string{"s.id", "s.content", "s.visibility", "s.spoiler_text", "s.sensitive", "s.create_at", "a.id", "a.username", "a.display_name", "a.avatar", "a.header", "a.note", "a.locked", "a.create_at"}).
+			AddRow(3, "Hello", object.VisibilityPublic, "", false, createdAt, 2, "john", nil, nil, nil, nil, false, createdAt))
+
+	bookmarkRepo := NewBookmark(db)
+	statuses, err := bookmarkRepo.FindStatuses(context.Background(), 1, 20)
+	assert.NoError(t, err)
+	if assert.Len(t, statuses, 1) {
+		assert.Equal(t, object.StatusID(3), statuses[0].ID)
+		assert.Equal(t, "john", statuses[0].Account.Username)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// Report
+func TestReport_Add(t *testing.T) {
+	db, mock := setup(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO report \\(account_id, target_account_id, category, comment, rule_action, rule_ids, action_taken_at\\)").
+		WithArgs(1, 2, "spam", "ads", nil, nil, nil).
+		WillReturnResult(sqlmock.NewResult(7, 1))
+	mock.ExpectExec("INSERT IGNORE INTO report_status \\(report_id, status_id\\) VALUES \\(\\?, \\?\\),\\(\\?, \\?\\)").
+		WithArgs(7, 10, 7, 11).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	reporterID, targetID := object.AccountID(1), object.AccountID(2)
+	reportRepo := NewReport(db)
+	id, err := reportRepo.Add(context.Background(), &object.Report{
+		AccountID:       &reporterID,
+		TargetAccountID: &targetID,
+		Category:        object.ReportCategorySpam,
+		Comment:         "ads",
+		StatusIDs:       []object.StatusID{10, 11},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, object.ReportID(7), id)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReport_Resolve(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		db, mock := setup(t)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT id FROM report WHERE id = \\? FOR UPDATE").
+			WithArgs(7).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+		mock.ExpectExec("UPDATE report SET action_taken_by_account_id = \\?, action_taken_at = NOW\\(\\) WHERE id = \\? AND action_taken_at IS NULL").
+			WithArgs(9, 7).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO moderation_log").
+			WithArgs(9, "resolve_report", "report", "7").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		reportRepo := NewReport(db)
+		assert.NoError(t, reportRepo.Resolve(context.Background(), 7, 9))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	// すでに対応済みなら記録しない
+	t.Run("already resolved", func(t *testing.T) {
+		db, mock := setup(t)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT id FROM report WHERE id = \\? FOR UPDATE").
+			WithArgs(7).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+		mock.ExpectExec("UPDATE report SET action_taken_by_account_id").
+			WithArgs(9, 7).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		reportRepo := NewReport(db)
+		assert.NoError(t, reportRepo.Resolve(context.Background(), 7, 9))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		db, mock := setup(t)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT id FROM report WHERE id = \\? FOR UPDATE").
+			WithArgs(7).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectRollback()
+
+		reportRepo := NewReport(db)
+		err := reportRepo.Resolve(context.Background(), 7, 9)
+		assert.ErrorIs(t, err, customerror.ErrNotFound)
+	})
+}
+
+func TestAccount_TakeAction(t *testing.T) {
+	t.Run("suspend", func(t *testing.T) {
+		db, mock := setup(t)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT id FROM account WHERE id = \\? FOR UPDATE").
+			WithArgs(2).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+		mock.ExpectExec("UPDATE account SET suspended_at = NOW\\(\\) WHERE id = \\? AND suspended_at IS NULL").
+			WithArgs(2).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO account_warning \\(account_id, action, text\\)").
+			WithArgs(2, "suspend", "spam").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("INSERT INTO moderation_log").
+			WithArgs(9, "suspend_account", "account", "2").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		accountRepo := NewAccount(db)
+		err := accountRepo.TakeAction(context.Background(), &object.AccountAction{
+			Type:            object.AccountActionSuspend,
+			TargetAccountID: 2,
+			ActorID:         9,
+			Text:            "spam",
+		})
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	// 取り消しは警告として残さず、すでに取り消されていれば記録もしない
+	t.Run("unsuspend", func(t *testing.T) {
+		db, mock := setup(t)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT id FROM account WHERE id = \\? FOR UPDATE").
+			WithArgs(2).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+		mock.ExpectExec("UPDATE account SET suspended_at = NULL").
+			WithArgs(2).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		accountRepo := NewAccount(db)
+		err := accountRepo.TakeAction(context.Background(), &object.AccountAction{
+			Type:            object.AccountActionUnsuspend,
+			TargetAccountID: 2,
+			ActorID:         9,
+		})
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		db, mock := setup(t)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT id FROM account WHERE id = \\? FOR UPDATE").
+			WithArgs(2).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectRollback()
+
+		accountRepo := NewAccount(db)
+		err := accountRepo.TakeAction(context.Background(), &object.AccountAction{
+			Type:            object.AccountActionWarn,
+			TargetAccountID: 2,
+			ActorID:         9,
+			Text:            "spam",
+		})
+		assert.ErrorIs(t, err, customerror.ErrNotFound)
+	})
+}
+
+func TestAccount_Find(t *testing.T) {
+	db, mock := setup(t)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT \\* FROM account WHERE username LIKE \\? AND role = \\? AND suspended_at IS NOT NULL AND id IN \\(SELECT account_id FROM account_ip WHERE ip = \\?\\) ORDER BY id DESC LIMIT \\?").
+		WithArgs("jo%", "user", "192.0.2.1", 40).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "username", "role"}).AddRow(2, "john", "user"))
+
+	accountRepo := NewAccount(db)
+	accounts, err := accountRepo.Find(context.Background(), repository.AccountOptions{
+		Username: "jo",
+		Role:     object.RoleUser,
+		Status:   object.AccountStatusSuspended,
+		IP:       "192.0.2.1",
+		Limit:    40,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, accounts, 1)
+	assert.Equal(t, "john", accounts[0].Username)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// ContentRule
+func TestContentRule_Delete(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		db, mock := setup(t)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("DELETE FROM content_rule WHERE id = \\?").
+			WithArgs(3).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO moderation_log").
+			WithArgs(9, "delete_content_rule", "content_rule", "3").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		contentRuleRepo := NewContentRule(db)
+		assert.NoError(t, contentRuleRepo.Delete(context.Background(), 3, 9))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		db, mock := setup(t)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("DELETE FROM content_rule WHERE id = \\?").
+			WithArgs(3).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		contentRuleRepo := NewContentRule(db)
+		err := contentRuleRepo.Delete(context.Background(), 3, 9)
+		assert.ErrorIs(t, err, customerror.ErrNotFound)
+	})
+}
@@ -1,9 +1,13 @@
 package dao
 
 import (
+	"context"
 	"fmt"
+	"strings"
+	"yatter-backend-go/app/domain/object"
 
 	"github.com/jmoiron/sqlx"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Interface of configureation
@@ -21,3 +25,54 @@ func initDb(config DBConfig) (*sqlx.DB, error) {
 
 	return db, nil
 }
+
+// Run fn in a transaction
+//
+// fn がエラーを返した場合はロールバックし、そうでなければコミットする
+func withTx(ctx context.Context, db *sqlx.DB, fn func(tx *sqlx.Tx) error) error {
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	if err := fn(tx); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("%w (rollback failed: %v)", err, rbErr)
+		}
+		return err
+	}
+	return tx.Commit()
+}
+
+// Build LIKE pattern matching strings which start with q
+//
+// q に含まれる % や _ はワイルドカードとして扱わない
+func likePrefix(q string) string {
+	r := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return r.Replace(q) + "%"
+}
+
+// Pass accounts returned by query to fn one by one
+//
+// 結果をスライスに溜めずに、行を読みながら fn を呼ぶ
+func eachAccount(ctx context.Context, span trace.Span, db *sqlx.DB, query string, accountID object.AccountID, fn func(*object.Account) error) error {
+	rows, err := db.QueryxContext(ctx, query, accountID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var n int64
+	for rows.Next() {
+		entity := new(object.Account)
+		if err := rows.StructScan(entity); err != nil {
+			return err
+		}
+		if err := fn(entity); err != nil {
+			return err
+		}
+		n++
+	}
+	setRowCount(span, n)
+
+	return rows.Err()
+}
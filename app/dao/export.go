@@ -0,0 +1,155 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/domain/repository"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type (
+	// Implementation for repository.Export
+	export struct {
+		db *sqlx.DB
+	}
+)
+
+// Create export repository
+func NewExport(db *sqlx.DB) repository.Export {
+	return &export{db: db}
+}
+
+// Add : エクスポートを受け付ける
+func (r *export) Add(ctx context.Context, accountID object.AccountID) (_ object.ExportID, err error) {
+	query := "INSERT INTO export (account_id, state) VALUES (?, ?)"
+	ctx, span := startSpan(ctx, "export.Add", query)
+	defer func() { endSpan(span, err) }()
+
+	result, err := r.db.ExecContext(ctx, query, accountID, object.ExportStatePending)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+// FindByAccount : アカウントのエクスポートを新しい順に取得する
+func (r *export) FindByAccount(ctx context.Context, accountID object.AccountID, limit int64) (_ []*object.Export, err error) {
+	query := `
+	SELECT * FROM export
+	WHERE account_id = ?
+	ORDER BY id DESC
+	LIMIT ?
+	`
+	ctx, span := startSpan(ctx, "export.FindByAccount", query)
+	defer func() { endSpan(span, err) }()
+
+	exports := make([]*object.Export, 0)
+	if err := r.db.SelectContext(ctx, &exports, query, accountID, limit); err != nil {
+		return nil, err
+	}
+	setRowCount(span, int64(len(exports)))
+
+	return exports, nil
+}
+
+// FindByToken : ダウンロード用のトークンからエクスポートを取得する
+func (r *export) FindByToken(ctx context.Context, token string) (_ *object.Export, err error) {
+	query := "SELECT * FROM export WHERE token = ?"
+	ctx, span := startSpan(ctx, "export.FindByToken", query)
+	defer func() { endSpan(span, err) }()
+
+	entity := new(object.Export)
+	if err := r.db.QueryRowxContext(ctx, query, token).StructScan(entity); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("%w", err)
+	}
+	return entity, nil
+}
+
+// 待ち状態か、実行中のまま timeout を過ぎたエクスポート。プロセスが落ちて実行中のまま残ったものを拾い直す
+const claimableExportCondition = "(state = ? OR (state = ? AND claimed_at <= NOW() - INTERVAL ? SECOND))"
+
+// ClaimPending : 一番古い待ち状態のエクスポートを実行中にして取得する
+// 実行中のまま timeout を過ぎたものも取り直す。他のプロセスに先を越された場合は nil を返す
+func (r *export) ClaimPending(ctx context.Context, timeout time.Duration) (_ *object.Export, err error) {
+	query := "SELECT * FROM export WHERE " + claimableExportCondition + " ORDER BY id LIMIT 1"
+	ctx, span := startSpan(ctx, "export.ClaimPending", query)
+	defer func() { endSpan(span, err) }()
+
+	seconds := int64(timeout / time.Second)
+	entity := new(object.Export)
+	if err := r.db.QueryRowxContext(ctx, query, object.ExportStatePending, object.ExportStateRunning, seconds).StructScan(entity); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	// 条件をもう一度確かめて更新し、同時に取得しようとした他のプロセスとの競合を防ぐ
+	result, err := r.db.ExecContext(ctx,
+		"UPDATE export SET state = ?, claimed_at = NOW() WHERE id = ? AND "+claimableExportCondition,
+		object.ExportStateRunning, entity.ID, object.ExportStatePending, object.ExportStateRunning, seconds,
+	)
+	if err != nil {
+		return nil, err
+	}
+	affectedRows, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if affectedRows == 0 {
+		return nil, nil
+	}
+	entity.State = object.ExportStateRunning
+
+	return entity, nil
+}
+
+// Complete : エクスポートを完了にする
+func (r *export) Complete(ctx context.Context, id object.ExportID, path, token string, expiresAt time.Time) (err error) {
+	query := "UPDATE export SET state = ?, path = ?, token = ?, expires_at = ? WHERE id = ?"
+	ctx, span := startSpan(ctx, "export.Complete", query)
+	defer func() { endSpan(span, err) }()
+
+	_, err = r.db.ExecContext(ctx, query, object.ExportStateDone, path, token, expiresAt, id)
+	return err
+}
+
+// Fail : エクスポートを失敗にする
+func (r *export) Fail(ctx context.Context, id object.ExportID) (err error) {
+	query := "UPDATE export SET state = ? WHERE id = ?"
+	ctx, span := startSpan(ctx, "export.Fail", query)
+	defer func() { endSpan(span, err) }()
+
+	_, err = r.db.ExecContext(ctx, query, object.ExportStateFailed, id)
+	return err
+}
+
+// DeleteExpired : ダウンロード期限を過ぎたエクスポートを削除する
+func (r *export) DeleteExpired(ctx context.Context) (_ int64, err error) {
+	query := `
+	DELETE FROM export
+	WHERE expires_at IS NOT NULL AND expires_at <= NOW()
+	`
+	ctx, span := startSpan(ctx, "export.DeleteExpired", query)
+	defer func() { endSpan(span, err) }()
+
+	result, err := r.db.ExecContext(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	setRowCount(span, n)
+
+	return n, nil
+}
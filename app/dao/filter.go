@@ -0,0 +1,187 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"yatter-backend-go/app/domain/customerror"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/domain/repository"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type (
+	// Implementation for repository.Filter
+	filter struct {
+		db *sqlx.DB
+	}
+)
+
+// Create filter repository
+func NewFilter(db *sqlx.DB) repository.Filter {
+	return &filter{db: db}
+}
+
+// Add : フィルターをキーワードと共に作成する
+func (r *filter) Add(ctx context.Context, f *object.Filter) (_ object.FilterID, err error) {
+	query := "INSERT INTO filter (account_id, title, context, filter_action, expires_at) VALUES (?, ?, ?, ?, ?)"
+	ctx, span := startSpan(ctx, "filter.Add", query)
+	defer func() { endSpan(span, err) }()
+
+	var id object.FilterID
+	err = withTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		result, err := tx.ExecContext(ctx, query, f.AccountID, f.Title, f.Context, f.FilterAction, f.ExpiresAt)
+		if err != nil {
+			return err
+		}
+		if id, err = result.LastInsertId(); err != nil {
+			return err
+		}
+		return addKeywords(ctx, tx, id, f.Keywords)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// フィルターにキーワードを登録する
+func addKeywords(ctx context.Context, tx *sqlx.Tx, id object.FilterID, keywords []*object.FilterKeyword) error {
+	for _, k := range keywords {
+		k.FilterID = id
+	}
+	if len(keywords) == 0 {
+		return nil
+	}
+	_, err := tx.NamedExecContext(ctx,
+		"INSERT INTO filter_keyword (filter_id, keyword, whole_word) VALUES (:filter_id, :keyword, :whole_word)", keywords)
+	return err
+}
+
+// FindByID : フィルターをキーワードと共に取得する
+func (r *filter) FindByID(ctx context.Context, id object.FilterID) (_ *object.Filter, err error) {
+	query := "SELECT * FROM filter WHERE id = ?"
+	ctx, span := startSpan(ctx, "filter.FindByID", query)
+	defer func() { endSpan(span, err) }()
+
+	entity := new(object.Filter)
+	if err := r.db.QueryRowxContext(ctx, query, id).StructScan(entity); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("%w", err)
+	}
+	if err := r.fillKeywords(ctx, []*object.Filter{entity}); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
+
+// FindByAccount : アカウントのフィルターを作成した順にキーワードと共に取得する
+func (r *filter) FindByAccount(ctx context.Context, accountID object.AccountID) (_ []*object.Filter, err error) {
+	query := "SELECT * FROM filter WHERE account_id = ? ORDER BY id"
+	ctx, span := startSpan(ctx, "filter.FindByAccount", query)
+	defer func() { endSpan(span, err) }()
+
+	filters := make([]*object.Filter, 0)
+	if err := r.db.SelectContext(ctx, &filters, query, accountID); err != nil {
+		return nil, err
+	}
+	setRowCount(span, int64(len(filters)))
+
+	if err := r.fillKeywords(ctx, filters); err != nil {
+		return nil, err
+	}
+	return filters, nil
+}
+
+// FindActive : 期限切れでなく、指定した場所に適用するフィルターをキーワードと共に取得する
+func (r *filter) FindActive(ctx context.Context, accountID object.AccountID, filterContext object.FilterContext) (_ []*object.Filter, err error) {
+	query := `
+	SELECT * FROM filter
+	WHERE account_id = ?
+		AND JSON_CONTAINS(context, JSON_QUOTE(?))
+		AND (expires_at IS NULL OR expires_at > NOW())
+	ORDER BY id
+	`
+	ctx, span := startSpan(ctx, "filter.FindActive", query)
+	defer func() { endSpan(span, err) }()
+
+	filters := make([]*object.Filter, 0)
+	if err := r.db.SelectContext(ctx, &filters, query, accountID, filterContext); err != nil {
+		return nil, err
+	}
+	setRowCount(span, int64(len(filters)))
+
+	if err := r.fillKeywords(ctx, filters); err != nil {
+		return nil, err
+	}
+	return filters, nil
+}
+
+// フィルターにキーワードを付ける
+func (r *filter) fillKeywords(ctx context.Context, filters []*object.Filter) error {
+	if len(filters) == 0 {
+		return nil
+	}
+	ids := make([]object.FilterID, 0, len(filters))
+	byID := make(map[object.FilterID]*object.Filter, len(filters))
+	for _, f := range filters {
+		ids = append(ids, f.ID)
+		byID[f.ID] = f
+		f.Keywords = make([]*object.FilterKeyword, 0)
+	}
+
+	query, args, err := sqlx.In("SELECT * FROM filter_keyword WHERE filter_id IN (?) ORDER BY id", ids)
+	if err != nil {
+		return err
+	}
+	keywords := make([]*object.FilterKeyword, 0)
+	if err := r.db.SelectContext(ctx, &keywords, query, args...); err != nil {
+		return err
+	}
+	for _, k := range keywords {
+		byID[k.FilterID].Keywords = append(byID[k.FilterID].Keywords, k)
+	}
+	return nil
+}
+
+// Update : フィルターを更新する。Keywords が nil でなければキーワードを入れ替える
+func (r *filter) Update(ctx context.Context, f *object.Filter) (err error) {
+	query := "UPDATE filter SET title = ?, context = ?, filter_action = ?, expires_at = ? WHERE id = ?"
+	ctx, span := startSpan(ctx, "filter.Update", query)
+	defer func() { endSpan(span, err) }()
+
+	return withTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		if _, err := tx.ExecContext(ctx, query, f.Title, f.Context, f.FilterAction, f.ExpiresAt, f.ID); err != nil {
+			return err
+		}
+		if f.Keywords == nil {
+			return nil
+		}
+		if _, err := tx.ExecContext(ctx, "DELETE FROM filter_keyword WHERE filter_id = ?", f.ID); err != nil {
+			return err
+		}
+		return addKeywords(ctx, tx, f.ID, f.Keywords)
+	})
+}
+
+// Delete : フィルターを削除する。キーワードは ON DELETE CASCADE で消える
+func (r *filter) Delete(ctx context.Context, id object.FilterID) (err error) {
+	query := "DELETE FROM filter WHERE id = ?"
+	ctx, span := startSpan(ctx, "filter.Delete", query)
+	defer func() { endSpan(span, err) }()
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+	if affected, err := result.RowsAffected(); err != nil {
+		return err
+	} else if affected == 0 {
+		return customerror.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,245 @@
+package dao
+
+import (
+	"context"
+	"yatter-backend-go/app/domain/customerror"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/domain/repository"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type (
+	// Implementation for repository.Follow
+	follow struct {
+		db *sqlx.DB
+	}
+
+	// Implementation for repository.FollowRequest
+	followRequest struct {
+		db *sqlx.DB
+	}
+)
+
+// Create follow repository
+func NewFollow(db *sqlx.DB) repository.Follow {
+	return &follow{db: db}
+}
+
+// Create follow request repository
+func NewFollowRequest(db *sqlx.DB) repository.FollowRequest {
+	return &followRequest{db: db}
+}
+
+// Add : フォローする。すでにフォローしている場合は何もしない
+func (r *follow) Add(ctx context.Context, accountID, targetID object.AccountID) (err error) {
+	query := `
+	INSERT IGNORE INTO follow (account_id, target_account_id)
+	VALUES (?, ?)
+	`
+	ctx, span := startSpan(ctx, "follow.Add", query)
+	defer func() { endSpan(span, err) }()
+
+	_, err = r.db.ExecContext(ctx, query, accountID, targetID)
+	return err
+}
+
+// Delete : フォローを解除する
+func (r *follow) Delete(ctx context.Context, accountID, targetID object.AccountID) (err error) {
+	query := `
+	DELETE FROM follow
+	WHERE account_id = ? AND target_account_id = ?
+	`
+	ctx, span := startSpan(ctx, "follow.Delete", query)
+	defer func() { endSpan(span, err) }()
+
+	return withTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		if _, err := tx.ExecContext(ctx, query, accountID, targetID); err != nil {
+			return err
+		}
+		// リストにはフォローしているアカウントしか入れられない
+		return removeFromLists(ctx, tx, accountID, targetID)
+	})
+}
+
+// Exists : フォローしているかを確認する
+func (r *follow) Exists(ctx context.Context, accountID, targetID object.AccountID) (_ bool, err error) {
+	query := `
+	SELECT EXISTS(SELECT 1 FROM follow WHERE account_id = ? AND target_account_id = ?)
+	`
+	ctx, span := startSpan(ctx, "follow.Exists", query)
+	defer func() { endSpan(span, err) }()
+
+	var exists bool
+	if err := r.db.QueryRowxContext(ctx, query, accountID, targetID).Scan(&exists); err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+// FindFollowing : フォローしているアカウントを新しい順に取得する
+func (r *follow) FindFollowing(ctx context.Context, accountID object.AccountID, limit int64) (_ []*object.Account, err error) {
+	query := `
+	SELECT a.*
+	FROM follow f
+	INNER JOIN account a ON f.target_account_id = a.id
+	WHERE f.account_id = ?
+	ORDER BY f.create_at DESC
+	LIMIT ?
+	`
+	ctx, span := startSpan(ctx, "follow.FindFollowing", query)
+	defer func() { endSpan(span, err) }()
+
+	accounts := make([]*object.Account, 0)
+	if err := r.db.SelectContext(ctx, &accounts, query, accountID, limit); err != nil {
+		return nil, err
+	}
+	setRowCount(span, int64(len(accounts)))
+
+	return accounts, nil
+}
+
+// FindFollowers : フォロワーを新しい順に取得する
+func (r *follow) FindFollowers(ctx context.Context, accountID object.AccountID, limit int64) (_ []*object.Account, err error) {
+	query := `
+	SELECT a.*
+	FROM follow f
+	INNER JOIN account a ON f.account_id = a.id
+	WHERE f.target_account_id = ?
+	ORDER BY f.create_at DESC
+	LIMIT ?
+	`
+	ctx, span := startSpan(ctx, "follow.FindFollowers", query)
+	defer func() { endSpan(span, err) }()
+
+	accounts := make([]*object.Account, 0)
+	if err := r.db.SelectContext(ctx, &accounts, query, accountID, limit); err != nil {
+		return nil, err
+	}
+	setRowCount(span, int64(len(accounts)))
+
+	return accounts, nil
+}
+
+// Add : フォローリクエストを送る。すでに送っている場合は何もしない
+func (r *followRequest) Add(ctx context.Context, accountID, targetID object.AccountID) (err error) {
+	query := `
+	INSERT IGNORE INTO follow_request (account_id, target_account_id)
+	VALUES (?, ?)
+	`
+	ctx, span := startSpan(ctx, "followRequest.Add", query)
+	defer func() { endSpan(span, err) }()
+
+	_, err = r.db.ExecContext(ctx, query, accountID, targetID)
+	return err
+}
+
+// Delete : フォローリクエストを取り消す・拒否する
+func (r *followRequest) Delete(ctx context.Context, accountID, targetID object.AccountID) (err error) {
+	query := `
+	DELETE FROM follow_request
+	WHERE account_id = ? AND target_account_id = ?
+	`
+	ctx, span := startSpan(ctx, "followRequest.Delete", query)
+	defer func() { endSpan(span, err) }()
+
+	_, err = r.db.ExecContext(ctx, query, accountID, targetID)
+	return err
+}
+
+// Exists : フォローリクエストを送っているかを確認する
+func (r *followRequest) Exists(ctx context.Context, accountID, targetID object.AccountID) (_ bool, err error) {
+	query := `
+	SELECT EXISTS(SELECT 1 FROM follow_request WHERE account_id = ? AND target_account_id = ?)
+	`
+	ctx, span := startSpan(ctx, "followRequest.Exists", query)
+	defer func() { endSpan(span, err) }()
+
+	var exists bool
+	if err := r.db.QueryRowxContext(ctx, query, accountID, targetID).Scan(&exists); err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+// FindRequesters : フォローリクエストを送ってきたアカウントを古い順に取得する
+func (r *followRequest) FindRequesters(ctx context.Context, targetID object.AccountID, limit int64) (_ []*object.Account, err error) {
+	query := `
+	SELECT a.*
+	FROM follow_request fr
+	INNER JOIN account a ON fr.account_id = a.id
+	WHERE fr.target_account_id = ?
+	ORDER BY fr.create_at ASC
+	LIMIT ?
+	`
+	ctx, span := startSpan(ctx, "followRequest.FindRequesters", query)
+	defer func() { endSpan(span, err) }()
+
+	accounts := make([]*object.Account, 0)
+	if err := r.db.SelectContext(ctx, &accounts, query, targetID, limit); err != nil {
+		return nil, err
+	}
+	setRowCount(span, int64(len(accounts)))
+
+	return accounts, nil
+}
+
+// Authorize : フォローリクエストを承認してフォローにする
+func (r *followRequest) Authorize(ctx context.Context, accountID, targetID object.AccountID) (err error) {
+	query := `
+	DELETE FROM follow_request
+	WHERE account_id = ? AND target_account_id = ?
+	`
+	ctx, span := startSpan(ctx, "followRequest.Authorize", query)
+	defer func() { endSpan(span, err) }()
+
+	return withTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		result, err := tx.ExecContext(ctx, query, accountID, targetID)
+		if err != nil {
+			return err
+		}
+		affectedRows, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affectedRows == 0 {
+			return customerror.ErrNotFound
+		}
+
+		_, err = tx.ExecContext(ctx, "INSERT IGNORE INTO follow (account_id, target_account_id) VALUES (?, ?)", accountID, targetID)
+		return err
+	})
+}
+
+// AuthorizeAll : 保留中のフォローリクエストをすべて承認する
+func (r *followRequest) AuthorizeAll(ctx context.Context, targetID object.AccountID) (err error) {
+	query := `
+	INSERT IGNORE INTO follow (account_id, target_account_id)
+	SELECT account_id, target_account_id FROM follow_request WHERE target_account_id = ?
+	`
+	ctx, span := startSpan(ctx, "followRequest.AuthorizeAll", query)
+	defer func() { endSpan(span, err) }()
+
+	return withTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		if _, err := tx.ExecContext(ctx, query, targetID); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx, "DELETE FROM follow_request WHERE target_account_id = ?", targetID)
+		return err
+	})
+}
+
+// EachFollowing : フォローしているアカウントを古い順に 1 件ずつ fn に渡す
+func (r *follow) EachFollowing(ctx context.Context, accountID object.AccountID, fn func(*object.Account) error) (err error) {
+	query := `
+	SELECT a.*
+	FROM follow f
+	INNER JOIN account a ON f.target_account_id = a.id
+	WHERE f.account_id = ?
+	ORDER BY f.create_at
+	`
+	ctx, span := startSpan(ctx, "follow.EachFollowing", query)
+	defer func() { endSpan(span, err) }()
+
+	return eachAccount(ctx, span, r.db, query, accountID, fn)
+}
@@ -0,0 +1,170 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/domain/repository"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type (
+	// Implementation for repository.Import
+	accountImport struct {
+		db *sqlx.DB
+	}
+)
+
+// Create import repository
+func NewImport(db *sqlx.DB) repository.Import {
+	return &accountImport{db: db}
+}
+
+// CSV 本体 (data) は大きいので、一覧や進捗の取得では読まない
+const importColumns = "id, account_id, type, mode, state, total_rows, processed_rows, failed_rows, create_at"
+
+// Add : インポートを受け付ける
+func (r *accountImport) Add(ctx context.Context, imp *object.Import) (_ object.ImportID, err error) {
+	query := `
+	INSERT INTO account_import (account_id, type, mode, state, data)
+	VALUES (?, ?, ?, ?, ?)
+	`
+	ctx, span := startSpan(ctx, "import.Add", query)
+	defer func() { endSpan(span, err) }()
+
+	result, err := r.db.ExecContext(ctx, query, imp.AccountID, imp.Type, imp.Mode, object.ImportStatePending, imp.Data)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+// Find : アカウントのインポートを取得する
+func (r *accountImport) Find(ctx context.Context, accountID object.AccountID, id object.ImportID) (_ *object.Import, err error) {
+	query := "SELECT " + importColumns + " FROM account_import WHERE id = ? AND account_id = ?"
+	ctx, span := startSpan(ctx, "import.Find", query)
+	defer func() { endSpan(span, err) }()
+
+	entity := new(object.Import)
+	if err := r.db.QueryRowxContext(ctx, query, id, accountID).StructScan(entity); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("%w", err)
+	}
+	return entity, nil
+}
+
+// FindByAccount : アカウントのインポートを新しい順に取得する
+func (r *accountImport) FindByAccount(ctx context.Context, accountID object.AccountID, limit int64) (_ []*object.Import, err error) {
+	query := `
+	SELECT ` + importColumns + ` FROM account_import
+	WHERE account_id = ?
+	ORDER BY id DESC
+	LIMIT ?
+	`
+	ctx, span := startSpan(ctx, "import.FindByAccount", query)
+	defer func() { endSpan(span, err) }()
+
+	imports := make([]*object.Import, 0)
+	if err := r.db.SelectContext(ctx, &imports, query, accountID, limit); err != nil {
+		return nil, err
+	}
+	setRowCount(span, int64(len(imports)))
+
+	return imports, nil
+}
+
+// ClaimPending : 一番古い待ち状態のインポートを実行中にして取得する
+// 他のプロセスに先を越された場合は nil を返す
+func (r *accountImport) ClaimPending(ctx context.Context) (_ *object.Import, err error) {
+	query := "SELECT * FROM account_import WHERE state = ? ORDER BY id LIMIT 1"
+	ctx, span := startSpan(ctx, "import.ClaimPending", query)
+	defer func() { endSpan(span, err) }()
+
+	entity := new(object.Import)
+	if err := r.db.QueryRowxContext(ctx, query, object.ImportStatePending).StructScan(entity); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	result, err := r.db.ExecContext(ctx,
+		"UPDATE account_import SET state = ? WHERE id = ? AND state = ?",
+		object.ImportStateRunning, entity.ID, object.ImportStatePending,
+	)
+	if err != nil {
+		return nil, err
+	}
+	affectedRows, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if affectedRows == 0 {
+		return nil, nil
+	}
+	entity.State = object.ImportStateRunning
+
+	return entity, nil
+}
+
+// UpdateProgress : 進捗を保存する。終了したら CSV 本体はもう使わないので消す
+func (r *accountImport) UpdateProgress(ctx context.Context, imp *object.Import) (err error) {
+	query := `
+	UPDATE account_import
+	SET state = ?, total_rows = ?, processed_rows = ?, failed_rows = ?
+	WHERE id = ?
+	`
+	if imp.State == object.ImportStateDone || imp.State == object.ImportStateFailed {
+		query = `
+	UPDATE account_import
+	SET state = ?, total_rows = ?, processed_rows = ?, failed_rows = ?, data = ''
+	WHERE id = ?
+	`
+	}
+	ctx, span := startSpan(ctx, "import.UpdateProgress", query)
+	defer func() { endSpan(span, err) }()
+
+	_, err = r.db.ExecContext(ctx, query, imp.State, imp.TotalRows, imp.ProcessedRows, imp.FailedRows, imp.ID)
+	return err
+}
+
+// AddErrors : 取り込めなかった行を記録する
+func (r *accountImport) AddErrors(ctx context.Context, errs []*object.ImportError) (err error) {
+	if len(errs) == 0 {
+		return nil
+	}
+	query := `
+	INSERT IGNORE INTO account_import_error (import_id, row, username, reason)
+	VALUES (:import_id, :row, :username, :reason)
+	`
+	ctx, span := startSpan(ctx, "import.AddErrors", query)
+	defer func() { endSpan(span, err) }()
+
+	_, err = r.db.NamedExecContext(ctx, query, errs)
+	return err
+}
+
+// FindErrors : 取り込めなかった行を行番号順に取得する
+func (r *accountImport) FindErrors(ctx context.Context, id object.ImportID, limit int64) (_ []*object.ImportError, err error) {
+	query := `
+	SELECT * FROM account_import_error
+	WHERE import_id = ?
+	ORDER BY row
+	LIMIT ?
+	`
+	ctx, span := startSpan(ctx, "import.FindErrors", query)
+	defer func() { endSpan(span, err) }()
+
+	errs := make([]*object.ImportError, 0)
+	if err := r.db.SelectContext(ctx, &errs, query, id, limit); err != nil {
+		return nil, err
+	}
+	setRowCount(span, int64(len(errs)))
+
+	return errs, nil
+}
@@ -0,0 +1,175 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"yatter-backend-go/app/domain/customerror"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/domain/repository"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type (
+	// Implementation for repository.List
+	list struct {
+		db *sqlx.DB
+	}
+)
+
+// Create list repository
+func NewList(db *sqlx.DB) repository.List {
+	return &list{db: db}
+}
+
+// Add : リストを作成する
+func (r *list) Add(ctx context.Context, l *object.List) (_ object.ListID, err error) {
+	query := "INSERT INTO list (account_id, title) VALUES (?, ?)"
+	ctx, span := startSpan(ctx, "list.Add", query)
+	defer func() { endSpan(span, err) }()
+
+	result, err := r.db.ExecContext(ctx, query, l.AccountID, l.Title)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+// FindByID : リストを取得する
+func (r *list) FindByID(ctx context.Context, id object.ListID) (_ *object.List, err error) {
+	query := "SELECT * FROM list WHERE id = ?"
+	ctx, span := startSpan(ctx, "list.FindByID", query)
+	defer func() { endSpan(span, err) }()
+
+	entity := new(object.List)
+	if err := r.db.QueryRowxContext(ctx, query, id).StructScan(entity); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("%w", err)
+	}
+	return entity, nil
+}
+
+// FindByAccount : アカウントのリストを作成した順に取得する
+func (r *list) FindByAccount(ctx context.Context, accountID object.AccountID) (_ []*object.List, err error) {
+	query := "SELECT * FROM list WHERE account_id = ? ORDER BY id"
+	ctx, span := startSpan(ctx, "list.FindByAccount", query)
+	defer func() { endSpan(span, err) }()
+
+	lists := make([]*object.List, 0)
+	if err := r.db.SelectContext(ctx, &lists, query, accountID); err != nil {
+		return nil, err
+	}
+	setRowCount(span, int64(len(lists)))
+
+	return lists, nil
+}
+
+// UpdateTitle : リストの名前を変更する
+func (r *list) UpdateTitle(ctx context.Context, id object.ListID, title string) (err error) {
+	query := "UPDATE list SET title = ? WHERE id = ?"
+	ctx, span := startSpan(ctx, "list.UpdateTitle", query)
+	defer func() { endSpan(span, err) }()
+
+	_, err = r.db.ExecContext(ctx, query, title, id)
+	return err
+}
+
+// Delete : リストを削除する。メンバーは ON DELETE CASCADE で消える
+func (r *list) Delete(ctx context.Context, id object.ListID) (err error) {
+	query := "DELETE FROM list WHERE id = ?"
+	ctx, span := startSpan(ctx, "list.Delete", query)
+	defer func() { endSpan(span, err) }()
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+	if affected, err := result.RowsAffected(); err != nil {
+		return err
+	} else if affected == 0 {
+		return customerror.ErrNotFound
+	}
+	return nil
+}
+
+// FindAccounts : リストのメンバーを追加した順が新しい順に取得する
+func (r *list) FindAccounts(ctx context.Context, id object.ListID, limit int64) (_ []*object.Account, err error) {
+	query := `
+	SELECT a.*
+	FROM list_account la
+	INNER JOIN account a ON la.account_id = a.id
+	WHERE la.list_id = ?
+	ORDER BY la.create_at DESC
+	LIMIT ?
+	`
+	ctx, span := startSpan(ctx, "list.FindAccounts", query)
+	defer func() { endSpan(span, err) }()
+
+	accounts := make([]*object.Account, 0)
+	if err := r.db.SelectContext(ctx, &accounts, query, id, limit); err != nil {
+		return nil, err
+	}
+	setRowCount(span, int64(len(accounts)))
+
+	return accounts, nil
+}
+
+// AddAccounts : リストにアカウントを追加する
+// 確認してから追加するまでにフォローを外されても残らないように、フォローしているアカウントだけを挿入する
+func (r *list) AddAccounts(ctx context.Context, l *object.List, accountIDs []object.AccountID) (err error) {
+	if len(accountIDs) == 0 {
+		return nil
+	}
+	query, args, err := sqlx.In(`
+	INSERT IGNORE INTO list_account (list_id, account_id)
+	SELECT ?, target_account_id FROM follow
+	WHERE account_id = ? AND target_account_id IN (?)
+	`, l.ID, l.AccountID, accountIDs)
+	if err != nil {
+		return err
+	}
+	ctx, span := startSpan(ctx, "list.AddAccounts", query)
+	defer func() { endSpan(span, err) }()
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	setRowCount(span, affected)
+
+	return nil
+}
+
+// RemoveAccounts : リストからアカウントを外す
+func (r *list) RemoveAccounts(ctx context.Context, id object.ListID, accountIDs []object.AccountID) (err error) {
+	if len(accountIDs) == 0 {
+		return nil
+	}
+	query, args, err := sqlx.In("DELETE FROM list_account WHERE list_id = ? AND account_id IN (?)", id, accountIDs)
+	if err != nil {
+		return err
+	}
+	ctx, span := startSpan(ctx, "list.RemoveAccounts", query)
+	defer func() { endSpan(span, err) }()
+
+	_, err = r.db.ExecContext(ctx, query, args...)
+	return err
+}
+
+// フォローを外した・ブロックしたアカウントを、フォローしていた側のリストから外す
+func removeFromLists(ctx context.Context, tx *sqlx.Tx, ownerID, accountID object.AccountID) error {
+	_, err := tx.ExecContext(ctx, `
+	DELETE la FROM list_account la
+	INNER JOIN list l ON la.list_id = l.id
+	WHERE l.account_id = ? AND la.account_id = ?
+	`, ownerID, accountID)
+	return err
+}
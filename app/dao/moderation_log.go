@@ -0,0 +1,84 @@
+package dao
+
+import (
+	"context"
+	"strings"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/domain/repository"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type (
+	// Implementation for repository.ModerationLog
+	moderationLog struct {
+		db *sqlx.DB
+	}
+)
+
+// Create moderation log repository
+func NewModerationLog(db *sqlx.DB) repository.ModerationLog {
+	return &moderationLog{db: db}
+}
+
+// Find : 操作の記録を新しい順に操作したアカウント付きで取得する
+func (r *moderationLog) Find(ctx context.Context, opts repository.ModerationLogOptions) (_ []*object.ModerationLog, err error) {
+	whereClauses := make([]string, 0)
+	args := make([]interface{}, 0)
+	if opts.MaxID > 0 {
+		whereClauses = append(whereClauses, "id <= ?")
+		args = append(args, opts.MaxID)
+	}
+	if opts.SinceID > 0 {
+		whereClauses = append(whereClauses, "id >= ?")
+		args = append(args, opts.SinceID)
+	}
+	args = append(args, opts.Limit)
+
+	query := "SELECT * FROM moderation_log"
+	if len(whereClauses) > 0 {
+		query += " WHERE " + strings.Join(whereClauses, " AND ")
+	}
+	query += " ORDER BY id DESC LIMIT ?"
+	ctx, span := startSpan(ctx, "moderationLog.Find", query)
+	defer func() { endSpan(span, err) }()
+
+	logs := make([]*object.ModerationLog, 0)
+	if err := r.db.SelectContext(ctx, &logs, query, args...); err != nil {
+		return nil, err
+	}
+	setRowCount(span, int64(len(logs)))
+	if len(logs) == 0 {
+		return logs, nil
+	}
+
+	// 削除されたアカウントは Account が nil のまま
+	accountIDs := make([]object.AccountID, 0, len(logs))
+	for _, l := range logs {
+		accountIDs = append(accountIDs, l.AccountID)
+	}
+	q, qargs, err := sqlx.In("SELECT * FROM account WHERE id IN (?)", accountIDs)
+	if err != nil {
+		return nil, err
+	}
+	var accounts []*object.Account
+	if err := r.db.SelectContext(ctx, &accounts, q, qargs...); err != nil {
+		return nil, err
+	}
+	byID := make(map[object.AccountID]*object.Account, len(accounts))
+	for _, a := range accounts {
+		byID[a.ID] = a
+	}
+	for _, l := range logs {
+		l.Account = byID[l.AccountID]
+	}
+	return logs, nil
+}
+
+// モデレーターの操作を記録する。操作と同じトランザクションで呼ぶ
+func addModerationLog(ctx context.Context, tx *sqlx.Tx, actorID object.AccountID, action, targetType, targetID string) error {
+	_, err := tx.ExecContext(ctx,
+		"INSERT INTO moderation_log (account_id, action, target_type, target_id) VALUES (?, ?, ?, ?)",
+		actorID, action, targetType, targetID)
+	return err
+}
@@ -0,0 +1,152 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/domain/repository"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type (
+	// Implementation for repository.Mute
+	mute struct {
+		db *sqlx.DB
+	}
+)
+
+// 期限切れのミュートは削除されるまでの間も無視する
+const activeMuteCondition = "(m.expires_at IS NULL OR m.expires_at > NOW())"
+
+// Create mute repository
+func NewMute(db *sqlx.DB) repository.Mute {
+	return &mute{db: db}
+}
+
+// Add : ミュートする。すでにミュートしている場合は設定を上書きする
+func (r *mute) Add(ctx context.Context, m *object.Mute) (err error) {
+	query := `
+	INSERT INTO mute (account_id, target_account_id, hide_notifications, expires_at)
+	VALUES (?, ?, ?, ?)
+	ON DUPLICATE KEY UPDATE hide_notifications = VALUES(hide_notifications), expires_at = VALUES(expires_at)
+	`
+	ctx, span := startSpan(ctx, "mute.Add", query)
+	defer func() { endSpan(span, err) }()
+
+	_, err = r.db.ExecContext(ctx, query, m.AccountID, m.TargetAccountID, m.HideNotifications, m.ExpiresAt)
+	return err
+}
+
+// Delete : ミュートを解除する
+func (r *mute) Delete(ctx context.Context, accountID, targetID object.AccountID) (err error) {
+	query := `
+	DELETE FROM mute
+	WHERE account_id = ? AND target_account_id = ?
+	`
+	ctx, span := startSpan(ctx, "mute.Delete", query)
+	defer func() { endSpan(span, err) }()
+
+	_, err = r.db.ExecContext(ctx, query, accountID, targetID)
+	return err
+}
+
+// Find : 有効なミュートを取得する
+func (r *mute) Find(ctx context.Context, accountID, targetID object.AccountID) (_ *object.Mute, err error) {
+	query := `
+	SELECT m.*
+	FROM mute m
+	WHERE m.account_id = ? AND m.target_account_id = ? AND ` + activeMuteCondition
+	ctx, span := startSpan(ctx, "mute.Find", query)
+	defer func() { endSpan(span, err) }()
+
+	entity := new(object.Mute)
+	if err := r.db.QueryRowxContext(ctx, query, accountID, targetID).StructScan(entity); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return entity, nil
+}
+
+// FindMutedAccounts : ミュートしているアカウントを新しい順に取得する
+func (r *mute) FindMutedAccounts(ctx context.Context, accountID object.AccountID, limit int64) (_ []*object.Account, err error) {
+	query := `
+	SELECT a.*
+	FROM mute m
+	INNER JOIN account a ON m.target_account_id = a.id
+	WHERE m.account_id = ? AND ` + activeMuteCondition + `
+	ORDER BY m.create_at DESC
+	LIMIT ?
+	`
+	ctx, span := startSpan(ctx, "mute.FindMutedAccounts", query)
+	defer func() { endSpan(span, err) }()
+
+	accounts := make([]*object.Account, 0)
+	if err := r.db.SelectContext(ctx, &accounts, query, accountID, limit); err != nil {
+		return nil, err
+	}
+	setRowCount(span, int64(len(accounts)))
+
+	return accounts, nil
+}
+
+// DeleteExpired : 期限切れのミュートを削除する
+func (r *mute) DeleteExpired(ctx context.Context) (_ int64, err error) {
+	query := `
+	DELETE FROM mute
+	WHERE expires_at IS NOT NULL AND expires_at <= NOW()
+	`
+	ctx, span := startSpan(ctx, "mute.DeleteExpired", query)
+	defer func() { endSpan(span, err) }()
+
+	result, err := r.db.ExecContext(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	setRowCount(span, n)
+
+	return n, nil
+}
+
+// EachMuted : 有効なミュートを対象のアカウントと共に古い順に 1 件ずつ fn に渡す
+func (r *mute) EachMuted(ctx context.Context, accountID object.AccountID, fn func(*object.Account, *object.Mute) error) (err error) {
+	query := `
+	SELECT a.id, a.username, m.hide_notifications, m.expires_at, m.create_at
+	FROM mute m
+	INNER JOIN account a ON m.target_account_id = a.id
+	WHERE m.account_id = ? AND ` + activeMuteCondition + `
+	ORDER BY m.create_at
+	`
+	ctx, span := startSpan(ctx, "mute.EachMuted", query)
+	defer func() { endSpan(span, err) }()
+
+	rows, err := r.db.QueryxContext(ctx, query, accountID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var n int64
+	for rows.Next() {
+		account := new(object.Account)
+		mute := &object.Mute{AccountID: accountID}
+		if err := rows.Scan(&account.ID, &account.Username, &mute.HideNotifications, &mute.ExpiresAt, &mute.CreateAt); err != nil {
+			return err
+		}
+		mute.TargetAccountID = account.ID
+		if err := fn(account, mute); err != nil {
+			return err
+		}
+		n++
+	}
+	setRowCount(span, n)
+
+	return rows.Err()
+}
@@ -0,0 +1,62 @@
+package dao
+
+import (
+	"context"
+	"strings"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/domain/repository"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type (
+	// Implementation for repository.Notification
+	notification struct {
+		db *sqlx.DB
+	}
+)
+
+// Create notification repository
+func NewNotification(db *sqlx.DB) repository.Notification {
+	return &notification{db: db}
+}
+
+// FindByAccount : アカウント宛ての通知を新しい順に取得する
+// ブロックしているアカウントと、通知も隠す設定でミュートしているアカウントからの通知は除く
+func (r *notification) FindByAccount(ctx context.Context, accountID object.AccountID, opts repository.NotificationOptions) (_ []*object.Notification, err error) {
+	whereClauses := []string{
+		"n.account_id = ?",
+		`(n.from_account_id IS NULL OR n.from_account_id NOT IN (
+			SELECT target_account_id FROM block WHERE account_id = ?
+			UNION ALL
+			SELECT m.target_account_id FROM mute m WHERE m.account_id = ? AND m.hide_notifications = TRUE AND ` + activeMuteCondition + `))`,
+	}
+	args := []interface{}{accountID, accountID, accountID}
+	if opts.MaxID > 0 {
+		whereClauses = append(whereClauses, "n.id <= ?")
+		args = append(args, opts.MaxID)
+	}
+	if opts.SinceID > 0 {
+		whereClauses = append(whereClauses, "n.id >= ?")
+		args = append(args, opts.SinceID)
+	}
+	args = append(args, opts.Limit)
+
+	query := `
+	SELECT n.*
+	FROM notification n
+	WHERE ` + strings.Join(whereClauses, " AND ") + `
+	ORDER BY n.id DESC
+	LIMIT ?
+	`
+	ctx, span := startSpan(ctx, "notification.FindByAccount", query)
+	defer func() { endSpan(span, err) }()
+
+	notifications := make([]*object.Notification, 0)
+	if err := r.db.SelectContext(ctx, &notifications, query, args...); err != nil {
+		return nil, err
+	}
+	setRowCount(span, int64(len(notifications)))
+
+	return notifications, nil
+}
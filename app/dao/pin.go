@@ -0,0 +1,69 @@
+package dao
+
+import (
+	"context"
+	"yatter-backend-go/app/domain/customerror"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/domain/repository"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type (
+	// Implementation for repository.Pin
+	pin struct {
+		db *sqlx.DB
+	}
+)
+
+// Create pin repository
+func NewPin(db *sqlx.DB) repository.Pin {
+	return &pin{db: db}
+}
+
+// Add : ステータスをプロフィールにピン留めする。すでにピン留めしている場合は何もしない
+// 同時にピン留めされても上限を超えないように、アカウントの行をロックしてから数える
+func (r *pin) Add(ctx context.Context, accountID object.AccountID, statusID object.StatusID, max int) (err error) {
+	query := "INSERT IGNORE INTO status_pin (account_id, status_id) VALUES (?, ?)"
+	ctx, span := startSpan(ctx, "pin.Add", query)
+	defer func() { endSpan(span, err) }()
+
+	return withTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		var locked object.AccountID
+		if err := tx.QueryRowxContext(ctx,
+			"SELECT id FROM account WHERE id = ? FOR UPDATE", accountID).Scan(&locked); err != nil {
+			return err
+		}
+
+		var pinned bool
+		if err := tx.QueryRowxContext(ctx,
+			"SELECT EXISTS(SELECT 1 FROM status_pin WHERE status_id = ?)", statusID).Scan(&pinned); err != nil {
+			return err
+		}
+		if pinned {
+			return nil
+		}
+
+		var n int
+		if err := tx.QueryRowxContext(ctx,
+			"SELECT COUNT(*) FROM status_pin WHERE account_id = ?", accountID).Scan(&n); err != nil {
+			return err
+		}
+		if n >= max {
+			return customerror.ErrTooManyPins
+		}
+
+		_, err := tx.ExecContext(ctx, query, accountID, statusID)
+		return err
+	})
+}
+
+// Delete : ピン留めを外す
+func (r *pin) Delete(ctx context.Context, accountID object.AccountID, statusID object.StatusID) (err error) {
+	query := "DELETE FROM status_pin WHERE account_id = ? AND status_id = ?"
+	ctx, span := startSpan(ctx, "pin.Delete", query)
+	defer func() { endSpan(span, err) }()
+
+	_, err = r.db.ExecContext(ctx, query, accountID, statusID)
+	return err
+}
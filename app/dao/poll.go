@@ -0,0 +1,273 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+	"yatter-backend-go/app/domain/customerror"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/domain/repository"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type (
+	// Implementation for repository.Poll
+	poll struct {
+		db *sqlx.DB
+	}
+)
+
+// Create poll repository
+func NewPoll(db *sqlx.DB) repository.Poll {
+	return &poll{db: db}
+}
+
+// ステータスに投票を付ける。insertStatus のトランザクションの中で呼ぶ
+func addPoll(ctx context.Context, tx *sqlx.Tx, statusID object.StatusID, p *object.Poll) error {
+	result, err := tx.ExecContext(ctx,
+		"INSERT INTO poll (status_id, expires_at, multiple, hide_totals) VALUES (?, ?, ?, ?)",
+		statusID, p.ExpiresAt, p.Multiple, p.HideTotals)
+	if err != nil {
+		return err
+	}
+	pollID, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	for i, o := range p.Options {
+		if _, err := tx.ExecContext(ctx,
+			"INSERT INTO poll_option (poll_id, position, title) VALUES (?, ?, ?)", pollID, i, o.Title); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FindByID : 選択肢と閲覧者の投票と共に投票を取得する
+func (r *poll) FindByID(ctx context.Context, id object.PollID, viewerID object.AccountID) (_ *object.Poll, err error) {
+	query := "SELECT * FROM poll WHERE id = ?"
+	ctx, span := startSpan(ctx, "poll.FindByID", query)
+	defer func() { endSpan(span, err) }()
+
+	entity := new(object.Poll)
+	if err := r.db.QueryRowxContext(ctx, query, id).StructScan(entity); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if err := r.fill(ctx, []*object.Poll{entity}, viewerID); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
+
+// Attach : ステータスに付いている投票をまとめて取得して設定する
+func (r *poll) Attach(ctx context.Context, statuses []*object.Status, viewerID object.AccountID) (err error) {
+	if len(statuses) == 0 {
+		return nil
+	}
+	ids := make([]object.StatusID, 0, len(statuses))
+	for _, s := range statuses {
+		ids = append(ids, s.ID)
+	}
+
+	query, args, err := sqlx.In("SELECT * FROM poll WHERE status_id IN (?)", ids)
+	if err != nil {
+		return err
+	}
+	ctx, span := startSpan(ctx, "poll.Attach", query)
+	defer func() { endSpan(span, err) }()
+
+	polls := make([]*object.Poll, 0)
+	if err := r.db.SelectContext(ctx, &polls, query, args...); err != nil {
+		return err
+	}
+	setRowCount(span, int64(len(polls)))
+	if err := r.fill(ctx, polls, viewerID); err != nil {
+		return err
+	}
+
+	byStatus := make(map[object.StatusID]*object.Poll, len(polls))
+	for _, p := range polls {
+		byStatus[p.StatusID] = p
+	}
+	for _, s := range statuses {
+		s.Poll = byStatus[s.ID]
+	}
+	return nil
+}
+
+// 選択肢と閲覧者の投票を読み込む
+func (r *poll) fill(ctx context.Context, polls []*object.Poll, viewerID object.AccountID) error {
+	if len(polls) == 0 {
+		return nil
+	}
+	ids := make([]object.PollID, 0, len(polls))
+	byID := make(map[object.PollID]*object.Poll, len(polls))
+	for _, p := range polls {
+		ids = append(ids, p.ID)
+		byID[p.ID] = p
+		p.Options = make([]*object.PollOption, 0)
+	}
+
+	query, args, err := sqlx.In("SELECT * FROM poll_option WHERE poll_id IN (?) ORDER BY poll_id, position", ids)
+	if err != nil {
+		return err
+	}
+	options := make([]*object.PollOption, 0)
+	if err := r.db.SelectContext(ctx, &options, query, args...); err != nil {
+		return err
+	}
+	for _, o := range options {
+		byID[o.PollID].Options = append(byID[o.PollID].Options, o)
+	}
+
+	if viewerID > 0 {
+		query, args, err := sqlx.In("SELECT poll_id, choice FROM poll_vote WHERE account_id = ? AND poll_id IN (?) ORDER BY poll_id, choice", viewerID, ids)
+		if err != nil {
+			return err
+		}
+		var votes []struct {
+			PollID object.PollID `db:"poll_id"`
+			Choice int           `db:"choice"`
+		}
+		if err := r.db.SelectContext(ctx, &votes, query, args...); err != nil {
+			return err
+		}
+		for _, p := range polls {
+			voted := false
+			p.Voted = &voted
+		}
+		for _, v := range votes {
+			p := byID[v.PollID]
+			*p.Voted = true
+			p.OwnVotes = append(p.OwnVotes, v.Choice)
+		}
+	}
+
+	now := time.Now()
+	for _, p := range polls {
+		p.SetExpired(now)
+	}
+	return nil
+}
+
+// Vote : 投票する
+// 同時に投票されても 1 アカウント 1 回になるように、投票の行をロックしてから確かめる
+func (r *poll) Vote(ctx context.Context, id object.PollID, accountID object.AccountID, choices []int) (err error) {
+	query := `
+	SELECT closed_at IS NOT NULL OR expires_at <= NOW()
+	FROM poll
+	WHERE id = ?
+	FOR UPDATE
+	`
+	ctx, span := startSpan(ctx, "poll.Vote", query)
+	defer func() { endSpan(span, err) }()
+
+	return withTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		var expired bool
+		if err := tx.QueryRowxContext(ctx, query, id).Scan(&expired); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return customerror.ErrNotFound
+			}
+			return err
+		}
+		if expired {
+			return customerror.ErrPollExpired
+		}
+
+		var voted bool
+		if err := tx.QueryRowxContext(ctx,
+			"SELECT EXISTS(SELECT 1 FROM poll_vote WHERE poll_id = ? AND account_id = ?)", id, accountID).Scan(&voted); err != nil {
+			return err
+		}
+		if voted {
+			return customerror.ErrAlreadyVoted
+		}
+
+		for _, choice := range choices {
+			if _, err := tx.ExecContext(ctx,
+				"INSERT INTO poll_vote (poll_id, account_id, choice) VALUES (?, ?, ?)", id, accountID, choice); err != nil {
+				return err
+			}
+			if _, err := tx.ExecContext(ctx,
+				"UPDATE poll_option SET votes_count = votes_count + 1 WHERE poll_id = ? AND position = ?", id, choice); err != nil {
+				return err
+			}
+		}
+		_, err := tx.ExecContext(ctx,
+			"UPDATE poll SET votes_count = votes_count + ?, voters_count = voters_count + 1 WHERE id = ?", len(choices), id)
+		return err
+	})
+}
+
+// CloseExpired : 締め切りを過ぎた投票を終了し、投票したアカウントと投稿者に通知する
+func (r *poll) CloseExpired(ctx context.Context) (_ int64, err error) {
+	query := `
+	SELECT id FROM poll
+	WHERE closed_at IS NULL AND expires_at <= NOW()
+	`
+	ctx, span := startSpan(ctx, "poll.CloseExpired", query)
+	defer func() { endSpan(span, err) }()
+
+	var ids []object.PollID
+	if err := r.db.SelectContext(ctx, &ids, query); err != nil {
+		return 0, err
+	}
+
+	var n int64
+	for _, id := range ids {
+		closed, err := r.close(ctx, id)
+		if err != nil {
+			return n, err
+		}
+		if closed {
+			n++
+		}
+	}
+	setRowCount(span, n)
+
+	return n, nil
+}
+
+// 投稿者自身が投票していても通知は 1 件にする
+const pollNotificationQuery = `
+INSERT INTO notification (account_id, type, from_account_id, status_id)
+SELECT DISTINCT v.account_id, ?, s.account_id, s.id
+FROM poll_vote v
+INNER JOIN poll p ON v.poll_id = p.id
+INNER JOIN status s ON p.status_id = s.id
+WHERE p.id = ? AND v.account_id <> s.account_id
+UNION ALL
+SELECT s.account_id, ?, s.account_id, s.id
+FROM poll p
+INNER JOIN status s ON p.status_id = s.id
+WHERE p.id = ?
+`
+
+// 他のワーカーが先に終了させていた場合は false を返す
+func (r *poll) close(ctx context.Context, id object.PollID) (closed bool, err error) {
+	err = withTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		result, err := tx.ExecContext(ctx, "UPDATE poll SET closed_at = NOW() WHERE id = ? AND closed_at IS NULL", id)
+		if err != nil {
+			return err
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return nil
+		}
+		if _, err := tx.ExecContext(ctx, pollNotificationQuery,
+			object.NotificationTypePoll, id, object.NotificationTypePoll, id); err != nil {
+			return err
+		}
+		closed = true
+		return nil
+	})
+	return closed, err
+}
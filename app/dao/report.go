@@ -0,0 +1,226 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"strings"
+	"yatter-backend-go/app/domain/customerror"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/domain/repository"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type (
+	// Implementation for repository.Report
+	report struct {
+		db *sqlx.DB
+	}
+)
+
+// Create report repository
+func NewReport(db *sqlx.DB) repository.Report {
+	return &report{db: db}
+}
+
+// Add : 通報と対象のステータスを保存する。ActionTakenAt があれば対応済みとして保存する
+func (r *report) Add(ctx context.Context, rp *object.Report) (_ object.ReportID, err error) {
+	query := `
+	INSERT INTO report (account_id, target_account_id, category, comment, rule_action, rule_ids, action_taken_at)
+	VALUES (:account_id, :target_account_id, :category, :comment, :rule_action, :rule_ids, :action_taken_at)
+	`
+	ctx, span := startSpan(ctx, "report.Add", query)
+	defer func() { endSpan(span, err) }()
+
+	var id object.ReportID
+	err = withTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		result, err := tx.NamedExecContext(ctx, query, rp)
+		if err != nil {
+			return err
+		}
+		if id, err = result.LastInsertId(); err != nil {
+			return err
+		}
+
+		if len(rp.StatusIDs) == 0 {
+			return nil
+		}
+		rows := make([]map[string]interface{}, 0, len(rp.StatusIDs))
+		for _, statusID := range rp.StatusIDs {
+			rows = append(rows, map[string]interface{}{"report_id": id, "status_id": statusID})
+		}
+		_, err = tx.NamedExecContext(ctx, "INSERT IGNORE INTO report_status (report_id, status_id) VALUES (:report_id, :status_id)", rows)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// FindByID : 通報を対象のステータスとアカウント付きで取得する
+func (r *report) FindByID(ctx context.Context, id object.ReportID) (_ *object.Report, err error) {
+	query := "SELECT * FROM report WHERE id = ?"
+	ctx, span := startSpan(ctx, "report.FindByID", query)
+	defer func() { endSpan(span, err) }()
+
+	rp := new(object.Report)
+	if err := r.db.QueryRowxContext(ctx, query, id).StructScan(rp); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if err := r.fill(ctx, []*object.Report{rp}); err != nil {
+		return nil, err
+	}
+	return rp, nil
+}
+
+// Find : 未対応または対応済みの通報を新しい順に取得する
+func (r *report) Find(ctx context.Context, opts repository.ReportOptions) (_ []*object.Report, err error) {
+	whereClauses := []string{"action_taken_at IS NULL"}
+	if opts.Resolved {
+		whereClauses[0] = "action_taken_at IS NOT NULL"
+	}
+	args := make([]interface{}, 0)
+	if opts.MaxID > 0 {
+		whereClauses = append(whereClauses, "id <= ?")
+		args = append(args, opts.MaxID)
+	}
+	if opts.SinceID > 0 {
+		whereClauses = append(whereClauses, "id >= ?")
+		args = append(args, opts.SinceID)
+	}
+	args = append(args, opts.Limit)
+
+	query := "SELECT * FROM report WHERE " + strings.Join(whereClauses, " AND ") + " ORDER BY id DESC LIMIT ?"
+	ctx, span := startSpan(ctx, "report.Find", query)
+	defer func() { endSpan(span, err) }()
+
+	reports := make([]*object.Report, 0)
+	if err := r.db.SelectContext(ctx, &reports, query, args...); err != nil {
+		return nil, err
+	}
+	setRowCount(span, int64(len(reports)))
+
+	if err := r.fill(ctx, reports); err != nil {
+		return nil, err
+	}
+	return reports, nil
+}
+
+// 通報に対象のステータスの ID とアカウントを付ける
+func (r *report) fill(ctx context.Context, reports []*object.Report) error {
+	if len(reports) == 0 {
+		return nil
+	}
+	ids := make([]object.ReportID, 0, len(reports))
+	accountIDs := make([]object.AccountID, 0, len(reports))
+	byID := make(map[object.ReportID]*object.Report, len(reports))
+	for _, rp := range reports {
+		ids = append(ids, rp.ID)
+		if rp.TargetAccountID != nil {
+			accountIDs = append(accountIDs, *rp.TargetAccountID)
+		}
+		byID[rp.ID] = rp
+		rp.ActionTaken = rp.ActionTakenAt != nil
+		rp.StatusIDs = make([]object.StatusID, 0)
+	}
+
+	query, args, err := sqlx.In("SELECT report_id, status_id FROM report_status WHERE report_id IN (?) ORDER BY report_id, status_id", ids)
+	if err != nil {
+		return err
+	}
+	var statuses []struct {
+		ReportID object.ReportID `db:"report_id"`
+		StatusID object.StatusID `db:"status_id"`
+	}
+	if err := r.db.SelectContext(ctx, &statuses, query, args...); err != nil {
+		return err
+	}
+	for _, s := range statuses {
+		byID[s.ReportID].StatusIDs = append(byID[s.ReportID].StatusIDs, s.StatusID)
+	}
+
+	// 対象のアカウントが削除済みの通報だけなら引くものはない
+	if len(accountIDs) == 0 {
+		return nil
+	}
+	query, args, err = sqlx.In("SELECT * FROM account WHERE id IN (?)", accountIDs)
+	if err != nil {
+		return err
+	}
+	var accounts []*object.Account
+	if err := r.db.SelectContext(ctx, &accounts, query, args...); err != nil {
+		return err
+	}
+	byAccountID := make(map[object.AccountID]*object.Account, len(accounts))
+	for _, a := range accounts {
+		byAccountID[a.ID] = a
+	}
+	for _, rp := range reports {
+		if rp.TargetAccountID != nil {
+			rp.TargetAccount = byAccountID[*rp.TargetAccountID]
+		}
+	}
+	return nil
+}
+
+// Assign : 通報の担当者を設定する。assigneeID が nil なら担当を外す
+func (r *report) Assign(ctx context.Context, id object.ReportID, assigneeID *object.AccountID, actorID object.AccountID) (err error) {
+	query := "UPDATE report SET assigned_account_id = ? WHERE id = ?"
+	ctx, span := startSpan(ctx, "report.Assign", query)
+	defer func() { endSpan(span, err) }()
+
+	action := object.ModerationActionAssignReport
+	if assigneeID == nil {
+		action = object.ModerationActionUnassignReport
+	}
+	return r.update(ctx, id, actorID, action, query, assigneeID, id)
+}
+
+// Resolve : 通報を対応済みにする。すでに対応済みなら何もしない
+func (r *report) Resolve(ctx context.Context, id object.ReportID, actorID object.AccountID) (err error) {
+	query := "UPDATE report SET action_taken_by_account_id = ?, action_taken_at = NOW() WHERE id = ? AND action_taken_at IS NULL"
+	ctx, span := startSpan(ctx, "report.Resolve", query)
+	defer func() { endSpan(span, err) }()
+
+	return r.update(ctx, id, actorID, object.ModerationActionResolveReport, query, actorID, id)
+}
+
+// Reopen : 対応済みの通報を未対応に戻す。未対応なら何もしない
+func (r *report) Reopen(ctx context.Context, id object.ReportID, actorID object.AccountID) (err error) {
+	query := "UPDATE report SET action_taken_by_account_id = NULL, action_taken_at = NULL WHERE id = ? AND action_taken_at IS NOT NULL"
+	ctx, span := startSpan(ctx, "report.Reopen", query)
+	defer func() { endSpan(span, err) }()
+
+	return r.update(ctx, id, actorID, object.ModerationActionReopenReport, query, id)
+}
+
+// 通報を更新し、変化があったときだけ操作を記録する
+// 同じ値で更新すると RowsAffected が 0 になるので、存在は行ロックで確かめる
+func (r *report) update(ctx context.Context, id object.ReportID, actorID object.AccountID, action, query string, args ...interface{}) error {
+	return withTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		var locked object.ReportID
+		if err := tx.QueryRowxContext(ctx, "SELECT id FROM report WHERE id = ? FOR UPDATE", id).Scan(&locked); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return customerror.ErrNotFound
+			}
+			return err
+		}
+
+		result, err := tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+		if affected, err := result.RowsAffected(); err != nil {
+			return err
+		} else if affected == 0 {
+			return nil
+		}
+		return addModerationLog(ctx, tx, actorID, action, object.ModerationTargetReport, strconv.FormatInt(id, 10))
+	})
+}
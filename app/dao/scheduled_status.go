@@ -0,0 +1,193 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"yatter-backend-go/app/domain/customerror"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/domain/repository"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type (
+	// Implementation for repository.ScheduledStatus
+	scheduledStatus struct {
+		db *sqlx.DB
+	}
+)
+
+// Create scheduled status repository
+func NewScheduledStatus(db *sqlx.DB) repository.ScheduledStatus {
+	return &scheduledStatus{db: db}
+}
+
+// Add : ステータスの投稿を予約する
+func (r *scheduledStatus) Add(ctx context.Context, s *object.ScheduledStatus) (_ object.ScheduledStatusID, err error) {
+	query := "INSERT INTO scheduled_status (account_id, scheduled_at, params, report_id) VALUES (?, ?, ?, ?)"
+	ctx, span := startSpan(ctx, "scheduled_status.Add", query)
+	defer func() { endSpan(span, err) }()
+
+	result, err := r.db.ExecContext(ctx, query, s.AccountID, s.ScheduledAt, s.Params, s.ReportID)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+// FindByID : 予約を取得する
+func (r *scheduledStatus) FindByID(ctx context.Context, id object.ScheduledStatusID) (_ *object.ScheduledStatus, err error) {
+	query := "SELECT * FROM scheduled_status WHERE id = ?"
+	ctx, span := startSpan(ctx, "scheduled_status.FindByID", query)
+	defer func() { endSpan(span, err) }()
+
+	entity := new(object.ScheduledStatus)
+	if err := r.db.QueryRowxContext(ctx, query, id).StructScan(entity); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("%w", err)
+	}
+	return entity, nil
+}
+
+// FindByAccount : アカウントの予約を新しく予約した順に取得する
+// 他の一覧と同じく max_id / since_id で辿れるように ID の順に並べる
+func (r *scheduledStatus) FindByAccount(ctx context.Context, accountID object.AccountID, opts repository.ScheduledStatusOptions) (_ []*object.ScheduledStatus, err error) {
+	whereClauses := []string{"account_id = ?"}
+	args := []interface{}{accountID}
+	if opts.MaxID > 0 {
+		whereClauses = append(whereClauses, "id <= ?")
+		args = append(args, opts.MaxID)
+	}
+	if opts.SinceID > 0 {
+		whereClauses = append(whereClauses, "id >= ?")
+		args = append(args, opts.SinceID)
+	}
+	args = append(args, opts.Limit)
+
+	query := `
+	SELECT * FROM scheduled_status
+	WHERE ` + strings.Join(whereClauses, " AND ") + `
+	ORDER BY id DESC
+	LIMIT ?
+	`
+	ctx, span := startSpan(ctx, "scheduled_status.FindByAccount", query)
+	defer func() { endSpan(span, err) }()
+
+	statuses := make([]*object.ScheduledStatus, 0)
+	if err := r.db.SelectContext(ctx, &statuses, query, args...); err != nil {
+		return nil, err
+	}
+	setRowCount(span, int64(len(statuses)))
+
+	return statuses, nil
+}
+
+// CountByAccount : アカウントの予約の件数を数える
+func (r *scheduledStatus) CountByAccount(ctx context.Context, accountID object.AccountID) (_ int, err error) {
+	query := "SELECT COUNT(*) FROM scheduled_status WHERE account_id = ?"
+	ctx, span := startSpan(ctx, "scheduled_status.CountByAccount", query)
+	defer func() { endSpan(span, err) }()
+
+	var n int
+	if err := r.db.QueryRowxContext(ctx, query, accountID).Scan(&n); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+// UpdateScheduledAt : 投稿予定時刻を変更する
+func (r *scheduledStatus) UpdateScheduledAt(ctx context.Context, id object.ScheduledStatusID, at time.Time) (err error) {
+	query := "UPDATE scheduled_status SET scheduled_at = ? WHERE id = ?"
+	ctx, span := startSpan(ctx, "scheduled_status.UpdateScheduledAt", query)
+	defer func() { endSpan(span, err) }()
+
+	// 同じ時刻で更新すると RowsAffected が 0 になるので、存在の確認は呼び出し側で行う
+	_, err = r.db.ExecContext(ctx, query, at, id)
+	return err
+}
+
+// Delete : 予約を取り消す
+func (r *scheduledStatus) Delete(ctx context.Context, id object.ScheduledStatusID) (err error) {
+	query := "DELETE FROM scheduled_status WHERE id = ?"
+	ctx, span := startSpan(ctx, "scheduled_status.Delete", query)
+	defer func() { endSpan(span, err) }()
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+	if affected, err := result.RowsAffected(); err != nil {
+		return err
+	} else if affected == 0 {
+		return customerror.ErrNotFound
+	}
+	return nil
+}
+
+// PublishDue : 投稿予定時刻を過ぎた予約を投稿する
+// 複数のプロセスが同時に実行しても 1 回だけ投稿されるように、予約の行をロックしてから
+// ステータスの登録と予約の削除を同じトランザクションで行う
+func (r *scheduledStatus) PublishDue(ctx context.Context) (_ int64, err error) {
+	query := "SELECT id FROM scheduled_status WHERE scheduled_at <= NOW() ORDER BY scheduled_at"
+	ctx, span := startSpan(ctx, "scheduled_status.PublishDue", query)
+	defer func() { endSpan(span, err) }()
+
+	var ids []object.ScheduledStatusID
+	if err := r.db.SelectContext(ctx, &ids, query); err != nil {
+		return 0, err
+	}
+
+	var n int64
+	for _, id := range ids {
+		published, err := r.publish(ctx, id)
+		if err != nil {
+			return n, err
+		}
+		if published {
+			n++
+		}
+	}
+	setRowCount(span, n)
+
+	return n, nil
+}
+
+// 他のプロセスが先に投稿していた場合は false を返す
+func (r *scheduledStatus) publish(ctx context.Context, id object.ScheduledStatusID) (published bool, err error) {
+	err = withTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		entity := new(object.ScheduledStatus)
+		// 予約時刻を変更された場合に備えて、ロックを取ってから確かめ直す
+		err := tx.QueryRowxContext(ctx,
+			"SELECT * FROM scheduled_status WHERE id = ? AND scheduled_at <= NOW() FOR UPDATE", id).StructScan(entity)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil
+			}
+			return err
+		}
+
+		statusID, err := insertStatus(ctx, tx, entity.Status(time.Now()))
+		if err != nil {
+			return err
+		}
+		// 予約時にコンテンツルールで通報されていれば、投稿されたステータスを通報に結び付ける
+		if entity.ReportID != nil {
+			if _, err := tx.ExecContext(ctx,
+				"INSERT IGNORE INTO report_status (report_id, status_id) VALUES (?, ?)", *entity.ReportID, statusID); err != nil {
+				return err
+			}
+		}
+		if _, err := tx.ExecContext(ctx, "DELETE FROM scheduled_status WHERE id = ?", id); err != nil {
+			return err
+		}
+		published = true
+		return nil
+	})
+	return published, err
+}
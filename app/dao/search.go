@@ -0,0 +1,163 @@
+package dao
+
+import (
+	"context"
+	"strings"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/domain/repository"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type (
+	// Implementation for repository.Search using MySQL FULLTEXT
+	search struct {
+		db *sqlx.DB
+	}
+)
+
+// Create search repository
+func NewSearch(db *sqlx.DB) repository.Search {
+	return &search{db: db}
+}
+
+// 検索語を BOOLEAN MODE のフレーズにする。
+// 演算子 (+ - * など) を無効にしつつ、ngram で区切られた語が連続しているものだけに絞る
+func fulltextPhrase(q string) string {
+	return `"` + strings.ReplaceAll(q, `"`, " ") + `"`
+}
+
+// Accounts : ユーザ名と表示名からアカウントを検索する。関連度の高い順
+func (r *search) Accounts(ctx context.Context, q string, opts repository.SearchOptions) (_ []*object.Account, err error) {
+	query := `
+	SELECT a.*
+	FROM account a
+	WHERE MATCH (a.username, a.display_name) AGAINST (? IN BOOLEAN MODE)
+	AND a.suspended_at IS NULL
+	`
+	args := []interface{}{fulltextPhrase(q)}
+	if opts.ViewerID > 0 {
+		query += `
+	AND a.id NOT IN (SELECT target_account_id FROM block WHERE account_id = ?)
+	AND a.id NOT IN (SELECT account_id FROM block WHERE target_account_id = ?)
+	`
+		args = append(args, opts.ViewerID, opts.ViewerID)
+	}
+	query += `
+	ORDER BY MATCH (a.username, a.display_name) AGAINST (? IN BOOLEAN MODE) DESC, a.id
+	LIMIT ? OFFSET ?
+	`
+	args = append(args, fulltextPhrase(q), opts.Limit, opts.Offset)
+
+	ctx, span := startSpan(ctx, "search.Accounts", query)
+	defer func() { endSpan(span, err) }()
+
+	accounts := make([]*object.Account, 0)
+	if err := r.db.SelectContext(ctx, &accounts, query, args...); err != nil {
+		return nil, err
+	}
+	setRowCount(span, int64(len(accounts)))
+
+	return accounts, nil
+}
+
+// Statuses : 本文からステータスを検索する。閲覧者が見られるものだけを新しい順に返す
+func (r *search) Statuses(ctx context.Context, q string, opts repository.SearchOptions) (_ []*object.Status, err error) {
+	query := `
+	SELECT s.id,
+				 s.content,
+				 s.visibility,
+				 s.spoiler_text,
+				 s.sensitive,
+				 s.create_at as status_create_at,
+				 a.id as account_id,
+				 a.username,
+				 a.display_name,
+				 a.avatar,
+				 a.header,
+				 a.note,
+				 a.locked,
+				 a.create_at as account_create_at
+	FROM status s
+	INNER JOIN account a ON s.account_id = a.id
+	`
+	whereClauses := []string{"MATCH (s.content) AGAINST (? IN BOOLEAN MODE)", "a.suspended_at IS NULL"}
+	args := []interface{}{fulltextPhrase(q)}
+
+	clause, visibleArgs := visibleCondition(opts.ViewerID)
+	whereClauses = append(whereClauses, clause)
+	args = append(args, visibleArgs...)
+	if opts.ViewerID > 0 {
+		clauses, viewerArgs := viewerFilter(opts.ViewerID)
+		whereClauses = append(whereClauses, clauses...)
+		args = append(args, viewerArgs...)
+	}
+
+	query += " WHERE " + strings.Join(whereClauses, " AND ")
+	query += " ORDER BY s.id DESC LIMIT ? OFFSET ?"
+	args = append(args, opts.Limit, opts.Offset)
+
+	ctx, span := startSpan(ctx, "search.Statuses", query)
+	defer func() { endSpan(span, err) }()
+
+	rows, err := r.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	statuses := make([]*object.Status, 0)
+	for rows.Next() {
+		status := new(object.Status)
+		account := new(object.Account)
+		err := rows.Scan(
+			&status.ID,
+			&status.Content,
+			&status.Visibility,
+			&status.SpoilerText,
+			&status.Sensitive,
+			&status.CreateAt,
+			&account.ID,
+			&account.Username,
+			&account.DisplayName,
+			&account.Avatar,
+			&account.Header,
+			&account.Note,
+			&account.Locked,
+			&account.CreateAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		status.Account = account
+		statuses = append(statuses, status)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	setRowCount(span, int64(len(statuses)))
+
+	return statuses, nil
+}
+
+// Hashtags : 名前の前方一致でタグを検索する
+func (r *search) Hashtags(ctx context.Context, q string, opts repository.SearchOptions) (_ []*object.Tag, err error) {
+	query := `
+	SELECT id, name
+	FROM tag
+	WHERE name LIKE ?
+	ORDER BY CHAR_LENGTH(name), name
+	LIMIT ? OFFSET ?
+	`
+	ctx, span := startSpan(ctx, "search.Hashtags", query)
+	defer func() { endSpan(span, err) }()
+
+	name := strings.ToLower(strings.TrimLeft(q, "#＃"))
+	tags := make([]*object.Tag, 0)
+	if err := r.db.SelectContext(ctx, &tags, query, likePrefix(name), opts.Limit, opts.Offset); err != nil {
+		return nil, err
+	}
+	setRowCount(span, int64(len(tags)))
+
+	return tags, nil
+}
@@ -26,10 +26,22 @@ func NewStatus(db *sqlx.DB) repository.Status {
 }
 
 // FindWIthAccountByID : アカウントの情報と共にステータスを取得する
+// 凍結されたアカウントのステータスは見つからないものとして扱う
 func (r *status) FindWithAccountByID(ctx context.Context, id object.StatusID) (*object.Status, error) {
-	query := `
+	return r.findWithAccount(ctx, "status.FindWithAccountByID", findWithAccountQuery+" AND a.suspended_at IS NULL", id)
+}
+
+// FindForModeration : 凍結されたアカウントのものも含めてステータスを取得する
+func (r *status) FindForModeration(ctx context.Context, id object.StatusID) (*object.Status, error) {
+	return r.findWithAccount(ctx, "status.FindForModeration", findWithAccountQuery, id)
+}
+
+const findWithAccountQuery = `
 	SELECT s.id,
 				 s.content,
+				 s.visibility,
+				 s.spoiler_text,
+				 s.sensitive,
 				 s.create_at as status_create_at,
 				 a.id,
 				 a.username,
@@ -38,16 +50,24 @@ func (r *status) FindWithAccountByID(ctx context.Context, id object.StatusID) (*
 				 a.avatar,
 				 a.header,
 				 a.note,
+				 a.locked,
 				 a.create_at as account_create_at
 	FROM status s
 	INNER JOIN account a ON s.account_id = a.id
-	WHERE s.id = ?
-	`
+	WHERE s.id = ?`
+
+func (r *status) findWithAccount(ctx context.Context, name, query string, id object.StatusID) (_ *object.Status, err error) {
+	ctx, span := startSpan(ctx, name, query)
+	defer func() { endSpan(span, err) }()
+
 	statusEntity := new(object.Status)
 	accountEntity := new(object.Account)
-	err := r.db.QueryRowxContext(ctx, query, id).Scan(
+	err = r.db.QueryRowxContext(ctx, query, id).Scan(
 		&statusEntity.ID,
 		&statusEntity.Content,
+		&statusEntity.Visibility,
+		&statusEntity.SpoilerText,
+		&statusEntity.Sensitive,
 		&statusEntity.CreateAt,
 		&accountEntity.ID,
 		&accountEntity.Username,
@@ -56,6 +76,7 @@ func (r *status) FindWithAccountByID(ctx context.Context, id object.StatusID) (*
 		&accountEntity.Avatar,
 		&accountEntity.Header,
 		&accountEntity.Note,
+		&accountEntity.Locked,
 		&accountEntity.CreateAt,
 	)
 
@@ -70,31 +91,123 @@ func (r *status) FindWithAccountByID(ctx context.Context, id object.StatusID) (*
 	return statusEntity, nil
 }
 
-// Add : 新規ステータス作成
-func (r *status) Add(ctx context.Context, status *object.Status) (object.StatusID, error) {
-	query := `
-	INSERT INTO status (account_id, content)
-	VALUES (?, ?)
+const insertStatusQuery = `
+	INSERT INTO status (account_id, content, visibility, spoiler_text, sensitive)
+	VALUES (?, ?, ?, ?, ?)
 `
-	result, err := r.db.ExecContext(ctx, query, status.Account.ID, status.Content)
+
+// Add : 新規ステータス作成
+// 本文のハッシュタグと投票も同じトランザクションで登録する
+func (r *status) Add(ctx context.Context, status *object.Status) (_ object.StatusID, err error) {
+	ctx, span := startSpan(ctx, "status.Add", insertStatusQuery)
+	defer func() { endSpan(span, err) }()
+
+	var id object.StatusID
+	err = withTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		id, err = insertStatus(ctx, tx, status)
+		return err
+	})
 	if err != nil {
 		return 0, err
 	}
 
+	return id, nil
+}
+
+// ステータスをハッシュタグ・投票・メンションと共に登録する
+// ダイレクトのステータスはメンションしたアカウントとの会話に入れる
+func insertStatus(ctx context.Context, tx *sqlx.Tx, status *object.Status) (object.StatusID, error) {
+	visibility := status.Visibility
+	if visibility == "" {
+		visibility = object.VisibilityPublic
+	}
+
+	result, err := tx.ExecContext(ctx, insertStatusQuery, status.Account.ID, status.Content, visibility, status.SpoilerText, status.Sensitive)
+	if err != nil {
+		return 0, err
+	}
 	id, err := result.LastInsertId()
 	if err != nil {
 		return 0, err
 	}
-
+	if status.Poll != nil {
+		if err := addPoll(ctx, tx, id, status.Poll); err != nil {
+			return 0, err
+		}
+	}
+	if err := addTags(ctx, tx, id, object.ExtractHashtags(status.Content)); err != nil {
+		return 0, err
+	}
+	mentioned, err := addMentions(ctx, tx, id, status.Account.ID, object.ExtractMentions(status.Content))
+	if err != nil {
+		return 0, err
+	}
+	if visibility == object.VisibilityDirect {
+		if err := addToConversation(ctx, tx, id, status.Account.ID, mentioned); err != nil {
+			return 0, err
+		}
+	}
 	return id, nil
 }
 
+// ステータスでメンションしたアカウントを記録し、その ID を返す
+// 存在しないアカウント、投稿者自身、投稿者をブロックしているアカウントは除く
+func addMentions(ctx context.Context, tx *sqlx.Tx, id object.StatusID, authorID object.AccountID, usernames []string) ([]object.AccountID, error) {
+	if len(usernames) == 0 {
+		return nil, nil
+	}
+	query, args, err := sqlx.In(`
+	SELECT id FROM account
+	WHERE username IN (?) AND id <> ?
+		AND id NOT IN (SELECT account_id FROM block WHERE target_account_id = ?)
+	ORDER BY id
+	`, usernames, authorID, authorID)
+	if err != nil {
+		return nil, err
+	}
+	var ids []object.AccountID
+	if err := tx.SelectContext(ctx, &ids, query, args...); err != nil {
+		return nil, err
+	}
+	for _, accountID := range ids {
+		if _, err := tx.ExecContext(ctx,
+			"INSERT INTO status_mention (status_id, account_id) VALUES (?, ?)", id, accountID); err != nil {
+			return nil, err
+		}
+	}
+	return ids, nil
+}
+
+// ステータスにタグを付ける。タグがまだなければ作る
+func addTags(ctx context.Context, tx *sqlx.Tx, id object.StatusID, names []string) error {
+	for _, name := range names {
+		// 既存のタグの場合も LAST_INSERT_ID でその ID を取得できるようにする
+		result, err := tx.ExecContext(ctx,
+			"INSERT INTO tag (name) VALUES (?) ON DUPLICATE KEY UPDATE id = LAST_INSERT_ID(id)", name)
+		if err != nil {
+			return err
+		}
+		tagID, err := result.LastInsertId()
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx,
+			"INSERT IGNORE INTO status_tag (status_id, tag_id) VALUES (?, ?)", id, tagID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // DeleteByID : ステータスの削除
-func (r *status) DeleteByID(ctx context.Context, id object.StatusID) error {
+func (r *status) DeleteByID(ctx context.Context, id object.StatusID) (err error) {
 	query := `
 		DELETE FROM status
 		WHERE id = ?
 	`
+	ctx, span := startSpan(ctx, "status.DeleteByID", query)
+	defer func() { endSpan(span, err) }()
+
 	result, err := r.db.ExecContext(ctx, query, id)
 	if err != nil {
 		return err
@@ -104,6 +217,7 @@ func (r *status) DeleteByID(ctx context.Context, id object.StatusID) error {
 	if err != nil {
 		return err
 	}
+	setRowCount(span, affectedRows)
 
 	// 削除したかを確かめる
 	if affectedRows == 0 {
@@ -113,10 +227,13 @@ func (r *status) DeleteByID(ctx context.Context, id object.StatusID) error {
 }
 
 // FindPublic : 公開中のタイムラインを取得する
-func (r *status) FindPublicTimelines(ctx context.Context, onlyMedia bool, maxID int64, sinceID int64, limit int64) (object.Timelines, error) {
+func (r *status) FindPublicTimelines(ctx context.Context, opts repository.TimelineOptions) (_ object.Timelines, err error) {
 	query := `
 	SELECT s.id as status_id,
 				 s.content,
+				 s.visibility,
+				 s.spoiler_text,
+				 s.sensitive,
 				 s.create_at as status_create_at,
 				 a.id as account_id,
 				 a.username,
@@ -125,27 +242,51 @@ func (r *status) FindPublicTimelines(ctx context.Context, onlyMedia bool, maxID
 				 a.avatar,
 				 a.header,
 				 a.note,
+				 a.locked,
 				 a.create_at as account_create_at
 	FROM status s
 	INNER JOIN account a ON s.account_id = a.id
 	`
 	// クエリの条件を格納する変数を用意
-	whereClauses := make([]string, 0)
+	// 公開タイムラインには public の投稿だけを表示する
+	whereClauses := []string{"s.visibility = 'public'"}
 	args := make([]interface{}, 0)
 
-	if onlyMedia {
+	// リストのタイムラインはメンバーの投稿のうち閲覧者が見られるものすべて
+	if opts.ListID > 0 {
+		clause, visibleArgs := visibleCondition(opts.ViewerID)
+		whereClauses = []string{clause, "s.account_id IN (SELECT account_id FROM list_account WHERE list_id = ?)"}
+		args = append(visibleArgs, opts.ListID)
+	} else {
+		// 公開範囲を制限されたアカウントは、公開とハッシュタグのタイムラインに出さない
+		whereClauses = append(whereClauses, "a.silenced_at IS NULL")
+	}
+	whereClauses = append(whereClauses, "a.suspended_at IS NULL")
+
+	if opts.Tag != "" {
+		whereClauses = append(whereClauses, "s.id IN (SELECT st.status_id FROM status_tag st INNER JOIN tag t ON st.tag_id = t.id WHERE t.name = ?)")
+		args = append(args, strings.ToLower(opts.Tag))
+	}
+
+	if opts.OnlyMedia {
 		// NOTE: bonusでmediaのテーブルを追加する
 		// whereClauses = append(whereClauses, "s.id IN (SELECT status_id FROM media)")
 	}
 
-	if maxID > 0 {
+	if opts.MaxID > 0 {
 		whereClauses = append(whereClauses, "s.id <= ?")
-		args = append(args, maxID)
+		args = append(args, opts.MaxID)
 	}
 
-	if sinceID > 0 {
+	if opts.SinceID > 0 {
 		whereClauses = append(whereClauses, "s.id >= ?")
-		args = append(args, sinceID)
+		args = append(args, opts.SinceID)
+	}
+
+	if opts.ViewerID > 0 {
+		clauses, viewerArgs := viewerFilter(opts.ViewerID)
+		whereClauses = append(whereClauses, clauses...)
+		args = append(args, viewerArgs...)
 	}
 
 	if len(whereClauses) > 0 {
@@ -154,12 +295,17 @@ func (r *status) FindPublicTimelines(ctx context.Context, onlyMedia bool, maxID
 
 	query += " ORDER BY s.create_at DESC"
 
+	limit := opts.Limit
 	if limit <= 0 || limit > 80 {
 		limit = 40
 	}
 	query += " LIMIT ?"
 
 	args = append(args, limit)
+
+	ctx, span := startSpan(ctx, "status.FindPublicTimelines", query)
+	defer func() { endSpan(span, err) }()
+
 	rows, err := r.db.QueryxContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
@@ -173,6 +319,9 @@ func (r *status) FindPublicTimelines(ctx context.Context, onlyMedia bool, maxID
 		err := rows.Scan(
 			&status.ID,
 			&status.Content,
+			&status.Visibility,
+			&status.SpoilerText,
+			&status.Sensitive,
 			&status.CreateAt,
 			&account.ID,
 			&account.Username,
@@ -181,6 +330,7 @@ func (r *status) FindPublicTimelines(ctx context.Context, onlyMedia bool, maxID
 			&account.Avatar,
 			&account.Header,
 			&account.Note,
+			&account.Locked,
 			&account.CreateAt,
 		)
 		if err != nil {
@@ -196,6 +346,262 @@ func (r *status) FindPublicTimelines(ctx context.Context, onlyMedia bool, maxID
 			return nil, fmt.Errorf("%w", err)
 		}
 	}
+	setRowCount(span, int64(len(timelines)))
 
 	return timelines, nil
 }
+
+// 閲覧者が見られる公開範囲のステータスだけに絞る条件
+// 非公開 (private) はフォロワーと本人、ダイレクト (direct) はメンションされたアカウントと本人だけが見られる
+func visibleCondition(viewerID object.AccountID) (string, []interface{}) {
+	if viewerID == 0 {
+		return "s.visibility IN ('public', 'unlisted')", nil
+	}
+	clause := `(s.visibility IN ('public', 'unlisted')
+		OR s.account_id = ?
+		OR (s.visibility = 'private' AND s.account_id IN (SELECT target_account_id FROM follow WHERE account_id = ?))
+		OR (s.visibility = 'direct' AND s.id IN (SELECT status_id FROM status_mention WHERE account_id = ?)))`
+	return clause, []interface{}{viewerID, viewerID, viewerID}
+}
+
+// 閲覧者がブロック・ミュートしているアカウントと、閲覧者をブロックしているアカウントの投稿を除外する条件
+func viewerFilter(viewerID object.AccountID) ([]string, []interface{}) {
+	clauses, args := blockFilter(viewerID)
+	clauses = append(clauses, "s.account_id NOT IN (SELECT m.target_account_id FROM mute m WHERE m.account_id = ? AND "+activeMuteCondition+")")
+	return clauses, append(args, viewerID)
+}
+
+// 閲覧者とどちらかがブロックしているアカウントのステータスを除外する条件
+// プロフィールやブックマークのように、閲覧者が自分で選んで見るものはミュートしていても表示する
+func blockFilter(viewerID object.AccountID) ([]string, []interface{}) {
+	clauses := []string{
+		"s.account_id NOT IN (SELECT target_account_id FROM block WHERE account_id = ?)",
+		"s.account_id NOT IN (SELECT account_id FROM block WHERE target_account_id = ?)",
+	}
+	return clauses, []interface{}{viewerID, viewerID}
+}
+
+// EachByAccount : アカウントのステータスを古い順に 1 件ずつ fn に渡す
+// 件数が多くてもメモリに載せきらないように、行を読みながら処理する
+func (r *status) EachByAccount(ctx context.Context, accountID object.AccountID, fn func(*object.Status) error) (err error) {
+	query := `
+	SELECT id, content, visibility, spoiler_text, sensitive, create_at
+	FROM status
+	WHERE account_id = ?
+	ORDER BY id
+	`
+	ctx, span := startSpan(ctx, "status.EachByAccount", query)
+	defer func() { endSpan(span, err) }()
+
+	rows, err := r.db.QueryxContext(ctx, query, accountID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var n int64
+	for rows.Next() {
+		entity := new(object.Status)
+		if err := rows.StructScan(entity); err != nil {
+			return err
+		}
+		if err := fn(entity); err != nil {
+			return err
+		}
+		n++
+	}
+	setRowCount(span, n)
+
+	return rows.Err()
+}
+
+// ステータスと投稿者を取得する時の列。scanStatuses で読む
+const statusColumns = `s.id,
+				 s.content,
+				 s.visibility,
+				 s.spoiler_text,
+				 s.sensitive,
+				 s.create_at,
+				 a.id,
+				 a.username,
+				 a.display_name,
+				 a.avatar,
+				 a.header,
+				 a.note,
+				 a.locked,
+				 a.create_at`
+
+// statusColumns で取得した行を読む
+func scanStatuses(rows *sqlx.Rows) ([]*object.Status, error) {
+	defer rows.Close()
+
+	statuses := make([]*object.Status, 0)
+	for rows.Next() {
+		status := &object.Status{Account: new(object.Account)}
+		err := rows.Scan(
+			&status.ID,
+			&status.Content,
+			&status.Visibility,
+			&status.SpoilerText,
+			&status.Sensitive,
+			&status.CreateAt,
+			&status.Account.ID,
+			&status.Account.Username,
+			&status.Account.DisplayName,
+			&status.Account.Avatar,
+			&status.Account.Header,
+			&status.Account.Note,
+			&status.Account.Locked,
+			&status.Account.CreateAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, rows.Err()
+}
+
+// FindByAccount : アカウントのステータスのうち閲覧者が見られるものを新しい順に取得する
+func (r *status) FindByAccount(ctx context.Context, accountID object.AccountID, opts repository.AccountStatusOptions) (_ []*object.Status, err error) {
+	from := "status s INNER JOIN account a ON s.account_id = a.id"
+	order := "s.id DESC"
+	if opts.Pinned {
+		from += " INNER JOIN status_pin p ON p.status_id = s.id"
+		order = "p.id DESC"
+	}
+
+	clause, args := visibleCondition(opts.ViewerID)
+	whereClauses := []string{clause}
+	whereClauses = append(whereClauses, "s.account_id = ?", "a.suspended_at IS NULL")
+	args = append(args, accountID)
+	if opts.MaxID > 0 {
+		whereClauses = append(whereClauses, "s.id <= ?")
+		args = append(args, opts.MaxID)
+	}
+	if opts.SinceID > 0 {
+		whereClauses = append(whereClauses, "s.id >= ?")
+		args = append(args, opts.SinceID)
+	}
+	if opts.ViewerID > 0 {
+		clauses, viewerArgs := blockFilter(opts.ViewerID)
+		whereClauses = append(whereClauses, clauses...)
+		args = append(args, viewerArgs...)
+	}
+	args = append(args, opts.Limit)
+
+	query := `
+	SELECT ` + statusColumns + `
+	FROM ` + from + `
+	WHERE ` + strings.Join(whereClauses, " AND ") + `
+	ORDER BY ` + order + `
+	LIMIT ?
+	`
+	ctx, span := startSpan(ctx, "status.FindByAccount", query)
+	defer func() { endSpan(span, err) }()
+
+	rows, err := r.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	statuses, err := scanStatuses(rows)
+	if err != nil {
+		return nil, err
+	}
+	setRowCount(span, int64(len(statuses)))
+
+	return statuses, nil
+}
+
+// FindVisibleByID : 閲覧者が見られる場合だけステータスを取得する
+// どちらかがブロックしている場合と、投稿者が凍結されている場合も見られないものとして扱う
+func (r *status) FindVisibleByID(ctx context.Context, id object.StatusID, viewerID object.AccountID) (_ *object.Status, err error) {
+	clause, visibleArgs := visibleCondition(viewerID)
+	whereClauses := []string{"s.id = ?", clause, "a.suspended_at IS NULL"}
+	args := append([]interface{}{id}, visibleArgs...)
+	if viewerID != 0 {
+		clauses, viewerArgs := blockFilter(viewerID)
+		whereClauses = append(whereClauses, clauses...)
+		args = append(args, viewerArgs...)
+	}
+	query := `
+	SELECT ` + statusColumns + `
+	FROM status s
+	INNER JOIN account a ON s.account_id = a.id
+	WHERE ` + strings.Join(whereClauses, " AND ")
+	ctx, span := startSpan(ctx, "status.FindVisibleByID", query)
+	defer func() { endSpan(span, err) }()
+
+	rows, err := r.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	statuses, err := scanStatuses(rows)
+	if err != nil || len(statuses) == 0 {
+		return nil, err
+	}
+	return statuses[0], nil
+}
+
+// SetViewerFlags : 閲覧者から見たブックマーク・ピン留めの状態を設定する
+// ピン留めは投稿者本人にだけ返す
+func (r *status) SetViewerFlags(ctx context.Context, statuses []*object.Status, viewerID object.AccountID) (err error) {
+	if viewerID == 0 || len(statuses) == 0 {
+		return nil
+	}
+	ids := make([]object.StatusID, 0, len(statuses))
+	for _, s := range statuses {
+		ids = append(ids, s.ID)
+	}
+
+	query, args, err := sqlx.In(`
+	SELECT status_id, 'bookmark' AS kind FROM bookmark WHERE account_id = ? AND status_id IN (?)
+	UNION ALL
+	SELECT status_id, 'pin' AS kind FROM status_pin WHERE account_id = ? AND status_id IN (?)
+	`, viewerID, ids, viewerID, ids)
+	if err != nil {
+		return err
+	}
+	ctx, span := startSpan(ctx, "status.SetViewerFlags", query)
+	defer func() { endSpan(span, err) }()
+
+	var flags []struct {
+		StatusID object.StatusID `db:"status_id"`
+		Kind     string          `db:"kind"`
+	}
+	if err := r.db.SelectContext(ctx, &flags, query, args...); err != nil {
+		return err
+	}
+	bookmarked := make(map[object.StatusID]bool)
+	pinned := make(map[object.StatusID]bool)
+	for _, f := range flags {
+		if f.Kind == "pin" {
+			pinned[f.StatusID] = true
+		} else {
+			bookmarked[f.StatusID] = true
+		}
+	}
+
+	for _, s := range statuses {
+		b := bookmarked[s.ID]
+		s.Bookmarked = &b
+		if s.Account != nil && s.Account.ID == viewerID {
+			p := pinned[s.ID]
+			s.Pinned = &p
+		}
+	}
+	return nil
+}
+
+// IsMentioned : ステータスでアカウントがメンションされているかを確認する
+func (r *status) IsMentioned(ctx context.Context, id object.StatusID, accountID object.AccountID) (_ bool, err error) {
+	query := "SELECT EXISTS(SELECT 1 FROM status_mention WHERE status_id = ? AND account_id = ?)"
+	ctx, span := startSpan(ctx, "status.IsMentioned", query)
+	defer func() { endSpan(span, err) }()
+
+	var exists bool
+	if err := r.db.QueryRowxContext(ctx, query, id, accountID).Scan(&exists); err != nil {
+		return false, err
+	}
+	return exists, nil
+}
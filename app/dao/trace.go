@@ -0,0 +1,45 @@
+package dao
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Key of the number of rows returned or affected by a statement
+const rowCountKey = attribute.Key("db.row_count")
+
+var tracer = otel.Tracer("yatter-backend-go/app/dao")
+
+// Start span for a repository method
+//
+// name はリポジトリのメソッド名 (`status.FindPublicTimelines`) で、
+// タイムラインが遅い時にどの SQL が原因か分かるようにする
+func startSpan(ctx context.Context, name string, query string) (context.Context, trace.Span) {
+	return tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemMySQL,
+			semconv.DBOperationKey.String(name),
+			semconv.DBStatementKey.String(query),
+		),
+	)
+}
+
+// Record number of rows on span
+func setRowCount(span trace.Span, n int64) {
+	span.SetAttributes(rowCountKey.Int64(n))
+}
+
+// End span with the error returned by the repository method
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,340 @@
+package dao
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+	"yatter-backend-go/app/domain/customerror"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/domain/repository"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type (
+	// Implementation for repository.Trend
+	trend struct {
+		db *sqlx.DB
+	}
+)
+
+// Create trend repository
+func NewTrend(db *sqlx.DB) repository.Trend {
+	return &trend{db: db}
+}
+
+// TagUsages : 公開投稿でのハッシュタグの使用状況を集計する
+// 同じアカウントが何度使っても 1 アカウントとして数え、最後に使った時刻から半減期で重みを減らす
+func (r *trend) TagUsages(ctx context.Context, since time.Time, halfLife time.Duration) (_ []*object.TagUsage, err error) {
+	query := `
+	SELECT u.tag_id,
+				 t.name,
+				 SUM(u.uses_hour) AS uses_hour,
+				 SUM(u.uses) AS uses_day,
+				 COUNT(*) AS accounts,
+				 SUM(POW(0.5, TIMESTAMPDIFF(SECOND, u.last_use, NOW()) / ?)) AS decayed
+	FROM (
+		SELECT st.tag_id,
+					 s.account_id,
+					 COUNT(*) AS uses,
+					 SUM(s.create_at >= NOW() - INTERVAL 1 HOUR) AS uses_hour,
+					 MAX(s.create_at) AS last_use
+		FROM status_tag st
+		INNER JOIN status s ON st.status_id = s.id
+		WHERE s.create_at >= ? AND s.visibility = 'public'
+		GROUP BY st.tag_id, s.account_id
+	) u
+	INNER JOIN tag t ON u.tag_id = t.id
+	WHERE t.trendable IS NULL OR t.trendable = TRUE
+	GROUP BY u.tag_id, t.name
+	`
+	ctx, span := startSpan(ctx, "trend.TagUsages", query)
+	defer func() { endSpan(span, err) }()
+
+	usages := make([]*object.TagUsage, 0)
+	if err := r.db.SelectContext(ctx, &usages, query, halfLife.Seconds(), since); err != nil {
+		return nil, err
+	}
+	setRowCount(span, int64(len(usages)))
+
+	return usages, nil
+}
+
+// TagBaselines : 期間内にハッシュタグを使ったアカウント数を数える
+func (r *trend) TagBaselines(ctx context.Context, from, to time.Time) (_ map[object.TagID]int, err error) {
+	query := `
+	SELECT st.tag_id, COUNT(DISTINCT s.account_id) AS accounts
+	FROM status_tag st
+	INNER JOIN status s ON st.status_id = s.id
+	WHERE s.create_at >= ? AND s.create_at < ? AND s.visibility = 'public'
+	GROUP BY st.tag_id
+	`
+	ctx, span := startSpan(ctx, "trend.TagBaselines", query)
+	defer func() { endSpan(span, err) }()
+
+	rows, err := r.db.QueryxContext(ctx, query, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	baselines := make(map[object.TagID]int)
+	for rows.Next() {
+		var id object.TagID
+		var accounts int
+		if err := rows.Scan(&id, &accounts); err != nil {
+			return nil, err
+		}
+		baselines[id] = accounts
+	}
+	setRowCount(span, int64(len(baselines)))
+
+	return baselines, rows.Err()
+}
+
+// ReplaceTags : ハッシュタグのランキングを入れ替える
+func (r *trend) ReplaceTags(ctx context.Context, trends []*object.TagTrend) (err error) {
+	query := `
+	INSERT INTO trend_tag (tag_id, score, uses_hour, uses_day, accounts)
+	VALUES (:tag_id, :score, :uses_hour, :uses_day, :accounts)
+	`
+	ctx, span := startSpan(ctx, "trend.ReplaceTags", query)
+	defer func() { endSpan(span, err) }()
+
+	return withTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM trend_tag"); err != nil {
+			return err
+		}
+		if len(trends) == 0 {
+			return nil
+		}
+		_, err := tx.NamedExecContext(ctx, query, trends)
+		return err
+	})
+}
+
+// ReplaceStatuses : ステータスのランキングを入れ替える
+// 承認済みのトレンドのハッシュタグのスコアの合計を、投稿からの経過時間で減らしたものをスコアにする
+func (r *trend) ReplaceStatuses(ctx context.Context, since time.Time, halfLife time.Duration, limit int64) (err error) {
+	query := `
+	INSERT INTO trend_status (status_id, score)
+	SELECT s.id, SUM(tt.score) * POW(0.5, TIMESTAMPDIFF(SECOND, s.create_at, NOW()) / ?) AS score
+	FROM status s
+	INNER JOIN status_tag st ON st.status_id = s.id
+	INNER JOIN trend_tag tt ON tt.tag_id = st.tag_id
+	INNER JOIN tag t ON t.id = st.tag_id
+	WHERE s.create_at >= ? AND s.visibility = 'public' AND t.trendable = TRUE
+		AND (s.trendable IS NULL OR s.trendable = TRUE)
+	GROUP BY s.id, s.create_at
+	ORDER BY score DESC
+	LIMIT ?
+	`
+	ctx, span := startSpan(ctx, "trend.ReplaceStatuses", query)
+	defer func() { endSpan(span, err) }()
+
+	return withTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM trend_status"); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx, query, halfLife.Seconds(), since, limit)
+		return err
+	})
+}
+
+// FindTags : 承認済みのトレンドのハッシュタグをスコア順に取得する
+func (r *trend) FindTags(ctx context.Context, limit, offset int64) (_ []*object.TagTrend, err error) {
+	query := `
+	SELECT tt.*, t.name, t.trendable
+	FROM trend_tag tt
+	INNER JOIN tag t ON tt.tag_id = t.id
+	WHERE t.trendable = TRUE
+	ORDER BY tt.score DESC
+	LIMIT ? OFFSET ?
+	`
+	ctx, span := startSpan(ctx, "trend.FindTags", query)
+	defer func() { endSpan(span, err) }()
+
+	trends := make([]*object.TagTrend, 0)
+	if err := r.db.SelectContext(ctx, &trends, query, limit, offset); err != nil {
+		return nil, err
+	}
+	setRowCount(span, int64(len(trends)))
+
+	return trends, nil
+}
+
+// FindTagCandidates : 未審査のものも含めてハッシュタグをスコア順に取得する
+func (r *trend) FindTagCandidates(ctx context.Context, limit int64) (_ []*object.TagTrend, err error) {
+	query := `
+	SELECT tt.*, t.name, t.trendable
+	FROM trend_tag tt
+	INNER JOIN tag t ON tt.tag_id = t.id
+	ORDER BY tt.score DESC
+	LIMIT ?
+	`
+	ctx, span := startSpan(ctx, "trend.FindTagCandidates", query)
+	defer func() { endSpan(span, err) }()
+
+	trends := make([]*object.TagTrend, 0)
+	if err := r.db.SelectContext(ctx, &trends, query, limit); err != nil {
+		return nil, err
+	}
+	setRowCount(span, int64(len(trends)))
+
+	return trends, nil
+}
+
+// FindStatuses : 承認済みのトレンドのステータスをスコア順に取得する
+func (r *trend) FindStatuses(ctx context.Context, opts repository.TrendOptions) (_ []*object.Status, err error) {
+	whereClauses := []string{"s.trendable = TRUE", "a.silenced_at IS NULL", "a.suspended_at IS NULL"}
+	args := make([]interface{}, 0)
+	if opts.ViewerID > 0 {
+		clauses, viewerArgs := viewerFilter(opts.ViewerID)
+		whereClauses = append(whereClauses, clauses...)
+		args = append(args, viewerArgs...)
+	}
+	args = append(args, opts.Limit, opts.Offset)
+
+	query := `
+	SELECT ` + trendStatusColumns + `
+	FROM trend_status ts
+	INNER JOIN status s ON ts.status_id = s.id
+	INNER JOIN account a ON s.account_id = a.id
+	WHERE ` + strings.Join(whereClauses, " AND ") + `
+	ORDER BY ts.score DESC
+	LIMIT ? OFFSET ?
+	`
+	ctx, span := startSpan(ctx, "trend.FindStatuses", query)
+	defer func() { endSpan(span, err) }()
+
+	trends, err := r.findStatuses(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	setRowCount(span, int64(len(trends)))
+
+	statuses := make([]*object.Status, 0, len(trends))
+	for _, t := range trends {
+		statuses = append(statuses, t.Status)
+	}
+	return statuses, nil
+}
+
+// FindStatusCandidates : 未審査のものも含めてステータスをスコア順に取得する
+func (r *trend) FindStatusCandidates(ctx context.Context, limit int64) (_ []*object.StatusTrend, err error) {
+	query := `
+	SELECT ` + trendStatusColumns + `
+	FROM trend_status ts
+	INNER JOIN status s ON ts.status_id = s.id
+	INNER JOIN account a ON s.account_id = a.id
+	ORDER BY ts.score DESC
+	LIMIT ?
+	`
+	ctx, span := startSpan(ctx, "trend.FindStatusCandidates", query)
+	defer func() { endSpan(span, err) }()
+
+	trends, err := r.findStatuses(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	setRowCount(span, int64(len(trends)))
+
+	return trends, nil
+}
+
+const trendStatusColumns = `ts.score,
+				 s.trendable,
+				 s.id,
+				 s.content,
+				 s.visibility,
+				 s.spoiler_text,
+				 s.sensitive,
+				 s.create_at,
+				 a.id,
+				 a.username,
+				 a.display_name,
+				 a.avatar,
+				 a.header,
+				 a.note,
+				 a.locked,
+				 a.create_at`
+
+func (r *trend) findStatuses(ctx context.Context, query string, args ...interface{}) ([]*object.StatusTrend, error) {
+	rows, err := r.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	trends := make([]*object.StatusTrend, 0)
+	for rows.Next() {
+		t := &object.StatusTrend{Status: &object.Status{Account: new(object.Account)}}
+		err := rows.Scan(
+			&t.Score,
+			&t.Trendable,
+			&t.Status.ID,
+			&t.Status.Content,
+			&t.Status.Visibility,
+			&t.Status.SpoilerText,
+			&t.Status.Sensitive,
+			&t.Status.CreateAt,
+			&t.Status.Account.ID,
+			&t.Status.Account.Username,
+			&t.Status.Account.DisplayName,
+			&t.Status.Account.Avatar,
+			&t.Status.Account.Header,
+			&t.Status.Account.Note,
+			&t.Status.Account.Locked,
+			&t.Status.Account.CreateAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		trends = append(trends, t)
+	}
+	return trends, rows.Err()
+}
+
+// SetTagTrendable : ハッシュタグをトレンドに載せてよいかを設定する
+func (r *trend) SetTagTrendable(ctx context.Context, name string, trendable bool, actorID object.AccountID) (err error) {
+	query := "UPDATE tag SET trendable = ? WHERE name = ?"
+	ctx, span := startSpan(ctx, "trend.SetTagTrendable", query)
+	defer func() { endSpan(span, err) }()
+
+	name = strings.ToLower(name)
+	return r.setTrendable(ctx, "SELECT EXISTS(SELECT 1 FROM tag WHERE name = ?)", query, trendable, name,
+		actorID, object.ModerationTargetTag, name)
+}
+
+// SetStatusTrendable : ステータスをトレンドに載せてよいかを設定する
+func (r *trend) SetStatusTrendable(ctx context.Context, id object.StatusID, trendable bool, actorID object.AccountID) (err error) {
+	query := "UPDATE status SET trendable = ? WHERE id = ?"
+	ctx, span := startSpan(ctx, "trend.SetStatusTrendable", query)
+	defer func() { endSpan(span, err) }()
+
+	return r.setTrendable(ctx, "SELECT EXISTS(SELECT 1 FROM status WHERE id = ?)", query, trendable, id,
+		actorID, object.ModerationTargetStatus, strconv.FormatInt(id, 10))
+}
+
+func (r *trend) setTrendable(ctx context.Context, existsQuery, query string, trendable bool, key interface{}, actorID object.AccountID, targetType, targetID string) error {
+	return withTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		// 同じ値で更新した場合も見つかったことにしたいので、RowsAffected ではなく存在を確かめる
+		var exists bool
+		if err := tx.QueryRowxContext(ctx, existsQuery, key).Scan(&exists); err != nil {
+			return err
+		}
+		if !exists {
+			return customerror.ErrNotFound
+		}
+		if _, err := tx.ExecContext(ctx, query, trendable, key); err != nil {
+			return err
+		}
+
+		action := object.ModerationActionApproveTrend
+		if !trendable {
+			action = object.ModerationActionRejectTrend
+		}
+		return addModerationLog(ctx, tx, actorID, action, targetType, targetID)
+	})
+}
@@ -5,4 +5,13 @@ import "errors"
 var (
 	// ErrNotFound is an error for not found resources
 	ErrNotFound = errors.New("resource not found")
+
+	// ErrPollExpired is an error for votes on a closed poll
+	ErrPollExpired = errors.New("poll has already ended")
+
+	// ErrAlreadyVoted is an error for a second vote on the same poll
+	ErrAlreadyVoted = errors.New("already voted on poll")
+
+	// ErrTooManyPins is an error for pinning more statuses than allowed
+	ErrTooManyPins = errors.New("too many pinned statuses")
 )
@@ -38,9 +38,83 @@ type (
 
 		// URL to the header image
 		Header *string `json:"header,omitempty" db:"header"`
+
+		// Whether follows must be approved by the account
+		Locked bool `json:"locked" db:"locked"`
+
+		// Whether statuses with content warnings are expanded by default
+		ExpandSpoilers bool `json:"-" db:"expand_spoilers"`
+
+		// How media marked as sensitive is displayed. One of ExpandMedia*
+		ExpandMedia string `json:"-" db:"expand_media"`
+
+		// The time the account will be deleted, or nil if not scheduled
+		DeleteScheduledAt *DateTime `json:"-" db:"delete_scheduled_at"`
+
+		// What the account is allowed to do. One of Role*
+		Role Role `json:"-" db:"role"`
+
+		// The time the account was hidden from public timelines, or nil if not silenced
+		SilencedAt *DateTime `json:"-" db:"silenced_at"`
+
+		// The time the account was disabled, or nil if not suspended
+		SuspendedAt *DateTime `json:"-" db:"suspended_at"`
 	}
+
+	// Role of an account
+	Role = string
+)
+
+const (
+	// Regular account
+	RoleUser = "user"
+	// Can handle reports, review trends and take actions against users
+	RoleModerator = "moderator"
+	// Can also delete accounts and change roles
+	RoleAdmin = "admin"
+)
+
+// State of an account seen by moderators
+const (
+	AccountStatusActive    = "active"
+	AccountStatusSilenced  = "silenced"
+	AccountStatusSuspended = "suspended"
 )
 
+// 強い権限ほど大きい
+var roleRanks = map[Role]int{
+	RoleUser:      0,
+	RoleModerator: 1,
+	RoleAdmin:     2,
+}
+
+// Check if given string is a known role
+func IsValidRole(v string) bool {
+	_, ok := roleRanks[v]
+	return ok
+}
+
+// Report the state of the account. Suspension takes precedence over silence
+func (a *Account) Status() string {
+	switch {
+	case a.SuspendedAt != nil:
+		return AccountStatusSuspended
+	case a.SilencedAt != nil:
+		return AccountStatusSilenced
+	}
+	return AccountStatusActive
+}
+
+// Check if the account has the role or a stronger one
+func (a *Account) HasRole(role Role) bool {
+	return roleRanks[a.Role] >= roleRanks[role]
+}
+
+// Check if the account has a stronger role than other, so that it can take actions against other
+func (a *Account) Outranks(other *Account) bool {
+	return roleRanks[a.Role] > roleRanks[other.Role]
+}
+
 // Check if given password is match to account's password
 func (a *Account) CheckPassword(pass string) bool {
 	return bcrypt.CompareHashAndPassword([]byte(a.PasswordHash), []byte(pass)) == nil
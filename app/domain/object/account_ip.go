@@ -0,0 +1,18 @@
+package object
+
+type (
+	// IP address an account has used
+	AccountIP struct {
+		// The account which used the address
+		AccountID AccountID `json:"-" db:"account_id"`
+
+		// IPv4 or IPv6 address
+		IP string `json:"ip" db:"ip"`
+
+		// The time the address was first used
+		FirstUsedAt DateTime `json:"first_used_at" db:"first_used_at"`
+
+		// The time the address was last used
+		LastUsedAt DateTime `json:"last_used_at" db:"last_used_at"`
+	}
+)
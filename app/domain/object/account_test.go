@@ -0,0 +1,34 @@
+package object
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccount_Role(t *testing.T) {
+	user := &Account{Role: RoleUser}
+	moderator := &Account{Role: RoleModerator}
+	admin := &Account{Role: RoleAdmin}
+
+	assert.False(t, user.HasRole(RoleModerator))
+	assert.True(t, moderator.HasRole(RoleModerator))
+	assert.False(t, moderator.HasRole(RoleAdmin))
+	assert.True(t, admin.HasRole(RoleModerator))
+
+	assert.True(t, moderator.Outranks(user))
+	assert.False(t, moderator.Outranks(moderator))
+	assert.False(t, moderator.Outranks(admin))
+	assert.True(t, admin.Outranks(moderator))
+
+	// 不明な権限は一般ユーザーとして扱う
+	assert.False(t, (&Account{}).HasRole(RoleModerator))
+	assert.True(t, moderator.Outranks(&Account{}))
+}
+
+func TestAccount_Status(t *testing.T) {
+	now := &DateTime{}
+	assert.Equal(t, AccountStatusActive, (&Account{}).Status())
+	assert.Equal(t, AccountStatusSilenced, (&Account{SilencedAt: now}).Status())
+	assert.Equal(t, AccountStatusSuspended, (&Account{SilencedAt: now, SuspendedAt: now}).Status())
+}
@@ -0,0 +1,67 @@
+package object
+
+type (
+	AccountWarningID = int64
+
+	// What a moderator does to an account. One of AccountAction*
+	AccountActionType = string
+
+	// Action taken by a moderator against an account
+	AccountAction struct {
+		// What to do
+		Type AccountActionType
+
+		// The account the action is taken against
+		TargetAccountID AccountID
+
+		// The moderator taking the action
+		ActorID AccountID
+
+		// Reason told to the account
+		Text string
+	}
+
+	// Record of an action taken against an account, kept with the account
+	AccountWarning struct {
+		// The internal ID of the warning
+		ID AccountWarningID `json:"id" db:"id"`
+
+		// The warned account
+		AccountID AccountID `json:"-" db:"account_id"`
+
+		// What was done. One of AccountAction*
+		Action AccountActionType `json:"action" db:"action"`
+
+		// Reason told to the account
+		Text string `json:"text" db:"text"`
+
+		// The time the action was taken
+		CreateAt DateTime `json:"create_at" db:"create_at"`
+	}
+)
+
+const (
+	// Only record a warning
+	AccountActionWarn = "warn"
+	// Hide from public timelines and trends
+	AccountActionSilence = "silence"
+	// Undo silence
+	AccountActionUnsilence = "unsilence"
+	// Disable the account and hide it from timelines
+	AccountActionSuspend = "suspend"
+	// Undo suspend
+	AccountActionUnsuspend = "unsuspend"
+	// Suspend the account and delete it with its data
+	AccountActionDelete = "delete"
+)
+
+// Whether the action is recorded as a warning to the account
+//
+// 取り消しの操作は警告として残さない
+func (a *AccountAction) Warns() bool {
+	switch a.Type {
+	case AccountActionWarn, AccountActionSilence, AccountActionSuspend, AccountActionDelete:
+		return true
+	}
+	return false
+}
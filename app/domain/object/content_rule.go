@@ -0,0 +1,252 @@
+package object
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type (
+	ContentRuleID = int64
+
+	// How a rule matches statuses. One of ContentRuleType*
+	ContentRuleType = string
+
+	// What happens to a matching status. One of ContentRuleAction*
+	ContentRuleAction = string
+
+	// Instance-wide rule checked when statuses are posted
+	ContentRule struct {
+		// The internal ID of the rule
+		ID ContentRuleID `json:"id" db:"id"`
+
+		// How the rule matches statuses
+		Type ContentRuleType `json:"type" db:"type"`
+
+		// Keyword, regular expression, link domain or maximum number of mentions, depending on Type
+		Value string `json:"value" db:"value"`
+
+		// What happens to a matching status
+		Action ContentRuleAction `json:"action" db:"action"`
+
+		// Apply only to accounts younger than this many seconds. 0 applies to all accounts
+		MaxAccountAge int64 `json:"max_account_age" db:"max_account_age"`
+
+		// The time the rule was created
+		CreateAt DateTime `json:"create_at" db:"create_at"`
+	}
+
+	// IDs of rules stored as JSON array
+	ContentRuleIDs []ContentRuleID
+
+	// Result of checking a status against rules
+	ContentDecision struct {
+		// The status must not be posted
+		Reject bool
+
+		// The status is posted and queued for review
+		Flag bool
+
+		// The status is posted as sensitive
+		Sensitive bool
+
+		// Rules the status matched
+		RuleIDs ContentRuleIDs
+	}
+)
+
+const (
+	// Case-insensitive substring of the text
+	ContentRuleTypeKeyword = "keyword"
+	// Regular expression (RE2 syntax) searched in the text
+	ContentRuleTypeRegex = "regex"
+	// Links to the domain or its subdomains. "*" matches any link
+	ContentRuleTypeDomain = "domain"
+	// More mentions than the value
+	ContentRuleTypeMentions = "mentions"
+
+	ContentRuleActionReject    = "reject"
+	ContentRuleActionFlag      = "flag"
+	ContentRuleActionSensitive = "sensitive"
+)
+
+// 本文中のリンクのホスト名
+// https://user@spam.example/ のようなユーザー情報は読み飛ばす。ブラウザと同じく最後の @ までがユーザー情報で、
+// \ は / と同じくパスの区切りとして扱う
+var linkPattern = regexp.MustCompile(`(?i)https?://(?:[^\s/\\?#]*@)?([^\s/\\?#:@]+)`)
+
+// Trim the value and check if the rule can be compiled
+//
+// 保存する値とコンパイルする値が食い違わないように、前後の空白はここで取り除いておく
+func (r *ContentRule) Validate() error {
+	r.Value = strings.TrimSpace(r.Value)
+	switch r.Action {
+	case ContentRuleActionReject, ContentRuleActionFlag, ContentRuleActionSensitive:
+	default:
+		return fmt.Errorf("unknown action %q", r.Action)
+	}
+	if r.MaxAccountAge < 0 {
+		return errors.New("max_account_age must not be negative")
+	}
+	_, err := compileContentRule(r)
+	return err
+}
+
+// database/sql/driver/Valuer
+func (ids ContentRuleIDs) Value() (driver.Value, error) {
+	if ids == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal([]ContentRuleID(ids))
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// database/sql/Scanner
+func (ids *ContentRuleIDs) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*ids = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, ids)
+	case string:
+		return json.Unmarshal([]byte(v), ids)
+	default:
+		return fmt.Errorf("cannot scan %T into ContentRuleIDs", value)
+	}
+}
+
+// The strongest action among matched rules, or empty if no rule matched
+func (d *ContentDecision) Action() ContentRuleAction {
+	switch {
+	case d.Reject:
+		return ContentRuleActionReject
+	case d.Flag:
+		return ContentRuleActionFlag
+	case d.Sensitive:
+		return ContentRuleActionSensitive
+	}
+	return ""
+}
+
+// Rules compiled to check statuses
+//
+// 投稿のたびに作り直す。ルールの数は少ない想定
+type ContentRuleSet struct {
+	rules []*compiledContentRule
+}
+
+type compiledContentRule struct {
+	*ContentRule
+
+	// keyword と regex で使う
+	re *regexp.Regexp
+	// mentions で使う
+	maxMentions int
+	// domain で使う。小文字にしてある
+	domain string
+}
+
+// Compile rules to check statuses
+func CompileContentRules(rules []*ContentRule) (*ContentRuleSet, error) {
+	set := &ContentRuleSet{rules: make([]*compiledContentRule, 0, len(rules))}
+	for _, r := range rules {
+		c, err := compileContentRule(r)
+		if err != nil {
+			return nil, fmt.Errorf("content rule %d: %w", r.ID, err)
+		}
+		set.rules = append(set.rules, c)
+	}
+	return set, nil
+}
+
+func compileContentRule(r *ContentRule) (*compiledContentRule, error) {
+	c := &compiledContentRule{ContentRule: r}
+	if r.Value == "" {
+		return nil, errors.New("value is required")
+	}
+	var err error
+	switch r.Type {
+	case ContentRuleTypeKeyword:
+		c.re = regexp.MustCompile("(?i)" + regexp.QuoteMeta(r.Value))
+	case ContentRuleTypeRegex:
+		if c.re, err = regexp.Compile(r.Value); err != nil {
+			return nil, fmt.Errorf("invalid regular expression: %w", err)
+		}
+	case ContentRuleTypeDomain:
+		c.domain = strings.ToLower(strings.TrimPrefix(r.Value, "."))
+	case ContentRuleTypeMentions:
+		if c.maxMentions, err = strconv.Atoi(r.Value); err != nil || c.maxMentions < 0 {
+			return nil, errors.New("value of mentions rule must be a non-negative integer")
+		}
+	default:
+		return nil, fmt.Errorf("unknown type %q", r.Type)
+	}
+	return c, nil
+}
+
+// Check the status posted by status.Account at now
+//
+// 警告文、本文、投票の選択肢をまとめて調べる
+func (s *ContentRuleSet) Evaluate(status *Status, now time.Time) *ContentDecision {
+	d := &ContentDecision{}
+	if s == nil || len(s.rules) == 0 {
+		return d
+	}
+
+	texts := []string{status.SpoilerText, status.Content}
+	if status.Poll != nil {
+		for _, o := range status.Poll.Options {
+			texts = append(texts, o.Title)
+		}
+	}
+	text := strings.Join(texts, "\n")
+	mentions := len(ExtractMentions(status.Content))
+	hosts := make([]string, 0)
+	for _, m := range linkPattern.FindAllStringSubmatch(text, -1) {
+		hosts = append(hosts, strings.ToLower(m[1]))
+	}
+
+	for _, r := range s.rules {
+		if r.MaxAccountAge > 0 && now.Sub(status.Account.CreateAt.Time) >= time.Duration(r.MaxAccountAge)*time.Second {
+			continue
+		}
+		if !r.match(text, mentions, hosts) {
+			continue
+		}
+		d.RuleIDs = append(d.RuleIDs, r.ID)
+		switch r.Action {
+		case ContentRuleActionReject:
+			d.Reject = true
+		case ContentRuleActionFlag:
+			d.Flag = true
+		case ContentRuleActionSensitive:
+			d.Sensitive = true
+		}
+	}
+	return d
+}
+
+func (r *compiledContentRule) match(text string, mentions int, hosts []string) bool {
+	switch r.Type {
+	case ContentRuleTypeKeyword, ContentRuleTypeRegex:
+		return r.re.MatchString(text)
+	case ContentRuleTypeMentions:
+		return mentions > r.maxMentions
+	case ContentRuleTypeDomain:
+		for _, h := range hosts {
+			if r.domain == "*" || h == r.domain || strings.HasSuffix(h, "."+r.domain) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,90 @@
+package object
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContentRule_Validate(t *testing.T) {
+	valid := &ContentRule{Type: ContentRuleTypeRegex, Value: ` buy\s+now `, Action: ContentRuleActionFlag}
+	assert.NoError(t, valid.Validate())
+	// 保存される値とコンパイルされる値は同じく前後の空白を除いたもの
+	assert.Equal(t, `buy\s+now`, valid.Value)
+
+	for name, r := range map[string]*ContentRule{
+		"unknown type":     {Type: "url", Value: "x", Action: ContentRuleActionFlag},
+		"unknown action":   {Type: ContentRuleTypeKeyword, Value: "x", Action: "hide"},
+		"empty value":      {Type: ContentRuleTypeKeyword, Value: " ", Action: ContentRuleActionFlag},
+		"invalid regex":    {Type: ContentRuleTypeRegex, Value: "(", Action: ContentRuleActionFlag},
+		"invalid mentions": {Type: ContentRuleTypeMentions, Value: "many", Action: ContentRuleActionFlag},
+		"negative age":     {Type: ContentRuleTypeKeyword, Value: "x", Action: ContentRuleActionFlag, MaxAccountAge: -1},
+	} {
+		assert.Error(t, r.Validate(), name)
+	}
+}
+
+func TestContentRuleSet_Evaluate(t *testing.T) {
+	now := time.Now()
+	set, err := CompileContentRules([]*ContentRule{
+		{ID: 1, Type: ContentRuleTypeKeyword, Value: "Casino", Action: ContentRuleActionFlag},
+		{ID: 2, Type: ContentRuleTypeDomain, Value: "spam.example", Action: ContentRuleActionReject},
+		{ID: 3, Type: ContentRuleTypeMentions, Value: "2", Action: ContentRuleActionSensitive},
+		// 作成から 1 日以内のアカウントはリンクを貼れない
+		{ID: 4, Type: ContentRuleTypeDomain, Value: "*", Action: ContentRuleActionReject, MaxAccountAge: 24 * 60 * 60},
+	})
+	assert.NoError(t, err)
+
+	oldAccount := &Account{CreateAt: DateTime{Time: now.Add(-48 * time.Hour)}}
+	newAccount := &Account{CreateAt: DateTime{Time: now.Add(-time.Hour)}}
+
+	t.Run("no match", func(t *testing.T) {
+		d := set.Evaluate(&Status{Account: oldAccount, Content: "hello https://example.com"}, now)
+		assert.Equal(t, ContentRuleAction(""), d.Action())
+		assert.Empty(t, d.RuleIDs)
+	})
+
+	t.Run("keyword in poll", func(t *testing.T) {
+		d := set.Evaluate(&Status{
+			Account: oldAccount,
+			Content: "vote",
+			Poll:    &Poll{Options: []*PollOption{{Title: "online CASINO"}}},
+		}, now)
+		assert.Equal(t, ContentRuleActionFlag, d.Action())
+		assert.Equal(t, ContentRuleIDs{1}, d.RuleIDs)
+	})
+
+	t.Run("subdomain", func(t *testing.T) {
+		d := set.Evaluate(&Status{Account: oldAccount, Content: "see https://www.Spam.example/x"}, now)
+		assert.True(t, d.Reject)
+		assert.Equal(t, ContentRuleIDs{2}, d.RuleIDs)
+	})
+
+	t.Run("userinfo", func(t *testing.T) {
+		d := set.Evaluate(&Status{Account: oldAccount, Content: "see https://u@spam.example/x"}, now)
+		assert.True(t, d.Reject)
+		assert.Equal(t, ContentRuleIDs{2}, d.RuleIDs)
+
+		d = set.Evaluate(&Status{Account: oldAccount, Content: "see https://u:p@example.com@spam.example/x"}, now)
+		assert.Equal(t, ContentRuleIDs{2}, d.RuleIDs)
+
+		// \ の後はパスなので、@ の前がホスト名になる
+		d = set.Evaluate(&Status{Account: oldAccount, Content: `see https://spam.example\@example.com/x`}, now)
+		assert.Equal(t, ContentRuleIDs{2}, d.RuleIDs)
+	})
+
+	t.Run("combined", func(t *testing.T) {
+		d := set.Evaluate(&Status{Account: oldAccount, Content: "@a @b @c casino"}, now)
+		assert.True(t, d.Flag)
+		assert.True(t, d.Sensitive)
+		assert.Equal(t, ContentRuleActionFlag, d.Action())
+		assert.Equal(t, ContentRuleIDs{1, 3}, d.RuleIDs)
+	})
+
+	t.Run("new account", func(t *testing.T) {
+		d := set.Evaluate(&Status{Account: newAccount, Content: "https://example.com"}, now)
+		assert.Equal(t, ContentRuleActionReject, d.Action())
+		assert.Equal(t, ContentRuleIDs{4}, d.RuleIDs)
+	})
+}
@@ -0,0 +1,23 @@
+package object
+
+type (
+	ConversationID = int64
+
+	// Direct statuses between the same participants, seen by one of them
+	Conversation struct {
+		// The internal ID of the conversation
+		ID ConversationID `json:"id" db:"conversation_id"`
+
+		// Whether the conversation has statuses the account has not read
+		Unread bool `json:"unread" db:"unread"`
+
+		// Participants other than the account
+		Accounts []*Account `json:"accounts" db:"-"`
+
+		// The newest status of the conversation
+		LastStatus *Status `json:"last_status" db:"-"`
+
+		// The ID of LastStatus
+		LastStatusID StatusID `json:"-" db:"last_status_id"`
+	}
+)
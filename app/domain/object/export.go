@@ -0,0 +1,47 @@
+package object
+
+type (
+	ExportID    = int64
+	ExportState = string
+
+	// Archive of personal data requested by an account
+	Export struct {
+		// The internal ID of the export
+		ID ExportID `json:"id" db:"id"`
+
+		// The account which requested the export
+		AccountID AccountID `json:"-" db:"account_id"`
+
+		// Progress of the export
+		State ExportState `json:"state" db:"state"`
+
+		// The time a worker started building the archive
+		ClaimedAt *DateTime `json:"-" db:"claimed_at"`
+
+		// Secret part of the download link, set when the archive is ready
+		Token *string `json:"-" db:"token"`
+
+		// Path to the archive on disk
+		Path *string `json:"-" db:"path"`
+
+		// URL to download the archive, set when the archive is ready
+		URL string `json:"url,omitempty" db:"-"`
+
+		// The time the download link expires
+		ExpiresAt *DateTime `json:"expires_at,omitempty" db:"expires_at"`
+
+		// The time the export was requested
+		CreateAt DateTime `json:"create_at,omitempty" db:"create_at"`
+	}
+)
+
+const (
+	// Waiting for a worker
+	ExportStatePending ExportState = "pending"
+	// Being built by a worker
+	ExportStateRunning ExportState = "running"
+	// Ready to download
+	ExportStateDone ExportState = "done"
+	// Failed to build
+	ExportStateFailed ExportState = "failed"
+)
@@ -0,0 +1,285 @@
+package object
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type (
+	FilterID        = int64
+	FilterKeywordID = int64
+
+	// Where a filter applies. One of FilterContext*
+	FilterContext = string
+
+	// What happens to a matching status. One of FilterAction*
+	FilterAction = string
+
+	// Keyword filter of an account
+	Filter struct {
+		// The internal ID of the filter
+		ID FilterID `json:"id" db:"id"`
+
+		// The account which owns the filter
+		AccountID AccountID `json:"-" db:"account_id"`
+
+		// The title of the filter
+		Title string `json:"title" db:"title"`
+
+		// Where the filter applies
+		Context FilterContexts `json:"context" db:"context"`
+
+		// What happens to matching statuses
+		FilterAction FilterAction `json:"filter_action" db:"filter_action"`
+
+		// The time the filter stops applying. nil if it never expires
+		ExpiresAt *DateTime `json:"expires_at" db:"expires_at"`
+
+		// Keywords to match
+		Keywords []*FilterKeyword `json:"keywords" db:"-"`
+
+		// The time the filter was created
+		CreateAt DateTime `json:"-" db:"create_at"`
+	}
+
+	// Keyword of a filter
+	FilterKeyword struct {
+		// The internal ID of the keyword
+		ID FilterKeywordID `json:"id" db:"id"`
+
+		// The filter which has the keyword
+		FilterID FilterID `json:"-" db:"filter_id"`
+
+		// The phrase to match, case-insensitively
+		Keyword string `json:"keyword" db:"keyword"`
+
+		// Whether the phrase must not be a part of a longer word
+		WholeWord bool `json:"whole_word" db:"whole_word"`
+	}
+
+	// Contexts of a filter
+	//
+	// JSON の配列として filter.context に保存する
+	FilterContexts []FilterContext
+
+	// Filter matching a status
+	FilterResult struct {
+		Filter *Filter `json:"filter"`
+
+		// Keywords of the filter found in the status
+		KeywordMatches []string `json:"keyword_matches"`
+	}
+)
+
+const (
+	// Home and list timelines
+	FilterContextHome = "home"
+	// Public and hashtag timelines
+	FilterContextPublic = "public"
+	// Notifications
+	FilterContextNotifications = "notifications"
+	// Expanded status
+	FilterContextThread = "thread"
+	// Statuses of an account profile
+	FilterContextAccount = "account"
+
+	// Show matching statuses with a warning
+	FilterActionWarn = "warn"
+	// Drop matching statuses
+	FilterActionHide = "hide"
+)
+
+// Check if given string is a known filter context
+func IsValidFilterContext(v string) bool {
+	switch v {
+	case FilterContextHome, FilterContextPublic, FilterContextNotifications, FilterContextThread, FilterContextAccount:
+		return true
+	}
+	return false
+}
+
+// Check if given string is a known filter action
+func IsValidFilterAction(v string) bool {
+	return v == FilterActionWarn || v == FilterActionHide
+}
+
+// database/sql/driver/Valuer
+func (c FilterContexts) Value() (driver.Value, error) {
+	b, err := json.Marshal([]FilterContext(c))
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// database/sql/Scanner
+func (c *FilterContexts) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, c)
+	case string:
+		return json.Unmarshal([]byte(v), c)
+	default:
+		return fmt.Errorf("cannot scan %T into FilterContexts", value)
+	}
+}
+
+// Filters compiled to match statuses
+//
+// リクエストごとに一度だけ作り、全てのキーワードの出現位置を 1 つの正規表現で探す
+type FilterSet struct {
+	re *regexp.Regexp
+
+	// フィルターの持ち主。自分のステータスにはフィルターを適用しない
+	accountID AccountID
+
+	// 大文字・小文字を区別せずに同じキーワードをまとめたもの
+	groups []*filterKeywordGroup
+}
+
+// 同じ文字列のキーワードと、それぞれの持ち主のフィルター
+type filterKeywordGroup struct {
+	// 先頭に固定したキーワードの正規表現
+	re *regexp.Regexp
+
+	keywords []*FilterKeyword
+	filters  []*Filter
+}
+
+// Compile filters. Returns nil if there are no keywords, which matches nothing
+func CompileFilters(filters []*Filter) (*FilterSet, error) {
+	set := new(FilterSet)
+	byText := make(map[string]*filterKeywordGroup)
+	patterns := make([]string, 0)
+	for _, f := range filters {
+		set.accountID = f.AccountID
+		for _, k := range f.Keywords {
+			text := strings.ToLower(k.Keyword)
+			g, ok := byText[text]
+			if !ok {
+				pattern := regexp.QuoteMeta(k.Keyword)
+				re, err := regexp.Compile("(?i)^" + pattern)
+				if err != nil {
+					return nil, err
+				}
+				g = &filterKeywordGroup{re: re}
+				byText[text] = g
+				set.groups = append(set.groups, g)
+				patterns = append(patterns, pattern)
+			}
+			g.keywords = append(g.keywords, k)
+			g.filters = append(g.filters, f)
+		}
+	}
+	if len(patterns) == 0 {
+		return nil, nil
+	}
+
+	re, err := regexp.Compile("(?i)" + strings.Join(patterns, "|"))
+	if err != nil {
+		return nil, err
+	}
+	set.re = re
+	return set, nil
+}
+
+// Find filters matching the status. hide reports whether the status should be dropped
+func (s *FilterSet) Match(status *Status) (results []*FilterResult, hide bool) {
+	if s == nil || (status.Account != nil && status.Account.ID == s.accountID) {
+		return nil, false
+	}
+
+	texts := []string{status.SpoilerText, status.Content}
+	if status.Poll != nil {
+		for _, o := range status.Poll.Options {
+			texts = append(texts, o.Title)
+		}
+	}
+
+	byFilter := make(map[*Filter]*FilterResult)
+	for _, text := range texts {
+		for pos := 0; pos < len(text); {
+			loc := s.re.FindStringIndex(text[pos:])
+			if loc == nil {
+				break
+			}
+			start := pos + loc[0]
+
+			// キーワード同士が重なったり、単語の一部で外れたりしても見逃さないよう、
+			// 見つかった位置から始まる全てのキーワードを確かめてから 1 文字ずつ進める
+			for _, g := range s.groups {
+				m := g.re.FindStringIndex(text[start:])
+				if m == nil {
+					continue
+				}
+				end := start + m[1]
+				for i, k := range g.keywords {
+					if k.WholeWord && !isWordBoundary(text, start, end) {
+						continue
+					}
+					f := g.filters[i]
+					r, ok := byFilter[f]
+					if !ok {
+						r = &FilterResult{Filter: f}
+						byFilter[f] = r
+						results = append(results, r)
+					}
+					if !containsString(r.KeywordMatches, k.Keyword) {
+						r.KeywordMatches = append(r.KeywordMatches, k.Keyword)
+					}
+					if f.FilterAction == FilterActionHide {
+						hide = true
+					}
+				}
+			}
+
+			_, size := utf8.DecodeRuneInString(text[start:])
+			pos = start + size
+		}
+	}
+	return results, hide
+}
+
+// Drop statuses matching hide filters and annotate the ones matching warn filters
+func (s *FilterSet) Apply(statuses []*Status) []*Status {
+	if s == nil {
+		return statuses
+	}
+
+	kept := make([]*Status, 0, len(statuses))
+	for _, status := range statuses {
+		results, hide := s.Match(status)
+		if hide {
+			continue
+		}
+		status.Filtered = results
+		kept = append(kept, status)
+	}
+	return kept
+}
+
+// text[start:end] の前後が単語の文字でないか
+func isWordBoundary(text string, start, end int) bool {
+	before, _ := utf8.DecodeLastRuneInString(text[:start])
+	after, _ := utf8.DecodeRuneInString(text[end:])
+	// 文字列の端では utf8.RuneError になり、単語の文字とはみなされない
+	return !isWordRune(before) && !isWordRune(after)
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
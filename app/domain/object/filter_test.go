@@ -0,0 +1,163 @@
+package object
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterSet_Match(t *testing.T) {
+	warn := &Filter{
+		ID:           1,
+		FilterAction: FilterActionWarn,
+		Keywords: []*FilterKeyword{
+			{Keyword: "cat", WholeWord: true},
+			{Keyword: "ネタバレ", WholeWord: false},
+		},
+	}
+	hide := &Filter{
+		ID:           2,
+		FilterAction: FilterActionHide,
+		Keywords:     []*FilterKeyword{{Keyword: "spam", WholeWord: false}},
+	}
+	set, err := CompileFilters([]*Filter{warn, hide})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		status  *Status
+		matches map[FilterID][]string
+		hide    bool
+	}{
+		{
+			name:    "whole word",
+			status:  &Status{Content: "My Cat is cute"},
+			matches: map[FilterID][]string{1: {"cat"}},
+		},
+		{
+			name:   "part of a word",
+			status: &Status{Content: "concatenate"},
+		},
+		{
+			name:    "substring",
+			status:  &Status{Content: "最終回のネタバレあり"},
+			matches: map[FilterID][]string{1: {"ネタバレ"}},
+		},
+		{
+			name:    "spoiler text and poll",
+			status:  &Status{SpoilerText: "cat", Poll: &Poll{Options: []*PollOption{{Title: "SPAMMER"}}}},
+			matches: map[FilterID][]string{1: {"cat"}, 2: {"spam"}},
+			hide:    true,
+		},
+		{
+			name:   "no match",
+			status: &Status{Content: "dog"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, hide := set.Match(tt.status)
+			assert.Equal(t, tt.hide, hide)
+			assert.Len(t, results, len(tt.matches))
+			for _, r := range results {
+				assert.Equal(t, tt.matches[r.Filter.ID], r.KeywordMatches)
+			}
+		})
+	}
+}
+
+func TestFilterSet_Match_Overlapping(t *testing.T) {
+	warnCat := &Filter{
+		ID:           1,
+		FilterAction: FilterActionWarn,
+		Keywords:     []*FilterKeyword{{Keyword: "cat", WholeWord: true}},
+	}
+	hideCat := &Filter{
+		ID:           2,
+		FilterAction: FilterActionHide,
+		Keywords:     []*FilterKeyword{{Keyword: "cat", WholeWord: false}},
+	}
+	hideAte := &Filter{
+		ID:           3,
+		FilterAction: FilterActionHide,
+		Keywords:     []*FilterKeyword{{Keyword: "ate", WholeWord: false}},
+	}
+
+	tests := []struct {
+		name    string
+		filters []*Filter
+		status  *Status
+		matches map[FilterID][]string
+		hide    bool
+	}{
+		{
+			// 同じキーワードを持つフィルターは全てマッチする
+			name:    "same keyword in whole word and substring filters",
+			filters: []*Filter{warnCat, hideCat},
+			status:  &Status{Content: "a cat"},
+			matches: map[FilterID][]string{1: {"cat"}, 2: {"cat"}},
+			hide:    true,
+		},
+		{
+			name:    "same keyword as part of a word",
+			filters: []*Filter{warnCat, hideCat},
+			status:  &Status{Content: "concat"},
+			matches: map[FilterID][]string{2: {"cat"}},
+			hide:    true,
+		},
+		{
+			// "cat" が単語の一部で外れても、重なる "ate" を探す
+			name:    "overlapping keywords",
+			filters: []*Filter{warnCat, hideAte},
+			status:  &Status{Content: "locate"},
+			matches: map[FilterID][]string{3: {"ate"}},
+			hide:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set, err := CompileFilters(tt.filters)
+			assert.NoError(t, err)
+
+			results, hide := set.Match(tt.status)
+			assert.Equal(t, tt.hide, hide)
+			assert.Len(t, results, len(tt.matches))
+			for _, r := range results {
+				assert.Equal(t, tt.matches[r.Filter.ID], r.KeywordMatches)
+			}
+		})
+	}
+}
+
+func TestFilterSet_Apply(t *testing.T) {
+	set, err := CompileFilters([]*Filter{
+		{FilterAction: FilterActionWarn, Keywords: []*FilterKeyword{{Keyword: "warn"}}},
+		{FilterAction: FilterActionHide, Keywords: []*FilterKeyword{{Keyword: "hide"}}},
+	})
+	assert.NoError(t, err)
+
+	statuses := set.Apply([]*Status{{ID: 1, Content: "warn"}, {ID: 2, Content: "hide"}, {ID: 3, Content: "ok"}})
+	assert.Len(t, statuses, 2)
+	assert.Len(t, statuses[0].Filtered, 1)
+	assert.Nil(t, statuses[1].Filtered)
+
+	// キーワードがなければ何にもマッチしない
+	empty, err := CompileFilters([]*Filter{{FilterAction: FilterActionHide}})
+	assert.NoError(t, err)
+	assert.Len(t, empty.Apply([]*Status{{Content: "hide"}}), 1)
+}
+
+func TestFilterSet_Match_Own(t *testing.T) {
+	set, err := CompileFilters([]*Filter{
+		{AccountID: 1, FilterAction: FilterActionHide, Keywords: []*FilterKeyword{{Keyword: "hide"}}},
+	})
+	assert.NoError(t, err)
+
+	// 自分のステータスにはフィルターを適用しない
+	results, hide := set.Match(&Status{Account: &Account{ID: 1}, Content: "hide"})
+	assert.False(t, hide)
+	assert.Nil(t, results)
+
+	_, hide = set.Match(&Status{Account: &Account{ID: 2}, Content: "hide"})
+	assert.True(t, hide)
+}
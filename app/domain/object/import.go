@@ -0,0 +1,98 @@
+package object
+
+type (
+	ImportID    = int64
+	ImportType  = string
+	ImportMode  = string
+	ImportState = string
+
+	// CSV list imported by an account
+	Import struct {
+		// The internal ID of the import
+		ID ImportID `json:"id" db:"id"`
+
+		// The account which requested the import
+		AccountID AccountID `json:"-" db:"account_id"`
+
+		// Kind of the list
+		Type ImportType `json:"type" db:"type"`
+
+		// How to treat existing relationships
+		Mode ImportMode `json:"mode" db:"mode"`
+
+		// Progress of the import
+		State ImportState `json:"state" db:"state"`
+
+		// Uploaded CSV
+		Data []byte `json:"-" db:"data"`
+
+		// Number of rows in the CSV, set when the import starts
+		TotalRows int `json:"total_rows" db:"total_rows"`
+
+		// Number of rows handled so far, including failed ones
+		ProcessedRows int `json:"processed_rows" db:"processed_rows"`
+
+		// Number of rows which could not be imported
+		FailedRows int `json:"failed_rows" db:"failed_rows"`
+
+		// The time the import was requested
+		CreateAt DateTime `json:"create_at,omitempty" db:"create_at"`
+	}
+
+	// Row of an import which could not be imported
+	ImportError struct {
+		ImportID ImportID `json:"-" db:"import_id"`
+
+		// Row number in the CSV, starting from 1
+		Row int `json:"row" db:"row"`
+
+		// Account address, or status URL for bookmarks, written in the row
+		Username string `json:"username" db:"username"`
+
+		// Why the row was skipped
+		Reason string `json:"reason" db:"reason"`
+	}
+)
+
+const (
+	// Accounts to follow
+	ImportTypeFollowing ImportType = "following"
+	// Accounts to block
+	ImportTypeBlocks ImportType = "blocks"
+	// Accounts to mute
+	ImportTypeMutes ImportType = "mutes"
+	// Statuses to bookmark, one URL per line
+	ImportTypeBookmarks ImportType = "bookmarks"
+)
+
+const (
+	// Keep existing relationships and add the listed ones
+	ImportModeMerge ImportMode = "merge"
+	// Remove existing relationships which are not listed
+	ImportModeOverwrite ImportMode = "overwrite"
+)
+
+const (
+	ImportStatePending ImportState = "pending"
+	ImportStateRunning ImportState = "running"
+	ImportStateDone    ImportState = "done"
+	ImportStateFailed  ImportState = "failed"
+)
+
+// Check if given string is a known import type
+func IsValidImportType(t string) bool {
+	switch t {
+	case ImportTypeFollowing, ImportTypeBlocks, ImportTypeMutes, ImportTypeBookmarks:
+		return true
+	}
+	return false
+}
+
+// Check if given string is a known import mode
+func IsValidImportMode(m string) bool {
+	switch m {
+	case ImportModeMerge, ImportModeOverwrite:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,20 @@
+package object
+
+type (
+	ListID = int64
+
+	// List of followed accounts grouped by the owner
+	List struct {
+		// The internal ID of the list
+		ID ListID `json:"id" db:"id"`
+
+		// The account which owns the list
+		AccountID AccountID `json:"-" db:"account_id"`
+
+		// The title of the list
+		Title string `json:"title" db:"title"`
+
+		// The time the list was created
+		CreateAt DateTime `json:"-" db:"create_at"`
+	}
+)
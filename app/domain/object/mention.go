@@ -0,0 +1,22 @@
+package object
+
+import "regexp"
+
+// メールアドレスを拾わないように、単語の途中の @ は扱わない
+var mentionPattern = regexp.MustCompile(`(?:^|[^\p{L}\p{N}_&/.])[@＠]([\p{L}\p{N}_]+)`)
+
+// Extract usernames mentioned in content of status
+//
+// 重複は取り除く。ユーザー名は大文字小文字を区別するのでそのまま返す
+func ExtractMentions(content string) []string {
+	var usernames []string
+	seen := make(map[string]bool)
+	for _, m := range mentionPattern.FindAllStringSubmatch(content, -1) {
+		if seen[m[1]] {
+			continue
+		}
+		seen[m[1]] = true
+		usernames = append(usernames, m[1])
+	}
+	return usernames
+}
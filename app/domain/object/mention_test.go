@@ -0,0 +1,23 @@
+package object
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractMentions(t *testing.T) {
+	tests := []struct {
+		content string
+		want    []string
+	}{
+		{"no mentions", nil},
+		{"@john と ＠太郎 へ", []string{"john", "太郎"}},
+		{"@john @john @John", []string{"john", "John"}},
+		{"john@example.com https://example.com/@john", nil},
+		{"(@john), @sue.", []string{"john", "sue"}},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, ExtractMentions(tt.content), tt.content)
+	}
+}
@@ -0,0 +1,48 @@
+package object
+
+type (
+	ModerationLogID = int64
+
+	// Record of an action taken by a moderator
+	ModerationLog struct {
+		// The internal ID of the log
+		ID ModerationLogID `json:"id" db:"id"`
+
+		// The moderator who took the action
+		AccountID AccountID `json:"-" db:"account_id"`
+
+		// The moderator who took the action. nil if the account was deleted
+		Account *Account `json:"account" db:"-"`
+
+		// What was done. One of ModerationAction*
+		Action string `json:"action" db:"action"`
+
+		// Kind of the target. One of ModerationTarget*
+		TargetType string `json:"target_type" db:"target_type"`
+
+		// ID of the target, or the name for hashtags
+		TargetID string `json:"target_id" db:"target_id"`
+
+		// The time the action was taken
+		CreateAt DateTime `json:"create_at" db:"create_at"`
+	}
+)
+
+const (
+	ModerationTargetReport      = "report"
+	ModerationTargetTag         = "tag"
+	ModerationTargetStatus      = "status"
+	ModerationTargetAccount     = "account"
+	ModerationTargetContentRule = "content_rule"
+
+	ModerationActionAssignReport      = "assign_report"
+	ModerationActionUnassignReport    = "unassign_report"
+	ModerationActionResolveReport     = "resolve_report"
+	ModerationActionReopenReport      = "reopen_report"
+	ModerationActionApproveTrend      = "approve_trend"
+	ModerationActionRejectTrend       = "reject_trend"
+	ModerationActionChangeRole        = "change_role"
+	ModerationActionCreateContentRule = "create_content_rule"
+	ModerationActionUpdateContentRule = "update_content_rule"
+	ModerationActionDeleteContentRule = "delete_content_rule"
+)
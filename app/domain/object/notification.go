@@ -0,0 +1,38 @@
+package object
+
+type (
+	NotificationID   = int64
+	NotificationType = string
+
+	// Notification to an account
+	Notification struct {
+		// The internal ID of the notification
+		ID NotificationID `json:"id" db:"id"`
+
+		// The account which receives the notification
+		AccountID AccountID `json:"-" db:"account_id"`
+
+		// What happened
+		Type NotificationType `json:"type" db:"type"`
+
+		// The account which caused the notification
+		FromAccountID *AccountID `json:"-" db:"from_account_id"`
+
+		// The status the notification is about
+		StatusID *StatusID `json:"-" db:"status_id"`
+
+		// The account which caused the notification
+		Account *Account `json:"account,omitempty" db:"-"`
+
+		// The status the notification is about
+		Status *Status `json:"status,omitempty" db:"-"`
+
+		// The time the notification was created
+		CreateAt DateTime `json:"create_at" db:"create_at"`
+	}
+)
+
+const (
+	// A poll the account voted in or created has ended
+	NotificationTypePoll NotificationType = "poll"
+)
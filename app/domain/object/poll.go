@@ -0,0 +1,76 @@
+package object
+
+import "time"
+
+type (
+	PollID = int64
+
+	// Poll attached to a status
+	Poll struct {
+		// The internal ID of the poll
+		ID PollID `json:"id" db:"id"`
+
+		// The status the poll is attached to
+		StatusID StatusID `json:"-" db:"status_id"`
+
+		// The time the poll ends
+		ExpiresAt DateTime `json:"expires_at" db:"expires_at"`
+
+		// Whether the poll is closed
+		Expired bool `json:"expired" db:"-"`
+
+		// Whether multiple choices are allowed
+		Multiple bool `json:"multiple" db:"multiple"`
+
+		// Whether vote counts are hidden until the poll ends
+		HideTotals bool `json:"-" db:"hide_totals"`
+
+		// Number of votes, counting each choice
+		VotesCount int `json:"votes_count" db:"votes_count"`
+
+		// Number of accounts which voted. nil if multiple choices are not allowed
+		VotersCount *int `json:"voters_count" db:"voters_count"`
+
+		// Choices of the poll
+		Options []*PollOption `json:"options" db:"-"`
+
+		// Whether the viewer voted. nil if not authenticated
+		Voted *bool `json:"voted,omitempty" db:"-"`
+
+		// Indices of the options the viewer chose
+		OwnVotes []int `json:"own_votes,omitempty" db:"-"`
+
+		// The time the poll was closed by the worker
+		ClosedAt *DateTime `json:"-" db:"closed_at"`
+
+		// The time the poll was created
+		CreateAt DateTime `json:"-" db:"create_at"`
+	}
+
+	// Choice of a poll
+	PollOption struct {
+		PollID   PollID `json:"-" db:"poll_id"`
+		Position int    `json:"-" db:"position"`
+
+		// Text of the option
+		Title string `json:"title" db:"title"`
+
+		// Number of votes. nil while totals are hidden
+		VotesCount *int `json:"votes_count" db:"votes_count"`
+	}
+)
+
+// Set Expired and hide counts which must not be shown yet
+//
+// 締め切り後、ワーカーが closed_at を付けるまでの間も終了したものとして扱う
+func (p *Poll) SetExpired(now time.Time) {
+	p.Expired = p.ClosedAt != nil || !p.ExpiresAt.After(now)
+	if !p.Multiple {
+		p.VotersCount = nil
+	}
+	if p.HideTotals && !p.Expired {
+		for _, o := range p.Options {
+			o.VotesCount = nil
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package object
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPoll_SetExpired(t *testing.T) {
+	now := time.Now()
+	newPoll := func(expiresAt time.Time, multiple bool) *Poll {
+		votes, voters := 3, 2
+		return &Poll{
+			ExpiresAt:   DateTime{Time: expiresAt},
+			Multiple:    multiple,
+			HideTotals:  true,
+			VotersCount: &voters,
+			Options:     []*PollOption{{Title: "a", VotesCount: &votes}},
+		}
+	}
+
+	t.Run("open", func(t *testing.T) {
+		p := newPoll(now.Add(time.Hour), false)
+		p.SetExpired(now)
+		assert.False(t, p.Expired)
+		assert.Nil(t, p.VotersCount)
+		assert.Nil(t, p.Options[0].VotesCount)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		p := newPoll(now, true)
+		p.SetExpired(now)
+		assert.True(t, p.Expired)
+		assert.Equal(t, 2, *p.VotersCount)
+		assert.Equal(t, 3, *p.Options[0].VotesCount)
+	})
+}
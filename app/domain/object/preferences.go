@@ -0,0 +1,41 @@
+package object
+
+type (
+	// Reading preferences of the account
+	//
+	// キーは Mastodon の GET /api/v1/preferences に合わせている
+	Preferences struct {
+		// Whether statuses with content warnings are expanded by default
+		ExpandSpoilers bool `json:"reading:expand:spoilers"`
+
+		// How media marked as sensitive is displayed
+		ExpandMedia string `json:"reading:expand:media"`
+	}
+)
+
+const (
+	// Hide media marked as sensitive
+	ExpandMediaDefault = "default"
+	// Always show media
+	ExpandMediaShowAll = "show_all"
+	// Always hide media
+	ExpandMediaHideAll = "hide_all"
+)
+
+// Check if given string is a known value of reading:expand:media
+func IsValidExpandMedia(v string) bool {
+	switch v {
+	case ExpandMediaDefault, ExpandMediaShowAll, ExpandMediaHideAll:
+		return true
+	}
+	return false
+}
+
+// Reading preferences of the account
+func (a *Account) Preferences() *Preferences {
+	expandMedia := a.ExpandMedia
+	if expandMedia == "" {
+		expandMedia = ExpandMediaDefault
+	}
+	return &Preferences{ExpandSpoilers: a.ExpandSpoilers, ExpandMedia: expandMedia}
+}
@@ -0,0 +1,48 @@
+package object
+
+type (
+	// Relationship between the authenticated account and another account
+	Relationship struct {
+		// Target account id
+		ID AccountID `json:"id"`
+
+		// Whether the user is currently following the account
+		Following bool `json:"following"`
+
+		// Whether the user is currently being followed by the account
+		FollowedBy bool `json:"followed_by"`
+
+		// Whether the user has a pending follow request to the account
+		Requested bool `json:"requested"`
+
+		// Whether the user is blocking the account
+		Blocking bool `json:"blocking"`
+
+		// Whether the user is blocked by the account
+		BlockedBy bool `json:"blocked_by"`
+
+		// Whether the user is muting the account
+		Muting bool `json:"muting"`
+
+		// Whether the user is muting notifications from the account
+		MutingNotifications bool `json:"muting_notifications"`
+	}
+
+	// Mute of an account
+	Mute struct {
+		// The account muting
+		AccountID AccountID `json:"-" db:"account_id"`
+
+		// The account muted
+		TargetAccountID AccountID `json:"-" db:"target_account_id"`
+
+		// Whether notifications from the account are also hidden
+		HideNotifications bool `json:"hide_notifications" db:"hide_notifications"`
+
+		// The time the mute expires. nil means indefinite
+		ExpiresAt *DateTime `json:"expires_at,omitempty" db:"expires_at"`
+
+		// The time the mute was created
+		CreateAt DateTime `json:"create_at,omitempty" db:"create_at"`
+	}
+)
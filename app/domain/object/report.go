@@ -0,0 +1,73 @@
+package object
+
+type (
+	ReportID = int64
+
+	// Why an account was reported. One of ReportCategory*
+	ReportCategory = string
+
+	// Report of abuse by an account
+	Report struct {
+		// The internal ID of the report
+		ID ReportID `json:"id" db:"id"`
+
+		// The account which made the report. nil if made by content rules
+		AccountID *AccountID `json:"-" db:"account_id"`
+
+		// The reported account. nil once the account is deleted
+		TargetAccountID *AccountID `json:"-" db:"target_account_id"`
+
+		// Why the account was reported
+		Category ReportCategory `json:"category" db:"category"`
+
+		// Additional details from the reporter
+		Comment string `json:"comment" db:"comment"`
+
+		// The moderator handling the report. nil if unassigned
+		AssignedAccountID *AccountID `json:"-" db:"assigned_account_id"`
+
+		// The moderator who resolved the report
+		ActionTakenByAccountID *AccountID `json:"-" db:"action_taken_by_account_id"`
+
+		// The time the report was resolved. nil while it is open
+		ActionTakenAt *DateTime `json:"-" db:"action_taken_at"`
+
+		// The strongest action of the content rules which made the report
+		RuleAction *ContentRuleAction `json:"rule_action,omitempty" db:"rule_action"`
+
+		// The content rules which made the report
+		RuleIDs ContentRuleIDs `json:"rule_ids,omitempty" db:"rule_ids"`
+
+		// Whether the report has been resolved
+		ActionTaken bool `json:"action_taken" db:"-"`
+
+		// IDs of the reported statuses
+		StatusIDs []StatusID `json:"status_ids" db:"-"`
+
+		// The reported account
+		TargetAccount *Account `json:"target_account" db:"-"`
+
+		// The time the report was made
+		CreateAt DateTime `json:"create_at" db:"create_at"`
+	}
+)
+
+const (
+	// Unwanted or repetitive content
+	ReportCategorySpam = "spam"
+	// Illegal content
+	ReportCategoryLegal = "legal"
+	// Breaking the server rules
+	ReportCategoryViolation = "violation"
+	// Anything else
+	ReportCategoryOther = "other"
+)
+
+// Check if given string is a known report category
+func IsValidReportCategory(v string) bool {
+	switch v {
+	case ReportCategorySpam, ReportCategoryLegal, ReportCategoryViolation, ReportCategoryOther:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,97 @@
+package object
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+type (
+	ScheduledStatusID = int64
+
+	// Status waiting to be published
+	ScheduledStatus struct {
+		// The internal ID of the scheduled status
+		ID ScheduledStatusID `json:"id" db:"id"`
+
+		// The account which will post the status
+		AccountID AccountID `json:"-" db:"account_id"`
+
+		// The time the status will be published
+		ScheduledAt DateTime `json:"scheduled_at" db:"scheduled_at"`
+
+		// Parameters to create the status with
+		Params ScheduledStatusParams `json:"params" db:"params"`
+
+		// The report made by content rules when scheduled. The status is attached to it when published
+		ReportID *ReportID `json:"-" db:"report_id"`
+
+		// The time the status was scheduled
+		CreateAt DateTime `json:"-" db:"create_at"`
+	}
+
+	// Parameters of a scheduled status
+	//
+	// JSON のまま scheduled_status.params に保存する
+	ScheduledStatusParams struct {
+		Text        string         `json:"text"`
+		Visibility  Visibility     `json:"visibility"`
+		SpoilerText string         `json:"spoiler_text"`
+		Sensitive   bool           `json:"sensitive"`
+		Poll        *ScheduledPoll `json:"poll"`
+	}
+
+	// Poll of a scheduled status
+	ScheduledPoll struct {
+		Options []string `json:"options"`
+
+		// Duration of the poll in seconds, counted from the time of publishing
+		ExpiresIn  int64 `json:"expires_in"`
+		Multiple   bool  `json:"multiple"`
+		HideTotals bool  `json:"hide_totals"`
+	}
+)
+
+// Build the status to publish at now
+func (s *ScheduledStatus) Status(now time.Time) *Status {
+	status := &Status{
+		Account:     &Account{ID: s.AccountID},
+		Content:     s.Params.Text,
+		Visibility:  s.Params.Visibility,
+		SpoilerText: s.Params.SpoilerText,
+		Sensitive:   s.Params.Sensitive,
+	}
+	if p := s.Params.Poll; p != nil {
+		status.Poll = &Poll{
+			ExpiresAt:  DateTime{Time: now.Add(time.Duration(p.ExpiresIn) * time.Second)},
+			Multiple:   p.Multiple,
+			HideTotals: p.HideTotals,
+		}
+		for _, title := range p.Options {
+			status.Poll.Options = append(status.Poll.Options, &PollOption{Title: title})
+		}
+	}
+	return status
+}
+
+// database/sql/driver/Valuer
+func (p ScheduledStatusParams) Value() (driver.Value, error) {
+	b, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// database/sql/Scanner
+func (p *ScheduledStatusParams) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, p)
+	case string:
+		return json.Unmarshal([]byte(v), p)
+	default:
+		return fmt.Errorf("cannot scan %T into ScheduledStatusParams", value)
+	}
+}
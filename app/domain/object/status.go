@@ -1,7 +1,8 @@
 package object
 
 type (
-	StatusID = int64
+	StatusID   = int64
+	Visibility = string
 
 	// Account status
 	Status struct {
@@ -14,9 +15,50 @@ type (
 		// The content of the status
 		Content string `json:"content" db:"content"`
 
+		// Who can see the status
+		Visibility Visibility `json:"visibility" db:"visibility"`
+
+		// Subject or content warning shown in place of the content
+		SpoilerText string `json:"spoiler_text" db:"spoiler_text"`
+
+		// Whether the content or media should be hidden by default
+		Sensitive bool `json:"sensitive" db:"sensitive"`
+
+		// The poll attached to the status
+		Poll *Poll `json:"poll" db:"-"`
+
+		// Whether the viewer bookmarked the status. nil if not authenticated
+		Bookmarked *bool `json:"bookmarked,omitempty" db:"-"`
+
+		// Whether the status is pinned to the profile. Only set for the author
+		Pinned *bool `json:"pinned,omitempty" db:"-"`
+
+		// Filters of the viewer matching the status
+		Filtered []*FilterResult `json:"filtered,omitempty" db:"-"`
+
 		// The time the status was created
 		CreateAt DateTime `json:"create_at,omitempty" db:"create_at"`
 	}
 
 	Timelines []Status
 )
+
+const (
+	// Visible to everyone and shown in public timelines
+	VisibilityPublic Visibility = "public"
+	// Visible to everyone but not shown in public timelines
+	VisibilityUnlisted Visibility = "unlisted"
+	// Visible to followers only
+	VisibilityPrivate Visibility = "private"
+	// Visible to the author only
+	VisibilityDirect Visibility = "direct"
+)
+
+// Check if given string is a known visibility
+func IsValidVisibility(v string) bool {
+	switch v {
+	case VisibilityPublic, VisibilityUnlisted, VisibilityPrivate, VisibilityDirect:
+		return true
+	}
+	return false
+}
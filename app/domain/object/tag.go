@@ -0,0 +1,42 @@
+package object
+
+import (
+	"regexp"
+	"strings"
+)
+
+type (
+	TagID = int64
+
+	// Hashtag used in statuses
+	Tag struct {
+		// The internal ID of the tag
+		ID TagID `json:"-" db:"id"`
+
+		// The name of the tag without `#`, in lower case
+		Name string `json:"name" db:"name"`
+	}
+)
+
+// 日本語のハッシュタグも拾えるように、文字・数字・アンダースコアを名前として扱う
+var (
+	hashtagPattern = regexp.MustCompile(`(?:^|[^\p{L}\p{N}_&/])[#＃]([\p{L}\p{N}_]+)`)
+	digitsOnly     = regexp.MustCompile(`^[\p{N}_]+$`)
+)
+
+// Extract hashtags from content of status
+//
+// 大文字小文字は区別せず、重複は取り除く。数字だけのもの (#1 など) はタグにしない
+func ExtractHashtags(content string) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, m := range hashtagPattern.FindAllStringSubmatch(content, -1) {
+		name := strings.ToLower(m[1])
+		if seen[name] || digitsOnly.MatchString(name) {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	return names
+}
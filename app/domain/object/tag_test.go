@@ -0,0 +1,23 @@
+package object
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractHashtags(t *testing.T) {
+	tests := []struct {
+		content string
+		want    []string
+	}{
+		{"no tags", nil},
+		{"#Go と #golang", []string{"go", "golang"}},
+		{"#ピタゴラスイッチ♪ ＃ピタゴラスイッチ", []string{"ピタゴラスイッチ"}},
+		{"#GO #go", []string{"go"}},
+		{"issue#1 https://example.com/#anchor #2022", nil},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, ExtractHashtags(tt.content), tt.content)
+	}
+}
@@ -0,0 +1,55 @@
+package object
+
+type (
+	// Hashtag usage aggregated over the trend window
+	TagUsage struct {
+		TagID TagID  `db:"tag_id"`
+		Name  string `db:"name"`
+
+		// Number of uses in the last hour
+		UsesHour int `db:"uses_hour"`
+
+		// Number of uses in the last day
+		UsesDay int `db:"uses_day"`
+
+		// Number of distinct accounts in the last day
+		Accounts int `db:"accounts"`
+
+		// Distinct accounts weighted by how recently each used the tag
+		Decayed float64 `db:"decayed"`
+	}
+
+	// Trending hashtag
+	TagTrend struct {
+		TagID TagID `json:"-" db:"tag_id"`
+
+		// The name of the tag without `#`
+		Name string `json:"name" db:"name"`
+
+		// Number of uses in the last hour
+		UsesHour int `json:"uses_hour" db:"uses_hour"`
+
+		// Number of uses in the last day
+		UsesDay int `json:"uses_day" db:"uses_day"`
+
+		// Number of distinct accounts in the last day
+		Accounts int `json:"accounts" db:"accounts"`
+
+		// Ranking score
+		Score float64 `json:"-" db:"score"`
+
+		// Review result. nil until reviewed
+		Trendable *bool `json:"-" db:"trendable"`
+	}
+
+	// Trending status
+	StatusTrend struct {
+		Status *Status `json:"status"`
+
+		// Ranking score
+		Score float64 `json:"score"`
+
+		// Review result. nil until reviewed
+		Trendable *bool `json:"trendable"`
+	}
+)
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"yatter-backend-go/app/domain/object"
 )
@@ -9,6 +10,62 @@ import (
 type Account interface {
 	// Fetch account which has specified username
 	FindByUsername(ctx context.Context, username string) (*object.Account, error)
+	// Fetch account which has specified ID
+	FindByID(ctx context.Context, id object.AccountID) (*object.Account, error)
 	// Create account
 	Add(ctx context.Context, account *object.Account) (object.AccountID, error)
+	// Update profile of account
+	Update(ctx context.Context, account *object.Account) error
+	// Update reading preferences
+	UpdatePreferences(ctx context.Context, id object.AccountID, prefs *object.Preferences) error
+	// Fetch accounts whose username or display name starts with prefix
+	FindByPrefix(ctx context.Context, prefix string, opts AccountPrefixOptions) ([]*object.Account, error)
+	// Schedule deletion of account at specified time
+	ScheduleDeletion(ctx context.Context, id object.AccountID, at time.Time) error
+	// Cancel scheduled deletion of account
+	CancelDeletion(ctx context.Context, id object.AccountID) error
+	// Delete accounts whose scheduled time has passed together with their data, returning the number of deleted accounts
+	DeleteScheduled(ctx context.Context) (int64, error)
+	// Fetch accounts for moderators, newest first
+	Find(ctx context.Context, opts AccountOptions) ([]*object.Account, error)
+	// Change role of account. Recorded in the moderation log as actorID
+	SetRole(ctx context.Context, id object.AccountID, role object.Role, actorID object.AccountID) error
+	// Apply moderation action to account. Recorded in the moderation log and as a warning to the account
+	TakeAction(ctx context.Context, action *object.AccountAction) error
+	// Fetch warnings to account, newest first
+	FindWarnings(ctx context.Context, id object.AccountID) ([]*object.AccountWarning, error)
+	// Record that account is used from ip
+	AddIP(ctx context.Context, id object.AccountID, ip string) error
+	// Fetch IP addresses used by account, most recently used first
+	FindIPs(ctx context.Context, id object.AccountID) ([]*object.AccountIP, error)
+}
+
+// Conditions of account queries for moderators
+type AccountOptions struct {
+	// Accounts whose username starts with it
+	Username string
+
+	// Accounts which have the role
+	Role object.Role
+
+	// Accounts in the status. One of object.AccountStatus*
+	Status string
+
+	// Accounts which have used the IP address
+	IP string
+
+	MaxID   object.AccountID
+	SinceID object.AccountID
+	Limit   int64
+}
+
+// Conditions of account prefix queries
+type AccountPrefixOptions struct {
+	// Account searching. 0 if not authenticated
+	ViewerID object.AccountID
+
+	// Only accounts followed by the viewer
+	Following bool
+
+	Limit int64
 }
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+
+	"yatter-backend-go/app/domain/object"
+)
+
+type Block interface {
+	// Block target account
+	Add(ctx context.Context, accountID, targetID object.AccountID) error
+	// Unblock target account
+	Delete(ctx context.Context, accountID, targetID object.AccountID) error
+	// Check whether account is blocking target account
+	Exists(ctx context.Context, accountID, targetID object.AccountID) (bool, error)
+	// Fetch accounts blocked by account
+	FindBlockedAccounts(ctx context.Context, accountID object.AccountID, limit int64) ([]*object.Account, error)
+	// Pass accounts blocked by account to fn one by one
+	EachBlocked(ctx context.Context, accountID object.AccountID, fn func(*object.Account) error) error
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+
+	"yatter-backend-go/app/domain/object"
+)
+
+type Bookmark interface {
+	// Bookmark status
+	Add(ctx context.Context, accountID object.AccountID, statusID object.StatusID) error
+	// Remove bookmark
+	Delete(ctx context.Context, accountID object.AccountID, statusID object.StatusID) error
+	// Fetch bookmarked statuses still visible to account, most recently bookmarked first
+	FindStatuses(ctx context.Context, accountID object.AccountID, limit int64) ([]*object.Status, error)
+	// Pass IDs of bookmarked statuses to fn one by one, oldest bookmark first
+	EachBookmarked(ctx context.Context, accountID object.AccountID, fn func(object.StatusID) error) error
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+
+	"yatter-backend-go/app/domain/object"
+)
+
+type ContentRule interface {
+	// Create rule. Recorded in the moderation log as actorID
+	Add(ctx context.Context, rule *object.ContentRule, actorID object.AccountID) (object.ContentRuleID, error)
+	// Fetch rule which has specified ID
+	FindByID(ctx context.Context, id object.ContentRuleID) (*object.ContentRule, error)
+	// Fetch all rules in created order
+	FindAll(ctx context.Context) ([]*object.ContentRule, error)
+	// Update rule. Recorded in the moderation log as actorID
+	Update(ctx context.Context, rule *object.ContentRule, actorID object.AccountID) error
+	// Delete rule. Recorded in the moderation log as actorID
+	Delete(ctx context.Context, id object.ContentRuleID, actorID object.AccountID) error
+}
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+
+	"yatter-backend-go/app/domain/object"
+)
+
+type Conversation interface {
+	// Fetch conversations of account, most recently updated first
+	FindByAccount(ctx context.Context, accountID object.AccountID, opts ConversationOptions) ([]*object.Conversation, error)
+	// Fetch conversation of account. nil if the account has none or hid it
+	FindByID(ctx context.Context, id object.ConversationID, accountID object.AccountID) (*object.Conversation, error)
+	// Mark conversation as read by account
+	MarkAsRead(ctx context.Context, id object.ConversationID, accountID object.AccountID) error
+	// Hide conversation from account until a new status arrives. Fails with customerror.ErrNotFound if the account has none
+	Delete(ctx context.Context, id object.ConversationID, accountID object.AccountID) error
+}
+
+// Conditions of conversation queries, compared with the ID of the last status
+type ConversationOptions struct {
+	MaxID   object.StatusID
+	SinceID object.StatusID
+	Limit   int64
+}
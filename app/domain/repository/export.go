@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"yatter-backend-go/app/domain/object"
+)
+
+type Export interface {
+	// Request a new export
+	Add(ctx context.Context, accountID object.AccountID) (object.ExportID, error)
+	// Fetch exports requested by account, newest first
+	FindByAccount(ctx context.Context, accountID object.AccountID, limit int64) ([]*object.Export, error)
+	// Fetch the export which has specified download token
+	FindByToken(ctx context.Context, token string) (*object.Export, error)
+	// Take the oldest pending export, or one running longer than timeout, and mark it running.
+	// Return nil if there is none
+	ClaimPending(ctx context.Context, timeout time.Duration) (*object.Export, error)
+	// Mark export done with its archive
+	Complete(ctx context.Context, id object.ExportID, path, token string, expiresAt time.Time) error
+	// Mark export failed
+	Fail(ctx context.Context, id object.ExportID) error
+	// Delete exports whose download link has expired
+	DeleteExpired(ctx context.Context) (int64, error)
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+
+	"yatter-backend-go/app/domain/object"
+)
+
+type Filter interface {
+	// Create filter with its keywords
+	Add(ctx context.Context, filter *object.Filter) (object.FilterID, error)
+	// Fetch filter with its keywords
+	FindByID(ctx context.Context, id object.FilterID) (*object.Filter, error)
+	// Fetch filters of account with their keywords, oldest first
+	FindByAccount(ctx context.Context, accountID object.AccountID) ([]*object.Filter, error)
+	// Fetch unexpired filters of account applying to the context
+	FindActive(ctx context.Context, accountID object.AccountID, filterContext object.FilterContext) ([]*object.Filter, error)
+	// Update filter. Keywords are replaced if filter.Keywords is not nil
+	Update(ctx context.Context, filter *object.Filter) error
+	// Delete filter with its keywords. Fails with customerror.ErrNotFound if it does not exist
+	Delete(ctx context.Context, id object.FilterID) error
+}
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+
+	"yatter-backend-go/app/domain/object"
+)
+
+type Follow interface {
+	// Follow target account
+	Add(ctx context.Context, accountID, targetID object.AccountID) error
+	// Unfollow target account
+	Delete(ctx context.Context, accountID, targetID object.AccountID) error
+	// Check whether account is following target account
+	Exists(ctx context.Context, accountID, targetID object.AccountID) (bool, error)
+	// Fetch accounts followed by account
+	FindFollowing(ctx context.Context, accountID object.AccountID, limit int64) ([]*object.Account, error)
+	// Fetch accounts following account
+	FindFollowers(ctx context.Context, accountID object.AccountID, limit int64) ([]*object.Account, error)
+	// Pass accounts followed by account to fn one by one
+	EachFollowing(ctx context.Context, accountID object.AccountID, fn func(*object.Account) error) error
+}
+
+type FollowRequest interface {
+	// Request to follow target account
+	Add(ctx context.Context, accountID, targetID object.AccountID) error
+	// Withdraw or reject follow request
+	Delete(ctx context.Context, accountID, targetID object.AccountID) error
+	// Check whether account has requested to follow target account
+	Exists(ctx context.Context, accountID, targetID object.AccountID) (bool, error)
+	// Fetch accounts requesting to follow target account
+	FindRequesters(ctx context.Context, targetID object.AccountID, limit int64) ([]*object.Account, error)
+	// Accept follow request and create follow
+	Authorize(ctx context.Context, accountID, targetID object.AccountID) error
+	// Accept every pending request to target account
+	AuthorizeAll(ctx context.Context, targetID object.AccountID) error
+}
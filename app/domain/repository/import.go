@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+
+	"yatter-backend-go/app/domain/object"
+)
+
+type Import interface {
+	// Request a new import
+	Add(ctx context.Context, imp *object.Import) (object.ImportID, error)
+	// Fetch import of account which has specified ID, without its data
+	Find(ctx context.Context, accountID object.AccountID, id object.ImportID) (*object.Import, error)
+	// Fetch imports requested by account without their data, newest first
+	FindByAccount(ctx context.Context, accountID object.AccountID, limit int64) ([]*object.Import, error)
+	// Take the oldest pending import with its data and mark it running, or return nil if there is none
+	ClaimPending(ctx context.Context) (*object.Import, error)
+	// Save progress of import
+	UpdateProgress(ctx context.Context, imp *object.Import) error
+	// Record rows which could not be imported
+	AddErrors(ctx context.Context, errs []*object.ImportError) error
+	// Fetch rows which could not be imported
+	FindErrors(ctx context.Context, id object.ImportID, limit int64) ([]*object.ImportError, error)
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+
+	"yatter-backend-go/app/domain/object"
+)
+
+type List interface {
+	// Create list
+	Add(ctx context.Context, list *object.List) (object.ListID, error)
+	// Fetch list
+	FindByID(ctx context.Context, id object.ListID) (*object.List, error)
+	// Fetch lists owned by account, oldest first
+	FindByAccount(ctx context.Context, accountID object.AccountID) ([]*object.List, error)
+	// Rename list
+	UpdateTitle(ctx context.Context, id object.ListID, title string) error
+	// Delete list and its members. Fails with customerror.ErrNotFound if it does not exist
+	Delete(ctx context.Context, id object.ListID) error
+	// Fetch members of list
+	FindAccounts(ctx context.Context, id object.ListID, limit int64) ([]*object.Account, error)
+	// Add accounts to list. Accounts not followed by the list owner are ignored
+	AddAccounts(ctx context.Context, list *object.List, accountIDs []object.AccountID) error
+	// Remove accounts from list
+	RemoveAccounts(ctx context.Context, id object.ListID, accountIDs []object.AccountID) error
+}
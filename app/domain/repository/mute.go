@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+
+	"yatter-backend-go/app/domain/object"
+)
+
+type Mute interface {
+	// Mute target account, or update the existing mute
+	Add(ctx context.Context, mute *object.Mute) error
+	// Unmute target account
+	Delete(ctx context.Context, accountID, targetID object.AccountID) error
+	// Fetch active mute of target account
+	Find(ctx context.Context, accountID, targetID object.AccountID) (*object.Mute, error)
+	// Fetch accounts muted by account
+	FindMutedAccounts(ctx context.Context, accountID object.AccountID, limit int64) ([]*object.Account, error)
+	// Delete expired mutes
+	DeleteExpired(ctx context.Context) (int64, error)
+	// Pass active mutes of account to fn one by one with the muted account
+	EachMuted(ctx context.Context, accountID object.AccountID, fn func(*object.Account, *object.Mute) error) error
+}
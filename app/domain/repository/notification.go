@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+
+	"yatter-backend-go/app/domain/object"
+)
+
+type Notification interface {
+	// Fetch notifications to account, newest first
+	FindByAccount(ctx context.Context, accountID object.AccountID, opts NotificationOptions) ([]*object.Notification, error)
+}
+
+// Conditions of notification queries
+type NotificationOptions struct {
+	MaxID   object.NotificationID
+	SinceID object.NotificationID
+	Limit   int64
+}
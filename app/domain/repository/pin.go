@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"yatter-backend-go/app/domain/object"
+)
+
+type Pin interface {
+	// Pin status to the profile. Fails with customerror.ErrTooManyPins if max statuses are already pinned
+	Add(ctx context.Context, accountID object.AccountID, statusID object.StatusID, max int) error
+	// Unpin status
+	Delete(ctx context.Context, accountID object.AccountID, statusID object.StatusID) error
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+
+	"yatter-backend-go/app/domain/object"
+)
+
+type Poll interface {
+	// Fetch poll with options and votes of the viewer
+	FindByID(ctx context.Context, id object.PollID, viewerID object.AccountID) (*object.Poll, error)
+	// Set polls to statuses which have them
+	Attach(ctx context.Context, statuses []*object.Status, viewerID object.AccountID) error
+	// Vote on poll. choices are indices of options
+	Vote(ctx context.Context, id object.PollID, accountID object.AccountID, choices []int) error
+	// Close expired polls and notify voters and authors
+	CloseExpired(ctx context.Context) (int64, error)
+}
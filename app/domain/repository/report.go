@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+
+	"yatter-backend-go/app/domain/object"
+)
+
+type Report interface {
+	// Create report with the reported statuses
+	Add(ctx context.Context, report *object.Report) (object.ReportID, error)
+	// Fetch report with the reported statuses and account
+	FindByID(ctx context.Context, id object.ReportID) (*object.Report, error)
+	// Fetch reports with the reported statuses and accounts, newest first
+	Find(ctx context.Context, opts ReportOptions) ([]*object.Report, error)
+	// Assign report to moderator, or unassign it if assigneeID is nil. Recorded in the moderation log as actorID
+	Assign(ctx context.Context, id object.ReportID, assigneeID *object.AccountID, actorID object.AccountID) error
+	// Mark report as resolved. Recorded in the moderation log as actorID
+	Resolve(ctx context.Context, id object.ReportID, actorID object.AccountID) error
+	// Mark report as open again. Recorded in the moderation log as actorID
+	Reopen(ctx context.Context, id object.ReportID, actorID object.AccountID) error
+}
+
+// Conditions of report queries
+type ReportOptions struct {
+	// Resolved reports if true, open reports otherwise
+	Resolved bool
+
+	MaxID   object.ReportID
+	SinceID object.ReportID
+	Limit   int64
+}
+
+type ModerationLog interface {
+	// Fetch moderation logs with the moderators, newest first
+	Find(ctx context.Context, opts ModerationLogOptions) ([]*object.ModerationLog, error)
+}
+
+// Conditions of moderation log queries
+type ModerationLogOptions struct {
+	MaxID   object.ModerationLogID
+	SinceID object.ModerationLogID
+	Limit   int64
+}
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"yatter-backend-go/app/domain/object"
+)
+
+type ScheduledStatus interface {
+	// Schedule status
+	Add(ctx context.Context, s *object.ScheduledStatus) (object.ScheduledStatusID, error)
+	// Fetch scheduled status
+	FindByID(ctx context.Context, id object.ScheduledStatusID) (*object.ScheduledStatus, error)
+	// Fetch scheduled statuses of account, newest first
+	FindByAccount(ctx context.Context, accountID object.AccountID, opts ScheduledStatusOptions) ([]*object.ScheduledStatus, error)
+	// Count scheduled statuses of account
+	CountByAccount(ctx context.Context, accountID object.AccountID) (int, error)
+	// Change the time to publish
+	UpdateScheduledAt(ctx context.Context, id object.ScheduledStatusID, at time.Time) error
+	// Cancel scheduled status
+	Delete(ctx context.Context, id object.ScheduledStatusID) error
+	// Publish due statuses. Each one is published exactly once even if called concurrently
+	PublishDue(ctx context.Context) (int64, error)
+}
+
+// Conditions of scheduled status queries
+type ScheduledStatusOptions struct {
+	MaxID   object.ScheduledStatusID
+	SinceID object.ScheduledStatusID
+	Limit   int64
+}
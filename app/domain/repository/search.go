@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+
+	"yatter-backend-go/app/domain/object"
+)
+
+// Search of accounts, statuses and hashtags
+//
+// MySQL の FULLTEXT で実装しているが、外部の検索エンジンに差し替えられるように分けている
+type Search interface {
+	// Search accounts by username and display name
+	Accounts(ctx context.Context, q string, opts SearchOptions) ([]*object.Account, error)
+	// Search statuses visible to the viewer by content, newest first
+	Statuses(ctx context.Context, q string, opts SearchOptions) ([]*object.Status, error)
+	// Search hashtags by prefix of the name
+	Hashtags(ctx context.Context, q string, opts SearchOptions) ([]*object.Tag, error)
+}
+
+// Conditions of search queries
+type SearchOptions struct {
+	// Account searching. 0 if not authenticated
+	ViewerID object.AccountID
+
+	Offset int64
+	Limit  int64
+}
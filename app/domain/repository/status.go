@@ -9,10 +9,52 @@ import (
 type Status interface {
 	// Find Status
 	FindWithAccountByID(ctx context.Context, id object.StatusID) (*object.Status, error)
+	// Find Status even if its account is suspended, for moderators
+	FindForModeration(ctx context.Context, id object.StatusID) (*object.Status, error)
 	// Create Status
 	Add(ctx context.Context, status *object.Status) (object.StatusID, error)
 	// Delete Status
 	DeleteByID(ctx context.Context, id object.StatusID) error
 	// Find PublicTimeline
-	FindPublicTimelines(ctx context.Context, onlyMedia bool, maxID int64, sinceID int64, limit int64) (object.Timelines, error)
+	FindPublicTimelines(ctx context.Context, opts TimelineOptions) (object.Timelines, error)
+	// Fetch statuses of account visible to the viewer, newest first
+	FindByAccount(ctx context.Context, accountID object.AccountID, opts AccountStatusOptions) ([]*object.Status, error)
+	// Fetch status if the viewer can see it
+	FindVisibleByID(ctx context.Context, id object.StatusID, viewerID object.AccountID) (*object.Status, error)
+	// Set bookmarked and pinned flags of statuses for the viewer
+	SetViewerFlags(ctx context.Context, statuses []*object.Status, viewerID object.AccountID) error
+	// Check whether account is mentioned in status
+	IsMentioned(ctx context.Context, id object.StatusID, accountID object.AccountID) (bool, error)
+	// Pass statuses of account to fn one by one, oldest first
+	EachByAccount(ctx context.Context, accountID object.AccountID, fn func(*object.Status) error) error
+}
+
+// Conditions of timeline queries
+type TimelineOptions struct {
+	// Account viewing the timeline. 0 if not authenticated
+	ViewerID object.AccountID
+
+	// Only statuses with the hashtag if not empty
+	Tag string
+
+	// Statuses of the list members visible to the viewer instead of public statuses if not 0
+	ListID object.ListID
+
+	OnlyMedia bool
+	MaxID     object.StatusID
+	SinceID   object.StatusID
+	Limit     int64
+}
+
+// Conditions of account status queries
+type AccountStatusOptions struct {
+	// Account viewing the statuses. 0 if not authenticated
+	ViewerID object.AccountID
+
+	// Only pinned statuses, most recently pinned first
+	Pinned bool
+
+	MaxID   object.StatusID
+	SinceID object.StatusID
+	Limit   int64
 }
@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"yatter-backend-go/app/domain/object"
+)
+
+type Trend interface {
+	// Aggregate usage of hashtags in public statuses since specified time
+	TagUsages(ctx context.Context, since time.Time, halfLife time.Duration) ([]*object.TagUsage, error)
+	// Count distinct accounts using each hashtag between from and to
+	TagBaselines(ctx context.Context, from, to time.Time) (map[object.TagID]int, error)
+	// Replace ranking of hashtags
+	ReplaceTags(ctx context.Context, trends []*object.TagTrend) error
+	// Replace ranking of statuses, scored by approved trending hashtags they contain
+	ReplaceStatuses(ctx context.Context, since time.Time, halfLife time.Duration, limit int64) error
+
+	// Fetch approved trending hashtags
+	FindTags(ctx context.Context, limit, offset int64) ([]*object.TagTrend, error)
+	// Fetch approved trending statuses visible to the viewer
+	FindStatuses(ctx context.Context, opts TrendOptions) ([]*object.Status, error)
+
+	// Fetch ranked hashtags including the ones not reviewed yet
+	FindTagCandidates(ctx context.Context, limit int64) ([]*object.TagTrend, error)
+	// Fetch ranked statuses including the ones not reviewed yet
+	FindStatusCandidates(ctx context.Context, limit int64) ([]*object.StatusTrend, error)
+	// Approve or reject a hashtag for trends. Recorded in the moderation log as actorID
+	SetTagTrendable(ctx context.Context, name string, trendable bool, actorID object.AccountID) error
+	// Approve or reject a status for trends. Recorded in the moderation log as actorID
+	SetStatusTrendable(ctx context.Context, id object.StatusID, trendable bool, actorID object.AccountID) error
+}
+
+// Conditions of trend queries
+type TrendOptions struct {
+	// Account viewing the trends. 0 if not authenticated
+	ViewerID object.AccountID
+
+	Offset int64
+	Limit  int64
+}
@@ -0,0 +1,96 @@
+package export
+
+import (
+	"context"
+	"io"
+	"strconv"
+
+	"yatter-backend-go/app/domain/object"
+)
+
+const (
+	activityStreamsContext = "https://www.w3.org/ns/activitystreams"
+	publicCollection       = "https://www.w3.org/ns/activitystreams#Public"
+)
+
+// ActivityStreams の Note
+type note struct {
+	ID           string          `json:"id"`
+	Type         string          `json:"type"`
+	AttributedTo string          `json:"attributedTo"`
+	Summary      string          `json:"summary,omitempty"`
+	Content      string          `json:"content"`
+	Sensitive    bool            `json:"sensitive"`
+	Published    object.DateTime `json:"published"`
+	To           []string        `json:"to"`
+	Cc           []string        `json:"cc"`
+}
+
+// ActivityStreams の Create
+type create struct {
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	Actor     string          `json:"actor"`
+	Published object.DateTime `json:"published"`
+	To        []string        `json:"to"`
+	Cc        []string        `json:"cc"`
+	Object    *note           `json:"object"`
+}
+
+// 公開範囲を宛先に変換する。direct の宛先 (メンション) はまだ扱えないので空にする
+func audience(v object.Visibility, followers string) (to, cc []string) {
+	switch v {
+	case object.VisibilityPublic:
+		return []string{publicCollection}, []string{followers}
+	case object.VisibilityUnlisted:
+		return []string{followers}, []string{publicCollection}
+	case object.VisibilityPrivate:
+		return []string{followers}, []string{}
+	default:
+		return []string{}, []string{}
+	}
+}
+
+// ステータスを ActivityStreams の OrderedCollection (outbox) として書き出す
+func (b *Builder) writeOutbox(ctx context.Context, w io.Writer, account *object.Account) error {
+	actor := b.publicURL + "/v1/accounts/" + account.Username
+	followers := actor + "/followers"
+
+	if _, err := io.WriteString(w, `{"@context":"`+activityStreamsContext+`","type":"OrderedCollection","orderedItems":`); err != nil {
+		return err
+	}
+
+	arr := newJSONArray(w)
+	err := b.dao.Status().EachByAccount(ctx, account.ID, func(s *object.Status) error {
+		id := b.publicURL + "/v1/statuses/" + strconv.FormatInt(s.ID, 10)
+		to, cc := audience(s.Visibility, followers)
+		return arr.Add(&create{
+			ID:        id + "/activity",
+			Type:      "Create",
+			Actor:     actor,
+			Published: s.CreateAt,
+			To:        to,
+			Cc:        cc,
+			Object: &note{
+				ID:           id,
+				Type:         "Note",
+				AttributedTo: actor,
+				Summary:      s.SpoilerText,
+				Content:      s.Content,
+				Sensitive:    s.Sensitive,
+				Published:    s.CreateAt,
+				To:           to,
+				Cc:           cc,
+			},
+		})
+	})
+	if err != nil {
+		return err
+	}
+	if err := arr.Close(); err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, `,"totalItems":`+strconv.Itoa(arr.n)+"}\n")
+	return err
+}
@@ -0,0 +1,144 @@
+package export
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+
+	"yatter-backend-go/app/domain/object"
+)
+
+// Names of files in the archive
+//
+// CSV は Mastodon のエクスポートと同じ形式で、インポートにそのまま使える
+const (
+	AccountFile   = "account.json"
+	StatusesFile  = "statuses.json"
+	OutboxFile    = "outbox.json"
+	FollowingFile = "following_accounts.csv"
+	BlocksFile    = "blocked_accounts.csv"
+	MutesFile     = "muted_accounts.csv"
+)
+
+// Write ZIP archive of account's data to w
+//
+// DAO から 1 行ずつ受け取って書き込むので、データ量が多くてもメモリに載せきらない
+func (b *Builder) Write(ctx context.Context, w io.Writer, account *object.Account) error {
+	zw := zip.NewWriter(w)
+
+	steps := []struct {
+		name  string
+		write func(io.Writer) error
+	}{
+		{AccountFile, func(w io.Writer) error {
+			return json.NewEncoder(w).Encode(account)
+		}},
+		{StatusesFile, func(w io.Writer) error {
+			return b.writeStatuses(ctx, w, account)
+		}},
+		{OutboxFile, func(w io.Writer) error {
+			return b.writeOutbox(ctx, w, account)
+		}},
+		{FollowingFile, func(w io.Writer) error {
+			return writeAccountsCSV(w, func(fn func(*object.Account) error) error {
+				return b.dao.Follow().EachFollowing(ctx, account.ID, fn)
+			})
+		}},
+		{BlocksFile, func(w io.Writer) error {
+			return writeAccountsCSV(w, func(fn func(*object.Account) error) error {
+				return b.dao.Block().EachBlocked(ctx, account.ID, fn)
+			})
+		}},
+		{MutesFile, func(w io.Writer) error {
+			return b.writeMutes(ctx, w, account)
+		}},
+	}
+	for _, s := range steps {
+		fw, err := zw.Create(s.name)
+		if err != nil {
+			return err
+		}
+		if err := s.write(fw); err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
+
+func (b *Builder) writeStatuses(ctx context.Context, w io.Writer, account *object.Account) error {
+	arr := newJSONArray(w)
+	err := b.dao.Status().EachByAccount(ctx, account.ID, func(s *object.Status) error {
+		s.Account = account
+		return arr.Add(s)
+	})
+	if err != nil {
+		return err
+	}
+	return arr.Close()
+}
+
+func writeAccountsCSV(w io.Writer, each func(fn func(*object.Account) error) error) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"Account address"}); err != nil {
+		return err
+	}
+	err := each(func(a *object.Account) error {
+		return cw.Write([]string{a.Username})
+	})
+	if err != nil {
+		return err
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func (b *Builder) writeMutes(ctx context.Context, w io.Writer, account *object.Account) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"Account address", "Hide notifications"}); err != nil {
+		return err
+	}
+	err := b.dao.Mute().EachMuted(ctx, account.ID, func(a *object.Account, m *object.Mute) error {
+		return cw.Write([]string{a.Username, strconv.FormatBool(m.HideNotifications)})
+	})
+	if err != nil {
+		return err
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// JSON の配列を要素ごとに書き出す
+type jsonArray struct {
+	w   io.Writer
+	enc *json.Encoder
+	n   int
+}
+
+func newJSONArray(w io.Writer) *jsonArray {
+	return &jsonArray{w: w, enc: json.NewEncoder(w)}
+}
+
+func (a *jsonArray) Add(v interface{}) error {
+	sep := ","
+	if a.n == 0 {
+		sep = "["
+	}
+	if _, err := io.WriteString(a.w, sep); err != nil {
+		return err
+	}
+	a.n++
+	return a.enc.Encode(v)
+}
+
+func (a *jsonArray) Close() error {
+	end := "]\n"
+	if a.n == 0 {
+		end = "[]\n"
+	}
+	_, err := io.WriteString(a.w, end)
+	return err
+}
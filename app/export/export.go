@@ -0,0 +1,140 @@
+package export
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"yatter-backend-go/app/config"
+	"yatter-backend-go/app/dao"
+	"yatter-backend-go/app/domain/object"
+
+	"go.uber.org/zap"
+)
+
+// Builder of personal data archives
+//
+// リクエストを受け付けた時点では export テーブルに pending として登録するだけで、
+// アーカイブはワーカーが BuildPending で作成する
+type Builder struct {
+	dao       dao.Dao
+	cfg       config.Export
+	publicURL string
+	logger    *zap.Logger
+}
+
+// Create builder
+func New(dao dao.Dao, cfg config.Export, publicURL string, logger *zap.Logger) *Builder {
+	return &Builder{dao: dao, cfg: cfg, publicURL: publicURL, logger: logger}
+}
+
+// Build every pending export
+func (b *Builder) BuildPending(ctx context.Context) error {
+	for ctx.Err() == nil {
+		exp, err := b.dao.Export().ClaimPending(ctx, b.cfg.ClaimTimeout)
+		if err != nil {
+			return err
+		}
+		if exp == nil {
+			return nil
+		}
+
+		l := b.logger.With(zap.Int64("export_id", exp.ID), zap.Int64("account_id", exp.AccountID))
+		if err := b.build(ctx, exp); err != nil {
+			l.Error("failed to build export", zap.Error(err))
+			// 停止時に中断した場合も失敗扱いにする。再度リクエストしてもらう
+			if err := b.dao.Export().Fail(context.Background(), exp.ID); err != nil {
+				return err
+			}
+			continue
+		}
+		l.Info("built export")
+	}
+	return nil
+}
+
+func (b *Builder) build(ctx context.Context, exp *object.Export) error {
+	account, err := b.dao.Account().FindByID(ctx, exp.AccountID)
+	if err != nil {
+		return err
+	}
+	if account == nil {
+		return fmt.Errorf("account %d not found", exp.AccountID)
+	}
+
+	token, err := newToken()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(b.cfg.Dir, 0o700); err != nil {
+		return err
+	}
+	// 書き込み途中のファイルがダウンロードされないように、一時ファイルに書いてから名前を変える
+	tmp, err := ioutil.TempFile(b.cfg.Dir, "*.zip.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := b.Write(ctx, tmp, account); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	path := filepath.Join(b.cfg.Dir, token+".zip")
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return err
+	}
+
+	expiresAt := time.Now().Add(b.cfg.LinkTTL)
+	if err := b.dao.Export().Complete(ctx, exp.ID, path, token, expiresAt); err != nil {
+		os.Remove(path)
+		return err
+	}
+	return nil
+}
+
+// Delete expired exports and their archives
+//
+// アカウント削除などで行がなくなったファイルも消せるように、ディレクトリ内の古いファイルをまとめて消す
+func (b *Builder) Cleanup(ctx context.Context) error {
+	if _, err := b.dao.Export().DeleteExpired(ctx); err != nil {
+		return err
+	}
+
+	files, err := ioutil.ReadDir(b.cfg.Dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	deadline := time.Now().Add(-b.cfg.LinkTTL)
+	for _, f := range files {
+		if f.IsDir() || f.ModTime().After(deadline) {
+			continue
+		}
+		if err := os.Remove(filepath.Join(b.cfg.Dir, f.Name())); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// ダウンロードリンクに使う推測できない文字列を生成する
+func newToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
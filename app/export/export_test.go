@@ -0,0 +1,169 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"yatter-backend-go/app/config"
+	"yatter-backend-go/app/dao"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/domain/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// 必要なメソッドだけを実装したスタブ。それ以外を呼ぶと nil の埋め込みで panic する
+type stubDao struct {
+	dao.Dao
+	statuses []*object.Status
+	accounts []*object.Account
+}
+
+func (d *stubDao) Status() repository.Status { return &stubStatus{statuses: d.statuses} }
+func (d *stubDao) Follow() repository.Follow { return &stubFollow{accounts: d.accounts} }
+func (d *stubDao) Block() repository.Block   { return &stubBlock{} }
+func (d *stubDao) Mute() repository.Mute     { return &stubMute{accounts: d.accounts} }
+
+type stubStatus struct {
+	repository.Status
+	statuses []*object.Status
+}
+
+func (s *stubStatus) EachByAccount(_ context.Context, _ object.AccountID, fn func(*object.Status) error) error {
+	for _, st := range s.statuses {
+		copied := *st
+		if err := fn(&copied); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type stubFollow struct {
+	repository.Follow
+	accounts []*object.Account
+}
+
+func (s *stubFollow) EachFollowing(_ context.Context, _ object.AccountID, fn func(*object.Account) error) error {
+	for _, a := range s.accounts {
+		if err := fn(a); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type stubBlock struct {
+	repository.Block
+}
+
+func (s *stubBlock) EachBlocked(context.Context, object.AccountID, func(*object.Account) error) error {
+	return nil
+}
+
+type stubMute struct {
+	repository.Mute
+	accounts []*object.Account
+}
+
+func (s *stubMute) EachMuted(_ context.Context, _ object.AccountID, fn func(*object.Account, *object.Mute) error) error {
+	for _, a := range s.accounts {
+		if err := fn(a, &object.Mute{HideNotifications: true}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readZip(t *testing.T, b []byte) map[string]string {
+	t.Helper()
+	zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	require.NoError(t, err)
+
+	files := make(map[string]string)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		content, err := ioutil.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+		files[f.Name] = string(content)
+	}
+	return files
+}
+
+func TestBuilder_Write(t *testing.T) {
+	now := object.DateTime{Time: time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)}
+	d := &stubDao{
+		statuses: []*object.Status{
+			{ID: 1, Content: "hello", Visibility: object.VisibilityPublic, CreateAt: now},
+			{ID: 2, Content: "secret", Visibility: object.VisibilityPrivate, CreateAt: now},
+		},
+		accounts: []*object.Account{{ID: 2, Username: "bob"}},
+	}
+	b := New(d, config.Export{}, "https://example.com", zap.NewNop())
+
+	var buf bytes.Buffer
+	err := b.Write(context.Background(), &buf, &object.Account{ID: 1, Username: "alice"})
+	require.NoError(t, err)
+
+	files := readZip(t, buf.Bytes())
+	assert.Len(t, files, 6)
+
+	var account map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(files[AccountFile]), &account))
+	assert.Equal(t, "alice", account["username"])
+
+	var statuses []object.Status
+	require.NoError(t, json.Unmarshal([]byte(files[StatusesFile]), &statuses))
+	require.Len(t, statuses, 2)
+	assert.Equal(t, "hello", statuses[0].Content)
+	assert.Equal(t, "alice", statuses[0].Account.Username)
+
+	var outbox struct {
+		Type         string `json:"type"`
+		TotalItems   int    `json:"totalItems"`
+		OrderedItems []struct {
+			Type   string   `json:"type"`
+			Actor  string   `json:"actor"`
+			To     []string `json:"to"`
+			Object struct {
+				ID string `json:"id"`
+			} `json:"object"`
+		} `json:"orderedItems"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(files[OutboxFile]), &outbox))
+	assert.Equal(t, "OrderedCollection", outbox.Type)
+	assert.Equal(t, 2, outbox.TotalItems)
+	assert.Equal(t, "Create", outbox.OrderedItems[0].Type)
+	assert.Equal(t, "https://example.com/v1/accounts/alice", outbox.OrderedItems[0].Actor)
+	assert.Equal(t, []string{publicCollection}, outbox.OrderedItems[0].To)
+	assert.Equal(t, "https://example.com/v1/statuses/2", outbox.OrderedItems[1].Object.ID)
+	assert.Equal(t, []string{"https://example.com/v1/accounts/alice/followers"}, outbox.OrderedItems[1].To)
+
+	assert.Equal(t, "Account address\nbob\n", files[FollowingFile])
+	assert.Equal(t, "Account address\n", files[BlocksFile])
+	assert.Equal(t, "Account address,Hide notifications\nbob,true\n", files[MutesFile])
+}
+
+func TestBuilder_Write_Empty(t *testing.T) {
+	b := New(&stubDao{}, config.Export{}, "https://example.com", zap.NewNop())
+
+	var buf bytes.Buffer
+	err := b.Write(context.Background(), &buf, &object.Account{ID: 1, Username: "alice"})
+	require.NoError(t, err)
+
+	files := readZip(t, buf.Bytes())
+	assert.Equal(t, "[]\n", files[StatusesFile])
+
+	var outbox map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(files[OutboxFile]), &outbox))
+	assert.Equal(t, float64(0), outbox["totalItems"])
+}
@@ -0,0 +1,65 @@
+package accounts
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+)
+
+// Handle request for `POST /v1/accounts/{username}/block`
+func (h *handler) Block(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	target := h.targetOf(w, r)
+	if target == nil {
+		return
+	}
+	account := auth.AccountOf(r)
+
+	if err := h.app.Dao.Block().Add(ctx, account.ID, target.ID); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+
+	relationship, err := h.relationship(ctx, account, target)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(relationship); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+}
+
+// Handle request for `POST /v1/accounts/{username}/unblock`
+func (h *handler) Unblock(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	target := h.targetOf(w, r)
+	if target == nil {
+		return
+	}
+	account := auth.AccountOf(r)
+
+	if err := h.app.Dao.Block().Delete(ctx, account.ID, target.ID); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+
+	relationship, err := h.relationship(ctx, account, target)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(relationship); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+}
@@ -44,27 +44,28 @@ func (h *handler) Create(w http.ResponseWriter, r *http.Request) {
 		DisplayName: &req.DisplayName,
 	}
 	if err := account.SetPassword(req.Password); err != nil {
-		httperror.InternalServerError(w, err)
+		httperror.InternalServerError(w, r, err)
 		return
 	}
 
 	accountRepo := h.app.Dao.Account() // domain/repository の取得
 	_, err := accountRepo.Add(ctx, account)
 	if err != nil {
-		httperror.InternalServerError(w, err)
+		httperror.InternalServerError(w, r, err)
 		return
 	}
+	h.app.Metrics.AccountsRegistered.Inc()
 
 	addedAccount, err := accountRepo.FindByUsername(ctx, req.Username)
 	if err != nil {
-		httperror.InternalServerError(w, err)
+		httperror.InternalServerError(w, r, err)
 		return
 	}
 
 	// Userの情報を返す
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(addedAccount); err != nil {
-		httperror.InternalServerError(w, err)
+		httperror.InternalServerError(w, r, err)
 		return
 	}
 }
@@ -0,0 +1,77 @@
+package accounts
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+)
+
+// Request body for `DELETE /v1/accounts`
+type DeleteRequest struct {
+	Password string `json:"password"`
+}
+
+// Response body for `DELETE /v1/accounts`
+type DeleteResponse struct {
+	DeleteScheduledAt object.DateTime `json:"delete_scheduled_at"`
+}
+
+// Handle request for `DELETE /v1/accounts`
+//
+// すぐには削除せず、猶予期間が過ぎた後にワーカーが関連データごと削除する
+func (h *handler) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req DeleteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+	if req.Password == "" {
+		httperror.BadRequest(w, errors.New("password is required"))
+		return
+	}
+
+	account := auth.AccountOf(r)
+	if !account.CheckPassword(req.Password) {
+		httperror.Error(w, http.StatusForbidden)
+		return
+	}
+
+	at := time.Now().Add(h.app.Config.Accounts.DeletionGracePeriod)
+	if err := h.app.Dao.Account().ScheduleDeletion(ctx, account.ID, at); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(&DeleteResponse{
+		DeleteScheduledAt: object.DateTime{Time: at},
+	}); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+}
+
+// Handle request for `POST /v1/accounts/cancel_deletion`
+func (h *handler) CancelDeletion(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	account := auth.AccountOf(r)
+	if err := h.app.Dao.Account().CancelDeletion(ctx, account.ID); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+	account.DeleteScheduledAt = nil
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(account); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+}
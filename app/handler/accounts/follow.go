@@ -0,0 +1,91 @@
+package accounts
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+)
+
+// Handle request for `POST /v1/accounts/{username}/follow`
+//
+// 鍵アカウントの場合はフォローリクエストを送り、承認されるまでフォローにはならない
+func (h *handler) Follow(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	target := h.targetOf(w, r)
+	if target == nil {
+		return
+	}
+	account := auth.AccountOf(r)
+
+	// どちらかがブロックしている場合はフォローできない
+	rel, err := h.relationship(ctx, account, target)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+	if rel.Blocking || rel.BlockedBy {
+		httperror.Error(w, http.StatusForbidden)
+		return
+	}
+
+	if !rel.Following {
+		if target.Locked {
+			err = h.app.Dao.FollowRequest().Add(ctx, account.ID, target.ID)
+		} else {
+			err = h.app.Dao.Follow().Add(ctx, account.ID, target.ID)
+		}
+		if err != nil {
+			httperror.InternalServerError(w, r, err)
+			return
+		}
+	}
+
+	relationship, err := h.relationship(ctx, account, target)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(relationship); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+}
+
+// Handle request for `POST /v1/accounts/{username}/unfollow`
+//
+// 承認待ちのフォローリクエストも取り消す
+func (h *handler) Unfollow(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	target := h.targetOf(w, r)
+	if target == nil {
+		return
+	}
+	account := auth.AccountOf(r)
+
+	if err := h.app.Dao.Follow().Delete(ctx, account.ID, target.ID); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+	if err := h.app.Dao.FollowRequest().Delete(ctx, account.ID, target.ID); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+
+	relationship, err := h.relationship(ctx, account, target)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(relationship); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+}
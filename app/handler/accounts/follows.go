@@ -0,0 +1,69 @@
+package accounts
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/handler/httperror"
+	"yatter-backend-go/app/handler/request"
+)
+
+const (
+	DefaultLimit = 40
+	MaxLimit     = 80
+)
+
+// Handle request for `GET /v1/accounts/{username}/following`
+func (h *handler) Following(w http.ResponseWriter, r *http.Request) {
+	h.listFollows(w, r, h.app.Dao.Follow().FindFollowing)
+}
+
+// Handle request for `GET /v1/accounts/{username}/followers`
+func (h *handler) Followers(w http.ResponseWriter, r *http.Request) {
+	h.listFollows(w, r, h.app.Dao.Follow().FindFollowers)
+}
+
+func (h *handler) listFollows(w http.ResponseWriter, r *http.Request, find func(ctx context.Context, id object.AccountID, limit int64) ([]*object.Account, error)) {
+	ctx := r.Context()
+
+	username, err := request.UsernameOf(r)
+	if err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+	limit, err := request.QueryInt64(r, "limit", DefaultLimit)
+	if err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+	if limit == 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+
+	account, err := h.app.Dao.Account().FindByUsername(ctx, username)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+	if account == nil {
+		httperror.NotFound(w)
+		return
+	}
+
+	accounts, err := find(ctx, account.ID, limit)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(accounts); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+}
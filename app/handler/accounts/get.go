@@ -22,7 +22,7 @@ func (h *handler) Get(w http.ResponseWriter, r *http.Request) {
 
 	account, err := accountRepo.FindByUsername(ctx, username)
 	if err != nil {
-		httperror.InternalServerError(w, err)
+		httperror.InternalServerError(w, r, err)
 		return
 	}
 	if account == nil {
@@ -32,7 +32,7 @@ func (h *handler) Get(w http.ResponseWriter, r *http.Request) {
 	// Userの情報を返す
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(account); err != nil {
-		httperror.InternalServerError(w, err)
+		httperror.InternalServerError(w, r, err)
 		return
 	}
 }
@@ -0,0 +1,104 @@
+package accounts
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+)
+
+// Request body for `POST /v1/accounts/{username}/mute`
+type MuteRequest struct {
+	// Whether notifications from the account are also hidden (default: true)
+	Notifications *bool `json:"notifications"`
+
+	// Seconds until the mute expires. 0 means indefinite
+	Duration int64 `json:"duration"`
+}
+
+// Handle request for `POST /v1/accounts/{username}/mute`
+func (h *handler) Mute(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req MuteRequest
+	// ボディは省略できる
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		httperror.BadRequest(w, err)
+		return
+	}
+	if err := req.Validate(); err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+
+	target := h.targetOf(w, r)
+	if target == nil {
+		return
+	}
+	account := auth.AccountOf(r)
+
+	mute := &object.Mute{
+		AccountID:         account.ID,
+		TargetAccountID:   target.ID,
+		HideNotifications: req.Notifications == nil || *req.Notifications,
+	}
+	if req.Duration > 0 {
+		mute.ExpiresAt = &object.DateTime{Time: time.Now().Add(time.Duration(req.Duration) * time.Second)}
+	}
+	if err := h.app.Dao.Mute().Add(ctx, mute); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+
+	relationship, err := h.relationship(ctx, account, target)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(relationship); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+}
+
+// Handle request for `POST /v1/accounts/{username}/unmute`
+func (h *handler) Unmute(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	target := h.targetOf(w, r)
+	if target == nil {
+		return
+	}
+	account := auth.AccountOf(r)
+
+	if err := h.app.Dao.Mute().Delete(ctx, account.ID, target.ID); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+
+	relationship, err := h.relationship(ctx, account, target)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(relationship); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+}
+
+func (req *MuteRequest) Validate() error {
+	if req.Duration < 0 {
+		return errors.New("duration must not be negative")
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+	"yatter-backend-go/app/handler/request"
+)
+
+// Read target account of `/v1/accounts/{username}/...`
+//
+// 見つからない場合や自分自身を指定した場合はエラーレスポンスを書き込んで nil を返す
+func (h *handler) targetOf(w http.ResponseWriter, r *http.Request) *object.Account {
+	username, err := request.UsernameOf(r)
+	if err != nil {
+		httperror.BadRequest(w, err)
+		return nil
+	}
+
+	target, err := h.app.Dao.Account().FindByUsername(r.Context(), username)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return nil
+	}
+	if target == nil {
+		httperror.NotFound(w)
+		return nil
+	}
+	if target.ID == auth.AccountOf(r).ID {
+		httperror.BadRequest(w, errors.New("cannot target yourself"))
+		return nil
+	}
+	return target
+}
+
+// 認証中のアカウントから見た target との関係を取得する
+func (h *handler) relationship(ctx context.Context, account, target *object.Account) (*object.Relationship, error) {
+	following, err := h.app.Dao.Follow().Exists(ctx, account.ID, target.ID)
+	if err != nil {
+		return nil, err
+	}
+	followedBy, err := h.app.Dao.Follow().Exists(ctx, target.ID, account.ID)
+	if err != nil {
+		return nil, err
+	}
+	requested, err := h.app.Dao.FollowRequest().Exists(ctx, account.ID, target.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	blockRepo := h.app.Dao.Block()
+	blocking, err := blockRepo.Exists(ctx, account.ID, target.ID)
+	if err != nil {
+		return nil, err
+	}
+	blockedBy, err := blockRepo.Exists(ctx, target.ID, account.ID)
+	if err != nil {
+		return nil, err
+	}
+	mute, err := h.app.Dao.Mute().Find(ctx, account.ID, target.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &object.Relationship{
+		ID:                  target.ID,
+		Following:           following,
+		FollowedBy:          followedBy,
+		Requested:           requested,
+		Blocking:            blocking,
+		BlockedBy:           blockedBy,
+		Muting:              mute != nil,
+		MutingNotifications: mute != nil && mute.HideNotifications,
+	}, nil
+}
@@ -0,0 +1,56 @@
+package accounts
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+)
+
+// Handle request for `GET /v1/accounts/relationships?username=a,b`
+//
+// 存在しないアカウントは結果に含めない
+func (h *handler) Relationships(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	param := r.URL.Query().Get("username")
+	if param == "" {
+		httperror.BadRequest(w, errors.New("username is required"))
+		return
+	}
+	usernames := strings.Split(param, ",")
+	if len(usernames) > MaxLimit {
+		httperror.BadRequest(w, errors.New("too many usernames"))
+		return
+	}
+
+	account := auth.AccountOf(r)
+	relationships := make([]*object.Relationship, 0, len(usernames))
+	for _, username := range usernames {
+		target, err := h.app.Dao.Account().FindByUsername(ctx, strings.TrimSpace(username))
+		if err != nil {
+			httperror.InternalServerError(w, r, err)
+			return
+		}
+		if target == nil {
+			continue
+		}
+
+		relationship, err := h.relationship(ctx, account, target)
+		if err != nil {
+			httperror.InternalServerError(w, r, err)
+			return
+		}
+		relationships = append(relationships, relationship)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(relationships); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"net/http"
 
 	"yatter-backend-go/app/app"
+	"yatter-backend-go/app/config"
+	"yatter-backend-go/app/handler/auth"
 
 	"github.com/go-chi/chi"
 )
@@ -18,8 +20,27 @@ func NewRouter(app *app.App) http.Handler {
 	r := chi.NewRouter()
 
 	h := &handler{app: app}
-	r.Post("/", h.Create)
+	r.With(app.RateLimiter.Middleware(config.RateLimitAccountsCreate, auth.ClientKey)).Post("/", h.Create)
+	r.With(auth.OptionalMiddleware(app)).Get("/search", h.Search)
 	r.Get("/{username}", h.Get)
+	r.Get("/{username}/following", h.Following)
+	r.Get("/{username}/followers", h.Followers)
+	r.With(auth.OptionalMiddleware(app)).Get("/{username}/statuses", h.Statuses)
+
+	// 以下の処理は認証を必要とする
+	r.Group(func(r chi.Router) {
+		r.Use(auth.Middleware(app))
+		r.Delete("/", h.Delete)
+		r.Post("/cancel_deletion", h.CancelDeletion)
+		r.Post("/update_credentials", h.UpdateCredentials)
+		r.Get("/relationships", h.Relationships)
+		r.Post("/{username}/follow", h.Follow)
+		r.Post("/{username}/unfollow", h.Unfollow)
+		r.Post("/{username}/block", h.Block)
+		r.Post("/{username}/unblock", h.Unblock)
+		r.Post("/{username}/mute", h.Mute)
+		r.Post("/{username}/unmute", h.Unmute)
+	})
 
 	return r
 }
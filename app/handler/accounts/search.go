@@ -0,0 +1,62 @@
+package accounts
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"yatter-backend-go/app/domain/repository"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+	"yatter-backend-go/app/handler/request"
+)
+
+// Handle request for `GET /v1/accounts/search`
+//
+// 投稿画面でのメンションの補完用。q の先頭の @ は無視する
+func (h *handler) Search(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	q := strings.TrimPrefix(strings.TrimSpace(r.URL.Query().Get("q")), "@")
+	if q == "" {
+		httperror.BadRequest(w, errors.New("q is required"))
+		return
+	}
+	limit, err := request.QueryInt64(r, "limit", DefaultLimit)
+	if err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+	if limit == 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+	following, err := request.QueryBool(r, "following", false)
+	if err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+
+	opts := repository.AccountPrefixOptions{Following: following, Limit: limit}
+	if account := auth.AccountOf(r); account != nil {
+		opts.ViewerID = account.ID
+	} else if following {
+		httperror.Error(w, http.StatusUnauthorized)
+		return
+	}
+
+	accounts, err := h.app.Dao.Account().FindByPrefix(ctx, q, opts)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(accounts); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+}
@@ -0,0 +1,93 @@
+package accounts
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/domain/repository"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+	"yatter-backend-go/app/handler/request"
+	"yatter-backend-go/app/handler/statuses"
+)
+
+// Handle request for `GET /v1/accounts/{username}/statuses`
+func (h *handler) Statuses(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	username, err := request.UsernameOf(r)
+	if err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+	pinned, err := request.QueryBool(r, "pinned", false)
+	if err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+	maxID, err := request.QueryInt64(r, "max_id", 0)
+	if err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+	sinceID, err := request.QueryInt64(r, "since_id", 0)
+	if err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+	limit, err := request.QueryInt64(r, "limit", DefaultLimit)
+	if err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+	if limit == 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+
+	account, err := h.app.Dao.Account().FindByUsername(ctx, username)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+	if account == nil {
+		httperror.NotFound(w)
+		return
+	}
+
+	opts := repository.AccountStatusOptions{
+		Pinned:  pinned,
+		MaxID:   maxID,
+		SinceID: sinceID,
+		Limit:   limit,
+	}
+	// ログインしている場合は非公開の投稿も見られるかを判定し、ブロックしている相手の投稿は返さない
+	if viewer := auth.AccountOf(r); viewer != nil {
+		opts.ViewerID = viewer.ID
+	}
+
+	found, err := h.app.Dao.Status().FindByAccount(ctx, account.ID, opts)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+	if err := statuses.Attach(ctx, h.app.Dao, found, opts.ViewerID); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+	filters, err := statuses.Filters(ctx, h.app.Dao, opts.ViewerID, object.FilterContextAccount)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+	found = filters.Apply(found)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(found); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+}
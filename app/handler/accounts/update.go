@@ -0,0 +1,93 @@
+package accounts
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+)
+
+// Request body for `POST /v1/accounts/update_credentials`
+//
+// 指定されなかった項目は変更しない
+type UpdateRequest struct {
+	DisplayName *string `json:"display_name"`
+	Note        *string `json:"note"`
+	Locked      *bool   `json:"locked"`
+}
+
+// Handle request for `POST /v1/accounts/update_credentials`
+//
+// JSON と form (multipart/form-data, application/x-www-form-urlencoded) のどちらも受け付ける
+func (h *handler) UpdateCredentials(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := parseUpdateRequest(r)
+	if err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+
+	account := auth.AccountOf(r)
+	wasLocked := account.Locked
+	if req.DisplayName != nil {
+		account.DisplayName = req.DisplayName
+	}
+	if req.Note != nil {
+		account.Note = req.Note
+	}
+	if req.Locked != nil {
+		account.Locked = *req.Locked
+	}
+
+	if err := h.app.Dao.Account().Update(ctx, account); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+	// 鍵を外した時は保留中のフォローリクエストをすべて承認する
+	if wasLocked && !account.Locked {
+		if err := h.app.Dao.FollowRequest().AuthorizeAll(ctx, account.ID); err != nil {
+			httperror.InternalServerError(w, r, err)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(account); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+}
+
+func parseUpdateRequest(r *http.Request) (*UpdateRequest, error) {
+	var req UpdateRequest
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			return nil, err
+		}
+		return &req, nil
+	}
+
+	if err := r.ParseMultipartForm(1 << 20); err != nil && err != http.ErrNotMultipart {
+		return nil, err
+	}
+	if _, ok := r.Form["display_name"]; ok {
+		v := r.FormValue("display_name")
+		req.DisplayName = &v
+	}
+	if _, ok := r.Form["note"]; ok {
+		v := r.FormValue("note")
+		req.Note = &v
+	}
+	if _, ok := r.Form["locked"]; ok {
+		v, err := strconv.ParseBool(r.FormValue("locked"))
+		if err != nil {
+			return nil, err
+		}
+		req.Locked = &v
+	}
+	return &req, nil
+}
@@ -0,0 +1,307 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"unicode/utf8"
+
+	"yatter-backend-go/app/domain/customerror"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/domain/repository"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+	"yatter-backend-go/app/handler/request"
+)
+
+// 警告文の最大文字数
+const MaxWarningLength = 1000
+
+// Account with the details only moderators can see
+type AdminAccount struct {
+	// The internal ID of the account
+	ID object.AccountID `json:"id"`
+	*object.Account
+	// One of "user", "moderator" and "admin"
+	Role object.Role `json:"role"`
+	// One of "active", "silenced" and "suspended"
+	Status string `json:"status"`
+	// The time the account was silenced
+	SilencedAt *object.DateTime `json:"silenced_at"`
+	// The time the account was suspended
+	SuspendedAt *object.DateTime `json:"suspended_at"`
+	// The time the account will be deleted
+	DeleteScheduledAt *object.DateTime `json:"delete_scheduled_at"`
+	// IP addresses the account has used. Only for a single account
+	IPs []*object.AccountIP `json:"ips,omitempty"`
+	// Actions taken against the account. Only for a single account
+	Warnings []*object.AccountWarning `json:"warnings,omitempty"`
+}
+
+// Request body for `POST /v1/admin/accounts/{username}/{action}` and `DELETE /v1/admin/accounts/{username}`
+type ActionRequest struct {
+	// Reason told to the account
+	Text string `json:"text"`
+}
+
+// Request body for `POST /v1/admin/accounts/{username}/role`
+type RoleRequest struct {
+	Role object.Role `json:"role"`
+}
+
+func (h *handler) adminAccount(a *object.Account) *AdminAccount {
+	auth.SetConfiguredRole(h.app, a)
+	return &AdminAccount{
+		ID:                a.ID,
+		Account:           a,
+		Role:              a.Role,
+		Status:            a.Status(),
+		SilencedAt:        a.SilencedAt,
+		SuspendedAt:       a.SuspendedAt,
+		DeleteScheduledAt: a.DeleteScheduledAt,
+	}
+}
+
+// Handle request for `GET /v1/admin/accounts`
+//
+// username は前方一致、status は active, silenced, suspended のいずれか
+func (h *handler) Accounts(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	opts := repository.AccountOptions{
+		Username: query.Get("username"),
+		Role:     query.Get("role"),
+		Status:   query.Get("status"),
+		IP:       query.Get("ip"),
+	}
+	if opts.Role != "" && !object.IsValidRole(opts.Role) {
+		httperror.BadRequest(w, fmt.Errorf("invalid role: %s", opts.Role))
+		return
+	}
+	switch opts.Status {
+	case "", object.AccountStatusActive, object.AccountStatusSilenced, object.AccountStatusSuspended:
+	default:
+		httperror.BadRequest(w, fmt.Errorf("invalid status: %s", opts.Status))
+		return
+	}
+	var err error
+	if opts.MaxID, err = request.QueryInt64(r, "max_id", 0); err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+	if opts.SinceID, err = request.QueryInt64(r, "since_id", 0); err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+	if opts.Limit, err = request.QueryInt64(r, "limit", DefaultLimit); err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+	if opts.Limit <= 0 {
+		opts.Limit = DefaultLimit
+	}
+	if opts.Limit > MaxLimit {
+		opts.Limit = MaxLimit
+	}
+
+	accounts, err := h.app.Dao.Account().Find(r.Context(), opts)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+	views := make([]*AdminAccount, 0, len(accounts))
+	for _, a := range accounts {
+		views = append(views, h.adminAccount(a))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(views); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+}
+
+// Handle request for `GET /v1/admin/accounts/{username}`
+func (h *handler) Account(w http.ResponseWriter, r *http.Request) {
+	target := h.findAccount(w, r)
+	if target == nil {
+		return
+	}
+	h.writeAccount(w, r, target.ID)
+}
+
+// Handle request for `POST /v1/admin/accounts/{username}/warn`
+func (h *handler) WarnAccount(w http.ResponseWriter, r *http.Request) {
+	h.takeAction(w, r, object.AccountActionWarn)
+}
+
+// Handle request for `POST /v1/admin/accounts/{username}/silence`
+func (h *handler) SilenceAccount(w http.ResponseWriter, r *http.Request) {
+	h.takeAction(w, r, object.AccountActionSilence)
+}
+
+// Handle request for `POST /v1/admin/accounts/{username}/unsilence`
+func (h *handler) UnsilenceAccount(w http.ResponseWriter, r *http.Request) {
+	h.takeAction(w, r, object.AccountActionUnsilence)
+}
+
+// Handle request for `POST /v1/admin/accounts/{username}/suspend`
+func (h *handler) SuspendAccount(w http.ResponseWriter, r *http.Request) {
+	h.takeAction(w, r, object.AccountActionSuspend)
+}
+
+// Handle request for `POST /v1/admin/accounts/{username}/unsuspend`
+func (h *handler) UnsuspendAccount(w http.ResponseWriter, r *http.Request) {
+	h.takeAction(w, r, object.AccountActionUnsuspend)
+}
+
+// Handle request for `DELETE /v1/admin/accounts/{username}`
+//
+// 凍結したうえで削除を予約し、ワーカーが関連データごと削除する
+func (h *handler) DeleteAccount(w http.ResponseWriter, r *http.Request) {
+	h.takeAction(w, r, object.AccountActionDelete)
+}
+
+func (h *handler) takeAction(w http.ResponseWriter, r *http.Request, actionType object.AccountActionType) {
+	var req ActionRequest
+	// ボディは省略できる
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		httperror.BadRequest(w, err)
+		return
+	}
+	if actionType == object.AccountActionWarn && req.Text == "" {
+		httperror.UnprocessableEntity(w, errors.New("text is required"))
+		return
+	}
+	if utf8.RuneCountInString(req.Text) > MaxWarningLength {
+		httperror.UnprocessableEntity(w, fmt.Errorf("text must be at most %d characters", MaxWarningLength))
+		return
+	}
+
+	target := h.findTarget(w, r)
+	if target == nil {
+		return
+	}
+	action := &object.AccountAction{
+		Type:            actionType,
+		TargetAccountID: target.ID,
+		ActorID:         auth.AccountOf(r).ID,
+		Text:            req.Text,
+	}
+	if err := h.app.Dao.Account().TakeAction(r.Context(), action); err != nil {
+		if errors.Is(err, customerror.ErrNotFound) {
+			httperror.NotFound(w)
+			return
+		}
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+	h.writeAccount(w, r, target.ID)
+}
+
+// Handle request for `POST /v1/admin/accounts/{username}/role`
+func (h *handler) SetRole(w http.ResponseWriter, r *http.Request) {
+	var req RoleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+	if !object.IsValidRole(req.Role) {
+		httperror.UnprocessableEntity(w, fmt.Errorf("invalid role: %s", req.Role))
+		return
+	}
+
+	target := h.findAccount(w, r)
+	if target == nil {
+		return
+	}
+	actor := auth.AccountOf(r)
+	if target.ID == actor.ID {
+		httperror.UnprocessableEntity(w, errors.New("cannot change your own role"))
+		return
+	}
+	if h.app.Config.Admin.IsAdmin(target.Username) {
+		httperror.UnprocessableEntity(w, errors.New("role of the account is fixed by configuration"))
+		return
+	}
+
+	if err := h.app.Dao.Account().SetRole(r.Context(), target.ID, req.Role, actor.ID); err != nil {
+		if errors.Is(err, customerror.ErrNotFound) {
+			httperror.NotFound(w)
+			return
+		}
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+	h.writeAccount(w, r, target.ID)
+}
+
+// パスのアカウントを取得する。見つからなければ 404 を書いて nil を返す
+func (h *handler) findAccount(w http.ResponseWriter, r *http.Request) *object.Account {
+	username, err := request.UsernameOf(r)
+	if err != nil {
+		httperror.BadRequest(w, err)
+		return nil
+	}
+	target, err := h.app.Dao.Account().FindByUsername(r.Context(), username)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return nil
+	}
+	if target == nil {
+		httperror.NotFound(w)
+		return nil
+	}
+	auth.SetConfiguredRole(h.app, target)
+	return target
+}
+
+// 操作の対象にできるアカウントを取得する
+// 自分自身と、自分と同じかより強い権限を持つアカウントは対象にできない
+func (h *handler) findTarget(w http.ResponseWriter, r *http.Request) *object.Account {
+	target := h.findAccount(w, r)
+	if target == nil {
+		return nil
+	}
+	actor := auth.AccountOf(r)
+	if target.ID == actor.ID {
+		httperror.UnprocessableEntity(w, errors.New("cannot take actions against yourself"))
+		return nil
+	}
+	if !actor.Outranks(target) {
+		httperror.Error(w, http.StatusForbidden)
+		return nil
+	}
+	return target
+}
+
+// IP アドレスの履歴と警告を付けてアカウントを返す
+func (h *handler) writeAccount(w http.ResponseWriter, r *http.Request, id object.AccountID) {
+	ctx := r.Context()
+
+	a, err := h.app.Dao.Account().FindByID(ctx, id)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+	if a == nil {
+		httperror.NotFound(w)
+		return
+	}
+	view := h.adminAccount(a)
+	if view.IPs, err = h.app.Dao.Account().FindIPs(ctx, id); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+	if view.Warnings, err = h.app.Dao.Account().FindWarnings(ctx, id); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(view); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+}
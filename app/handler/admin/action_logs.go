@@ -0,0 +1,46 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"yatter-backend-go/app/domain/repository"
+	"yatter-backend-go/app/handler/httperror"
+	"yatter-backend-go/app/handler/request"
+)
+
+// Handle request for `GET /v1/admin/action_logs`
+func (h *handler) ActionLogs(w http.ResponseWriter, r *http.Request) {
+	opts := repository.ModerationLogOptions{}
+	var err error
+	if opts.MaxID, err = request.QueryInt64(r, "max_id", 0); err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+	if opts.SinceID, err = request.QueryInt64(r, "since_id", 0); err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+	if opts.Limit, err = request.QueryInt64(r, "limit", DefaultLimit); err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+	if opts.Limit <= 0 {
+		opts.Limit = DefaultLimit
+	}
+	if opts.Limit > MaxLimit {
+		opts.Limit = MaxLimit
+	}
+
+	logs, err := h.app.Dao.ModerationLog().Find(r.Context(), opts)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(logs); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+}
@@ -0,0 +1,137 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"yatter-backend-go/app/domain/customerror"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+	"yatter-backend-go/app/handler/request"
+)
+
+// Request body for `POST /v1/admin/content_rules` and `PUT /v1/admin/content_rules/{id}`
+type ContentRuleRequest struct {
+	Type          object.ContentRuleType   `json:"type"`
+	Value         string                   `json:"value"`
+	Action        object.ContentRuleAction `json:"action"`
+	MaxAccountAge int64                    `json:"max_account_age"`
+}
+
+func (req *ContentRuleRequest) rule() *object.ContentRule {
+	return &object.ContentRule{
+		Type:          req.Type,
+		Value:         req.Value,
+		Action:        req.Action,
+		MaxAccountAge: req.MaxAccountAge,
+	}
+}
+
+// Handle request for `GET /v1/admin/content_rules`
+func (h *handler) ContentRules(w http.ResponseWriter, r *http.Request) {
+	rules, err := h.app.Dao.ContentRule().FindAll(r.Context())
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(rules); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+}
+
+// Handle request for `POST /v1/admin/content_rules`
+func (h *handler) CreateContentRule(w http.ResponseWriter, r *http.Request) {
+	var req ContentRuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+	rule := req.rule()
+	if err := rule.Validate(); err != nil {
+		httperror.UnprocessableEntity(w, err)
+		return
+	}
+
+	id, err := h.app.Dao.ContentRule().Add(r.Context(), rule, auth.AccountOf(r).ID)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+	h.writeContentRule(w, r, id)
+}
+
+// Handle request for `PUT /v1/admin/content_rules/{id}`
+func (h *handler) UpdateContentRule(w http.ResponseWriter, r *http.Request) {
+	id, err := request.IDOf(r)
+	if err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+	var req ContentRuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+	rule := req.rule()
+	rule.ID = id
+	if err := rule.Validate(); err != nil {
+		httperror.UnprocessableEntity(w, err)
+		return
+	}
+
+	if err := h.app.Dao.ContentRule().Update(r.Context(), rule, auth.AccountOf(r).ID); err != nil {
+		if errors.Is(err, customerror.ErrNotFound) {
+			httperror.NotFound(w)
+			return
+		}
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+	h.writeContentRule(w, r, id)
+}
+
+// Handle request for `DELETE /v1/admin/content_rules/{id}`
+func (h *handler) DeleteContentRule(w http.ResponseWriter, r *http.Request) {
+	id, err := request.IDOf(r)
+	if err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+	if err := h.app.Dao.ContentRule().Delete(r.Context(), id, auth.AccountOf(r).ID); err != nil {
+		if errors.Is(err, customerror.ErrNotFound) {
+			httperror.NotFound(w)
+			return
+		}
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(&struct{}{}); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+}
+
+func (h *handler) writeContentRule(w http.ResponseWriter, r *http.Request, id object.ContentRuleID) {
+	rule, err := h.app.Dao.ContentRule().FindByID(r.Context(), id)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+	if rule == nil {
+		httperror.NotFound(w)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(rule); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+}
@@ -0,0 +1,204 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"yatter-backend-go/app/domain/customerror"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/domain/repository"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+	"yatter-backend-go/app/handler/request"
+)
+
+const (
+	DefaultLimit = 40
+	MaxLimit     = 80
+)
+
+// Report with the details only moderators can see
+type AdminReport struct {
+	*object.Report
+	// The account which made the report
+	Account *object.Account `json:"account"`
+	// The moderator handling the report
+	AssignedAccount *object.Account `json:"assigned_account"`
+	// The moderator who resolved the report
+	ActionTakenByAccount *object.Account `json:"action_taken_by_account"`
+	// The time the report was resolved
+	ActionTakenAt *object.DateTime `json:"action_taken_at"`
+	// The reported statuses which still exist
+	Statuses []*object.Status `json:"statuses"`
+}
+
+// Handle request for `GET /v1/admin/reports`
+//
+// resolved=true で対応済みの通報を返す
+func (h *handler) Reports(w http.ResponseWriter, r *http.Request) {
+	opts := repository.ReportOptions{}
+	var err error
+	if opts.Resolved, err = request.QueryBool(r, "resolved", false); err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+	if opts.MaxID, err = request.QueryInt64(r, "max_id", 0); err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+	if opts.SinceID, err = request.QueryInt64(r, "since_id", 0); err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+	if opts.Limit, err = request.QueryInt64(r, "limit", DefaultLimit); err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+	if opts.Limit <= 0 {
+		opts.Limit = DefaultLimit
+	}
+	if opts.Limit > MaxLimit {
+		opts.Limit = MaxLimit
+	}
+
+	reports, err := h.app.Dao.Report().Find(r.Context(), opts)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+	views, err := h.adminReports(r.Context(), reports)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(views); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+}
+
+// Handle request for `GET /v1/admin/reports/{id}`
+func (h *handler) Report(w http.ResponseWriter, r *http.Request) {
+	id, err := request.IDOf(r)
+	if err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+	h.writeReport(w, r, id)
+}
+
+// Handle request for `POST /v1/admin/reports/{id}/assign_to_self`
+func (h *handler) AssignReport(w http.ResponseWriter, r *http.Request) {
+	h.updateReport(w, r, func(ctx context.Context, id object.ReportID, actorID object.AccountID) error {
+		return h.app.Dao.Report().Assign(ctx, id, &actorID, actorID)
+	})
+}
+
+// Handle request for `POST /v1/admin/reports/{id}/unassign`
+func (h *handler) UnassignReport(w http.ResponseWriter, r *http.Request) {
+	h.updateReport(w, r, func(ctx context.Context, id object.ReportID, actorID object.AccountID) error {
+		return h.app.Dao.Report().Assign(ctx, id, nil, actorID)
+	})
+}
+
+// Handle request for `POST /v1/admin/reports/{id}/resolve`
+func (h *handler) ResolveReport(w http.ResponseWriter, r *http.Request) {
+	h.updateReport(w, r, h.app.Dao.Report().Resolve)
+}
+
+// Handle request for `POST /v1/admin/reports/{id}/reopen`
+func (h *handler) ReopenReport(w http.ResponseWriter, r *http.Request) {
+	h.updateReport(w, r, h.app.Dao.Report().Reopen)
+}
+
+// 通報を更新して、更新後の通報を返す
+func (h *handler) updateReport(w http.ResponseWriter, r *http.Request, update func(context.Context, object.ReportID, object.AccountID) error) {
+	id, err := request.IDOf(r)
+	if err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+	if err := update(r.Context(), id, auth.AccountOf(r).ID); err != nil {
+		if errors.Is(err, customerror.ErrNotFound) {
+			httperror.NotFound(w)
+			return
+		}
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+	h.writeReport(w, r, id)
+}
+
+func (h *handler) writeReport(w http.ResponseWriter, r *http.Request, id object.ReportID) {
+	report, err := h.app.Dao.Report().FindByID(r.Context(), id)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+	if report == nil {
+		httperror.NotFound(w)
+		return
+	}
+	views, err := h.adminReports(r.Context(), []*object.Report{report})
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(views[0]); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+}
+
+// 通報に関係するアカウントとステータスを付ける
+// 同じアカウントが何度も出てくることが多いので、取得したものを使い回す
+func (h *handler) adminReports(ctx context.Context, reports []*object.Report) ([]*AdminReport, error) {
+	accounts := make(map[object.AccountID]*object.Account)
+	findAccount := func(id *object.AccountID) (*object.Account, error) {
+		if id == nil {
+			return nil, nil
+		}
+		if a, ok := accounts[*id]; ok {
+			return a, nil
+		}
+		a, err := h.app.Dao.Account().FindByID(ctx, *id)
+		if err != nil {
+			return nil, err
+		}
+		accounts[*id] = a
+		return a, nil
+	}
+
+	views := make([]*AdminReport, 0, len(reports))
+	for _, rp := range reports {
+		v := &AdminReport{Report: rp, ActionTakenAt: rp.ActionTakenAt, Statuses: make([]*object.Status, 0, len(rp.StatusIDs))}
+		var err error
+		if v.Account, err = findAccount(rp.AccountID); err != nil {
+			return nil, err
+		}
+		if v.AssignedAccount, err = findAccount(rp.AssignedAccountID); err != nil {
+			return nil, err
+		}
+		if v.ActionTakenByAccount, err = findAccount(rp.ActionTakenByAccountID); err != nil {
+			return nil, err
+		}
+		// 削除されたステータスは含めない。凍結されたアカウントのものは確認できるように含める
+		for _, statusID := range rp.StatusIDs {
+			s, err := h.app.Dao.Status().FindForModeration(ctx, statusID)
+			if err != nil {
+				return nil, err
+			}
+			if s != nil {
+				v.Statuses = append(v.Statuses, s)
+			}
+		}
+		views = append(views, v)
+	}
+	return views, nil
+}
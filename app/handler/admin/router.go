@@ -0,0 +1,63 @@
+package admin
+
+import (
+	"net/http"
+
+	"yatter-backend-go/app/app"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/handler/auth"
+
+	"github.com/go-chi/chi"
+)
+
+// Implementation of handler
+type handler struct {
+	app *app.App
+}
+
+// Create Handler for `/v1/admin`
+func NewRouter(app *app.App) http.Handler {
+	r := chi.NewRouter()
+	h := &handler{app: app}
+
+	r.Use(auth.Middleware(app))
+	r.Use(auth.RoleMiddleware(object.RoleModerator))
+
+	r.Get("/trends/tags", h.TrendTags)
+	r.Post("/trends/tags/{name}/approve", h.ApproveTag)
+	r.Post("/trends/tags/{name}/reject", h.RejectTag)
+	r.Get("/trends/statuses", h.TrendStatuses)
+	r.Post("/trends/statuses/{id}/approve", h.ApproveStatus)
+	r.Post("/trends/statuses/{id}/reject", h.RejectStatus)
+
+	r.Get("/reports", h.Reports)
+	r.Get("/reports/{id}", h.Report)
+	r.Post("/reports/{id}/assign_to_self", h.AssignReport)
+	r.Post("/reports/{id}/unassign", h.UnassignReport)
+	r.Post("/reports/{id}/resolve", h.ResolveReport)
+	r.Post("/reports/{id}/reopen", h.ReopenReport)
+
+	r.Get("/action_logs", h.ActionLogs)
+
+	r.Get("/accounts", h.Accounts)
+	r.Get("/accounts/{username}", h.Account)
+	r.Post("/accounts/{username}/warn", h.WarnAccount)
+	r.Post("/accounts/{username}/silence", h.SilenceAccount)
+	r.Post("/accounts/{username}/unsilence", h.UnsilenceAccount)
+	r.Post("/accounts/{username}/suspend", h.SuspendAccount)
+	r.Post("/accounts/{username}/unsuspend", h.UnsuspendAccount)
+
+	// アカウントの削除、権限とコンテンツルールの変更は管理者だけができる
+	r.Group(func(r chi.Router) {
+		r.Use(auth.RoleMiddleware(object.RoleAdmin))
+		r.Delete("/accounts/{username}", h.DeleteAccount)
+		r.Post("/accounts/{username}/role", h.SetRole)
+
+		r.Get("/content_rules", h.ContentRules)
+		r.Post("/content_rules", h.CreateContentRule)
+		r.Put("/content_rules/{id}", h.UpdateContentRule)
+		r.Delete("/content_rules/{id}", h.DeleteContentRule)
+	})
+
+	return r
+}
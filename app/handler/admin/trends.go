@@ -0,0 +1,110 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"yatter-backend-go/app/domain/customerror"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+	"yatter-backend-go/app/handler/request"
+
+	"github.com/go-chi/chi"
+)
+
+// 審査待ちを見落とさないよう、ランキングに入っているものはまとめて返す
+const candidateLimit = 100
+
+// Trending hashtag with its review result
+type TagCandidate struct {
+	*object.TagTrend
+	Score     float64 `json:"score"`
+	Trendable *bool   `json:"trendable"`
+}
+
+// Handle request for `GET /v1/admin/trends/tags`
+func (h *handler) TrendTags(w http.ResponseWriter, r *http.Request) {
+	trends, err := h.app.Dao.Trend().FindTagCandidates(r.Context(), candidateLimit)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+
+	candidates := make([]*TagCandidate, 0, len(trends))
+	for _, t := range trends {
+		candidates = append(candidates, &TagCandidate{TagTrend: t, Score: t.Score, Trendable: t.Trendable})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(candidates); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+}
+
+// Handle request for `POST /v1/admin/trends/tags/{name}/approve`
+func (h *handler) ApproveTag(w http.ResponseWriter, r *http.Request) {
+	h.setTagTrendable(w, r, true)
+}
+
+// Handle request for `POST /v1/admin/trends/tags/{name}/reject`
+func (h *handler) RejectTag(w http.ResponseWriter, r *http.Request) {
+	h.setTagTrendable(w, r, false)
+}
+
+func (h *handler) setTagTrendable(w http.ResponseWriter, r *http.Request, trendable bool) {
+	name := chi.URLParam(r, "name")
+	if err := h.app.Dao.Trend().SetTagTrendable(r.Context(), name, trendable, auth.AccountOf(r).ID); err != nil {
+		if errors.Is(err, customerror.ErrNotFound) {
+			httperror.NotFound(w)
+			return
+		}
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Handle request for `GET /v1/admin/trends/statuses`
+func (h *handler) TrendStatuses(w http.ResponseWriter, r *http.Request) {
+	trends, err := h.app.Dao.Trend().FindStatusCandidates(r.Context(), candidateLimit)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(trends); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+}
+
+// Handle request for `POST /v1/admin/trends/statuses/{id}/approve`
+func (h *handler) ApproveStatus(w http.ResponseWriter, r *http.Request) {
+	h.setStatusTrendable(w, r, true)
+}
+
+// Handle request for `POST /v1/admin/trends/statuses/{id}/reject`
+func (h *handler) RejectStatus(w http.ResponseWriter, r *http.Request) {
+	h.setStatusTrendable(w, r, false)
+}
+
+func (h *handler) setStatusTrendable(w http.ResponseWriter, r *http.Request, trendable bool) {
+	id, err := request.IDOf(r)
+	if err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+	if err := h.app.Dao.Trend().SetStatusTrendable(r.Context(), id, trendable, auth.AccountOf(r).ID); err != nil {
+		if errors.Is(err, customerror.ErrNotFound) {
+			httperror.NotFound(w)
+			return
+		}
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
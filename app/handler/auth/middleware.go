@@ -2,12 +2,18 @@ package auth
 
 import (
 	"context"
+	"net"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"yatter-backend-go/app/app"
 	"yatter-backend-go/app/domain/object"
 	"yatter-backend-go/app/handler/httperror"
+	"yatter-backend-go/app/logger"
+
+	"go.uber.org/zap"
 )
 
 var contextKey = new(struct{})
@@ -30,30 +36,99 @@ func Middleware(app *app.App) func(http.Handler) http.Handler {
 			a := r.Header.Get("Authentication")
 			pair := strings.SplitN(a, " ", 2)
 			if len(pair) < 2 {
+				app.Metrics.AuthFailures.WithLabelValues("missing_credentials").Inc()
 				httperror.Error(w, http.StatusUnauthorized)
 				return
 			}
 
 			authType := pair[0]
 			if !strings.EqualFold(authType, "username") {
+				app.Metrics.AuthFailures.WithLabelValues("invalid_scheme").Inc()
 				httperror.Error(w, http.StatusUnauthorized)
 				return
 			}
 
 			username := pair[1]
 			if account, err := app.Dao.Account().FindByUsername(ctx, username); err != nil {
-				httperror.InternalServerError(w, err)
+				httperror.InternalServerError(w, r, err)
 				return
 			} else if account == nil {
+				app.Metrics.AuthFailures.WithLabelValues("unknown_account").Inc()
 				httperror.Error(w, http.StatusUnauthorized)
 				return
+			} else if account.SuspendedAt != nil {
+				app.Metrics.AuthFailures.WithLabelValues("suspended").Inc()
+				httperror.Error(w, http.StatusForbidden)
+				return
 			} else {
-				next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), contextKey, account)))
+				SetConfiguredRole(app, account)
+				ctx := logger.With(r.Context(), zap.Int64("account_id", account.ID))
+				recordIP(ctx, app, account.ID, clientIP(r))
+				next.ServeHTTP(w, r.WithContext(context.WithValue(ctx, contextKey, account)))
 			}
 		})
 	}
 }
 
+// How often the same IP address of an account is recorded
+const accountIPInterval = time.Hour
+
+// IP アドレスの記録はモデレーション用の補助情報なので、失敗してもリクエストは止めない
+func recordIP(ctx context.Context, app *app.App, id object.AccountID, ip string) {
+	key := "account_ip:" + strconv.FormatInt(id, 10) + ":" + ip
+	res, err := app.AccountIPThrottle.Take(ctx, key, 1, accountIPInterval)
+	if err != nil {
+		logger.FromContext(ctx).Warn("account ip throttle failed", zap.Error(err))
+		return
+	}
+	if !res.Allowed {
+		return
+	}
+	if err := app.Dao.Account().AddIP(ctx, id, ip); err != nil {
+		logger.FromContext(ctx).Warn("record account ip failed", zap.String("ip", ip), zap.Error(err))
+	}
+}
+
+// Auth by header only if present
+//
+// ヘッダーがなければ未認証のまま通す。ヘッダーが不正な場合は Middleware と同じく 401 を返す
+func OptionalMiddleware(app *app.App) func(http.Handler) http.Handler {
+	required := Middleware(app)
+	return func(next http.Handler) http.Handler {
+		authenticated := required(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authentication") == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			authenticated.ServeHTTP(w, r)
+		})
+	}
+}
+
+// Allow only accounts which have the role or a stronger one
+//
+// Middleware の後に使う。権限が足りなければ 403 を返す
+func RoleMiddleware(role object.Role) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			account := AccountOf(r)
+			if account == nil || !account.HasRole(role) {
+				httperror.Error(w, http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// Give the admin role to the account if it is listed in configuration
+func SetConfiguredRole(app *app.App, account *object.Account) {
+	if app.Config.Admin.IsAdmin(account.Username) {
+		account.Role = object.RoleAdmin
+	}
+}
+
 // Read Account data from authorized request
 func AccountOf(r *http.Request) *object.Account {
 	if cv := r.Context().Value(contextKey); cv == nil {
@@ -67,3 +142,22 @@ func AccountOf(r *http.Request) *object.Account {
 
 	}
 }
+
+// Key identifying the client of request
+//
+// 認証済みならアカウント、そうでなければ IP アドレスを返す
+func ClientKey(r *http.Request) string {
+	if account := AccountOf(r); account != nil {
+		return "account:" + strconv.FormatInt(account.ID, 10)
+	}
+	return "ip:" + clientIP(r)
+}
+
+// request.RealIP を通した後は RemoteAddr がクライアントの IP になる
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,50 @@
+package bookmarks
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+	"yatter-backend-go/app/handler/request"
+	"yatter-backend-go/app/handler/statuses"
+)
+
+const (
+	DefaultLimit = 20
+	MaxLimit     = 40
+)
+
+// Handle request for `GET /v1/bookmarks`
+func (h *handler) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	limit, err := request.QueryInt64(r, "limit", DefaultLimit)
+	if err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+	if limit == 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+
+	account := auth.AccountOf(r)
+	bookmarked, err := h.app.Dao.Bookmark().FindStatuses(ctx, account.ID, limit)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+	if err := statuses.Attach(ctx, h.app.Dao, bookmarked, account.ID); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(bookmarked); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+}
@@ -0,0 +1,38 @@
+package conversations
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"yatter-backend-go/app/domain/customerror"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+	"yatter-backend-go/app/handler/request"
+)
+
+// Handle request for `DELETE /v1/conversations/{id}`
+//
+// 自分の一覧から消すだけで、他の参加者やステータスには影響しない
+func (h *handler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := request.IDOf(r)
+	if err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+
+	if err := h.app.Dao.Conversation().Delete(r.Context(), id, auth.AccountOf(r).ID); err != nil {
+		if errors.Is(err, customerror.ErrNotFound) {
+			httperror.NotFound(w)
+			return
+		}
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(&struct{}{}); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+}
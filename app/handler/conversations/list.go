@@ -0,0 +1,73 @@
+package conversations
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/domain/repository"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+	"yatter-backend-go/app/handler/request"
+	"yatter-backend-go/app/handler/statuses"
+)
+
+const (
+	DefaultLimit = 20
+	MaxLimit     = 40
+)
+
+// Handle request for `GET /v1/conversations`
+func (h *handler) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	opts := repository.ConversationOptions{}
+	var err error
+	if opts.MaxID, err = request.QueryInt64(r, "max_id", 0); err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+	if opts.SinceID, err = request.QueryInt64(r, "since_id", 0); err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+	if opts.Limit, err = request.QueryInt64(r, "limit", DefaultLimit); err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+	if opts.Limit <= 0 {
+		opts.Limit = DefaultLimit
+	}
+	if opts.Limit > MaxLimit {
+		opts.Limit = MaxLimit
+	}
+
+	account := auth.AccountOf(r)
+	conversations, err := h.app.Dao.Conversation().FindByAccount(ctx, account.ID, opts)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+	if err := h.attach(ctx, conversations, account.ID); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(conversations); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+}
+
+// 会話の最後のステータスに投票と閲覧者から見た状態を付ける
+func (h *handler) attach(ctx context.Context, conversations []*object.Conversation, viewerID object.AccountID) error {
+	list := make([]*object.Status, 0, len(conversations))
+	for _, c := range conversations {
+		if c.LastStatus != nil {
+			list = append(list, c.LastStatus)
+		}
+	}
+	return statuses.Attach(ctx, h.app.Dao, list, viewerID)
+}
@@ -0,0 +1,49 @@
+package conversations
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+	"yatter-backend-go/app/handler/request"
+)
+
+// Handle request for `POST /v1/conversations/{id}/read`
+func (h *handler) Read(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := request.IDOf(r)
+	if err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+
+	account := auth.AccountOf(r)
+	conversation, err := h.app.Dao.Conversation().FindByID(ctx, id, account.ID)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+	if conversation == nil {
+		httperror.NotFound(w)
+		return
+	}
+
+	if err := h.app.Dao.Conversation().MarkAsRead(ctx, id, account.ID); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+	conversation.Unread = false
+	if err := h.attach(ctx, []*object.Conversation{conversation}, account.ID); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(conversation); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+}
@@ -0,0 +1,28 @@
+package conversations
+
+import (
+	"net/http"
+
+	"yatter-backend-go/app/app"
+	"yatter-backend-go/app/handler/auth"
+
+	"github.com/go-chi/chi"
+)
+
+// Implementation of handler
+type handler struct {
+	app *app.App
+}
+
+// Create Handler for `/v1/conversations`
+func NewRouter(app *app.App) http.Handler {
+	r := chi.NewRouter()
+	h := &handler{app: app}
+
+	r.Use(auth.Middleware(app))
+	r.Get("/", h.List)
+	r.Post("/{id}/read", h.Read)
+	r.Delete("/{id}", h.Delete)
+
+	return r
+}
@@ -0,0 +1,37 @@
+package exports
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+)
+
+// Handle request for `POST /v1/exports`
+//
+// アーカイブはワーカーが非同期に作成する。完了したかどうかは `GET /v1/exports` で確認する
+func (h *handler) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	account := auth.AccountOf(r)
+	id, err := h.app.Dao.Export().Add(ctx, account.ID)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	if err := json.NewEncoder(w).Encode(&object.Export{
+		ID:        id,
+		AccountID: account.ID,
+		State:     object.ExportStatePending,
+		CreateAt:  object.DateTime{Time: time.Now()},
+	}); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+}
@@ -0,0 +1,48 @@
+package exports
+
+import (
+	"net/http"
+	"os"
+	"strconv"
+
+	"yatter-backend-go/app/handler/httperror"
+
+	"github.com/go-chi/chi"
+)
+
+// Handle request for `GET /v1/exports/{token}/download`
+func (h *handler) Download(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	e, err := h.app.Dao.Export().FindByToken(ctx, chi.URLParam(r, "token"))
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+	if e == nil || !downloadable(e) {
+		httperror.NotFound(w)
+		return
+	}
+
+	f, err := os.Open(*e.Path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			httperror.NotFound(w)
+			return
+		}
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+
+	name := "yatter-export-" + strconv.FormatInt(e.ID, 10) + ".zip"
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+name+`"`)
+	w.Header().Set("Cache-Control", "private, no-store")
+	http.ServeContent(w, r, name, info.ModTime(), f)
+}
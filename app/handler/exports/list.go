@@ -0,0 +1,43 @@
+package exports
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+)
+
+// Maximum number of exports returned
+const listLimit = 20
+
+// Handle request for `GET /v1/exports`
+func (h *handler) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	exports, err := h.app.Dao.Export().FindByAccount(ctx, auth.AccountOf(r).ID, listLimit)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+	for _, e := range exports {
+		if downloadable(e) {
+			e.URL = h.app.Config.Server.PublicURL + "/v1/exports/" + *e.Token + "/download"
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(exports); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+}
+
+// 完了していてリンクの期限が切れていないか
+func downloadable(e *object.Export) bool {
+	return e.State == object.ExportStateDone &&
+		e.Token != nil && e.Path != nil &&
+		e.ExpiresAt != nil && time.Now().Before(e.ExpiresAt.Time)
+}
@@ -0,0 +1,33 @@
+package exports
+
+import (
+	"net/http"
+
+	"yatter-backend-go/app/app"
+	"yatter-backend-go/app/config"
+	"yatter-backend-go/app/handler/auth"
+
+	"github.com/go-chi/chi"
+)
+
+// Implementation of handler
+type handler struct {
+	app *app.App
+}
+
+// Create Handler for `/v1/exports/`
+func NewRouter(app *app.App) http.Handler {
+	r := chi.NewRouter()
+	h := &handler{app: app}
+
+	// ダウンロードリンクはトークン自体が認証を兼ねる
+	r.Get("/{token}/download", h.Download)
+
+	r.Group(func(r chi.Router) {
+		r.Use(auth.Middleware(app))
+		r.Get("/", h.List)
+		r.With(app.RateLimiter.Middleware(config.RateLimitExportsCreate, auth.ClientKey)).Post("/", h.Create)
+	})
+
+	return r
+}
@@ -0,0 +1,141 @@
+package filters
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+)
+
+const (
+	// フィルター名・キーワードの最大文字数
+	MaxTitleLength   = 255
+	MaxKeywordLength = 255
+)
+
+// Request body for `POST /v2/filters` and `PUT /v2/filters/{id}`
+//
+// 省略した項目は変更しない。作成時は title と context が必須
+type Request struct {
+	Title        *string  `json:"title"`
+	Context      []string `json:"context"`
+	FilterAction *string  `json:"filter_action"`
+
+	// Seconds until the filter expires. 0 removes the expiry
+	ExpiresIn *int64 `json:"expires_in"`
+
+	// Keywords replacing the current ones
+	KeywordsAttributes *[]KeywordRequest `json:"keywords_attributes"`
+}
+
+// Keyword of Request
+type KeywordRequest struct {
+	Keyword string `json:"keyword"`
+
+	// Default true
+	WholeWord *bool `json:"whole_word"`
+}
+
+// リクエストの内容をフィルターに反映して検証する
+func (req *Request) apply(f *object.Filter, now time.Time) error {
+	if req.Title != nil {
+		f.Title = strings.TrimSpace(*req.Title)
+	}
+	if req.Context != nil {
+		f.Context = object.FilterContexts(req.Context)
+	}
+	if req.FilterAction != nil {
+		f.FilterAction = *req.FilterAction
+	}
+	if req.ExpiresIn != nil {
+		switch {
+		case *req.ExpiresIn < 0:
+			return errors.New("expires_in must not be negative")
+		case *req.ExpiresIn == 0:
+			f.ExpiresAt = nil
+		default:
+			f.ExpiresAt = &object.DateTime{Time: now.Add(time.Duration(*req.ExpiresIn) * time.Second)}
+		}
+	}
+	if req.KeywordsAttributes != nil {
+		f.Keywords = make([]*object.FilterKeyword, 0, len(*req.KeywordsAttributes))
+		for _, k := range *req.KeywordsAttributes {
+			keyword := strings.TrimSpace(k.Keyword)
+			if keyword == "" {
+				return errors.New("keyword must not be empty")
+			}
+			if utf8.RuneCountInString(keyword) > MaxKeywordLength {
+				return fmt.Errorf("keyword must be at most %d characters", MaxKeywordLength)
+			}
+			wholeWord := true
+			if k.WholeWord != nil {
+				wholeWord = *k.WholeWord
+			}
+			f.Keywords = append(f.Keywords, &object.FilterKeyword{Keyword: keyword, WholeWord: wholeWord})
+		}
+	}
+
+	if f.Title == "" {
+		return errors.New("title is required")
+	}
+	if utf8.RuneCountInString(f.Title) > MaxTitleLength {
+		return fmt.Errorf("title must be at most %d characters", MaxTitleLength)
+	}
+	if len(f.Context) == 0 {
+		return errors.New("context is required")
+	}
+	for _, c := range f.Context {
+		if !object.IsValidFilterContext(c) {
+			return fmt.Errorf("unknown context %q", c)
+		}
+	}
+	if !object.IsValidFilterAction(f.FilterAction) {
+		return fmt.Errorf("unknown filter_action %q", f.FilterAction)
+	}
+	return nil
+}
+
+// Handle request for `POST /v2/filters`
+func (h *handler) Create(w http.ResponseWriter, r *http.Request) {
+	var req Request
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+
+	filter := &object.Filter{
+		AccountID:    auth.AccountOf(r).ID,
+		FilterAction: object.FilterActionWarn,
+		Keywords:     make([]*object.FilterKeyword, 0),
+	}
+	if err := req.apply(filter, time.Now()); err != nil {
+		httperror.UnprocessableEntity(w, err)
+		return
+	}
+
+	id, err := h.app.Dao.Filter().Add(r.Context(), filter)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+
+	// キーワードの ID を返すため、登録したものを取得し直す
+	created, err := h.app.Dao.Filter().FindByID(r.Context(), id)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(created); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+}
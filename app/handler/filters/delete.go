@@ -0,0 +1,33 @@
+package filters
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"yatter-backend-go/app/domain/customerror"
+	"yatter-backend-go/app/handler/httperror"
+)
+
+// Handle request for `DELETE /v2/filters/{id}`
+func (h *handler) Delete(w http.ResponseWriter, r *http.Request) {
+	filter := h.findOwn(w, r)
+	if filter == nil {
+		return
+	}
+
+	if err := h.app.Dao.Filter().Delete(r.Context(), filter.ID); err != nil {
+		if errors.Is(err, customerror.ErrNotFound) {
+			httperror.NotFound(w)
+			return
+		}
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(&struct{}{}); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+}
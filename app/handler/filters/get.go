@@ -0,0 +1,46 @@
+package filters
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+	"yatter-backend-go/app/handler/request"
+)
+
+// Handle request for `GET /v2/filters/{id}`
+func (h *handler) Get(w http.ResponseWriter, r *http.Request) {
+	filter := h.findOwn(w, r)
+	if filter == nil {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(filter); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+}
+
+// 認証中のアカウントのフィルターを取得する。見つからない場合はエラーレスポンスを書き込んで nil を返す
+// 他のアカウントのフィルターは存在しないものとして扱う
+func (h *handler) findOwn(w http.ResponseWriter, r *http.Request) *object.Filter {
+	id, err := request.IDOf(r)
+	if err != nil {
+		httperror.BadRequest(w, err)
+		return nil
+	}
+
+	filter, err := h.app.Dao.Filter().FindByID(r.Context(), id)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return nil
+	}
+	if filter == nil || filter.AccountID != auth.AccountOf(r).ID {
+		httperror.NotFound(w)
+		return nil
+	}
+	return filter
+}
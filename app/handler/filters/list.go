@@ -0,0 +1,24 @@
+package filters
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+)
+
+// Handle request for `GET /v2/filters`
+func (h *handler) List(w http.ResponseWriter, r *http.Request) {
+	filters, err := h.app.Dao.Filter().FindByAccount(r.Context(), auth.AccountOf(r).ID)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(filters); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+}
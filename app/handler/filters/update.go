@@ -0,0 +1,55 @@
+package filters
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"yatter-backend-go/app/handler/httperror"
+)
+
+// Handle request for `PUT /v2/filters/{id}`
+//
+// keywords_attributes を指定した場合はキーワードをすべて入れ替える
+func (h *handler) Update(w http.ResponseWriter, r *http.Request) {
+	var req Request
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+
+	filter := h.findOwn(w, r)
+	if filter == nil {
+		return
+	}
+	if err := req.apply(filter, time.Now()); err != nil {
+		httperror.UnprocessableEntity(w, err)
+		return
+	}
+	// キーワードを変更しない場合は入れ替えない
+	if req.KeywordsAttributes == nil {
+		filter.Keywords = nil
+	}
+
+	if err := h.app.Dao.Filter().Update(r.Context(), filter); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+
+	updated, err := h.app.Dao.Filter().FindByID(r.Context(), filter.ID)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+	// 更新中に削除された場合
+	if updated == nil {
+		httperror.NotFound(w)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(updated); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+}
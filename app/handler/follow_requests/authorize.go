@@ -0,0 +1,100 @@
+package follow_requests
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"yatter-backend-go/app/domain/customerror"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+	"yatter-backend-go/app/handler/request"
+)
+
+// Handle request for `POST /v1/follow_requests/{username}/authorize`
+func (h *handler) Authorize(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	requester := h.requesterOf(w, r)
+	if requester == nil {
+		return
+	}
+	account := auth.AccountOf(r)
+
+	if err := h.app.Dao.FollowRequest().Authorize(ctx, requester.ID, account.ID); err != nil {
+		if errors.Is(err, customerror.ErrNotFound) {
+			httperror.NotFound(w)
+			return
+		}
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+
+	h.writeRelationship(w, r, requester)
+}
+
+// Handle request for `POST /v1/follow_requests/{username}/reject`
+func (h *handler) Reject(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	requester := h.requesterOf(w, r)
+	if requester == nil {
+		return
+	}
+	account := auth.AccountOf(r)
+
+	if err := h.app.Dao.FollowRequest().Delete(ctx, requester.ID, account.ID); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+
+	h.writeRelationship(w, r, requester)
+}
+
+// リクエストを送ってきたアカウントを取得する。見つからない場合はエラーレスポンスを書き込んで nil を返す
+func (h *handler) requesterOf(w http.ResponseWriter, r *http.Request) *object.Account {
+	username, err := request.UsernameOf(r)
+	if err != nil {
+		httperror.BadRequest(w, err)
+		return nil
+	}
+
+	requester, err := h.app.Dao.Account().FindByUsername(r.Context(), username)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return nil
+	}
+	if requester == nil {
+		httperror.NotFound(w)
+		return nil
+	}
+	return requester
+}
+
+// 認証中のアカウントから見た requester との関係を書き込む
+func (h *handler) writeRelationship(w http.ResponseWriter, r *http.Request, requester *object.Account) {
+	ctx := r.Context()
+	account := auth.AccountOf(r)
+
+	followedBy, err := h.app.Dao.Follow().Exists(ctx, requester.ID, account.ID)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+	following, err := h.app.Dao.Follow().Exists(ctx, account.ID, requester.ID)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(&object.Relationship{
+		ID:         requester.ID,
+		Following:  following,
+		FollowedBy: followedBy,
+	}); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+}
@@ -0,0 +1,44 @@
+package follow_requests
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+	"yatter-backend-go/app/handler/request"
+)
+
+const (
+	DefaultLimit = 40
+	MaxLimit     = 80
+)
+
+// Handle request for `GET /v1/follow_requests`
+func (h *handler) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	limit, err := request.QueryInt64(r, "limit", DefaultLimit)
+	if err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+	if limit == 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+
+	accounts, err := h.app.Dao.FollowRequest().FindRequesters(ctx, auth.AccountOf(r).ID, limit)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(accounts); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+}
@@ -398,6 +398,123 @@ func TestTimeline_PublicGet(t *testing.T) {
 	}
 }
 
+func TestAccount_BlockAndMute(t *testing.T) {
+	c := setup(t)
+	defer c.Close()
+
+	testCases := []struct {
+		name         string
+		apiPath      string
+		payload      string
+		expectedCode int
+		expectedRes  map[string]interface{}
+	}{
+		{
+			name:         "正常系：ブロックできる",
+			apiPath:      "/v1/accounts/test-user2/block",
+			expectedCode: http.StatusOK,
+			expectedRes:  map[string]interface{}{"blocking": true, "muting": false},
+		},
+		{
+			name:         "正常系：期限付きでミュートできる",
+			apiPath:      "/v1/accounts/test-user3/mute",
+			payload:      `{"notifications":false,"duration":3600}`,
+			expectedCode: http.StatusOK,
+			expectedRes:  map[string]interface{}{"muting": true, "muting_notifications": false},
+		},
+		{
+			name:         "異常系：自分自身はブロックできない",
+			apiPath:      "/v1/accounts/test-user1/block",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "異常系：存在しないアカウント",
+			apiPath:      "/v1/accounts/nobody/mute",
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := c.PostJSONWithAuth(tc.apiPath, tc.payload, "test-user1")
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedCode, resp.StatusCode)
+
+			if tc.expectedCode == http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				assert.NoError(t, err)
+
+				var res map[string]interface{}
+				assert.NoError(t, json.Unmarshal(body, &res))
+				for k, v := range tc.expectedRes {
+					assert.Equal(t, v, res[k], k)
+				}
+			}
+		})
+	}
+
+	t.Run("一覧に含まれる", func(t *testing.T) {
+		for apiPath, username := range map[string]string{"/v1/blocks": "test-user2", "/v1/mutes": "test-user3"} {
+			resp, err := c.GetWithAuth(apiPath, "test-user1")
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+			var res []*object.Account
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&res))
+			assert.Len(t, res, 1)
+			assert.Equal(t, username, res[0].Username)
+		}
+	})
+
+	t.Run("ブロックした相手・された相手のステータスは取得できない", func(t *testing.T) {
+		// ステータス 2 は test-user2、ステータス 1 は test-user1 の投稿
+		for apiPath, username := range map[string]string{"/v1/statuses/2": "test-user1", "/v1/statuses/1": "test-user2"} {
+			resp, err := c.GetWithAuth(apiPath, username)
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusNotFound, resp.StatusCode, apiPath)
+		}
+	})
+
+	t.Run("タイムラインから除外される", func(t *testing.T) {
+		resp, err := c.GetWithAuth("/v1/timelines/public", "test-user1")
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var res []*object.Status
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&res))
+		for _, status := range res {
+			assert.NotContains(t, []string{"test-user2", "test-user3"}, status.Account.Username)
+		}
+	})
+}
+
+/// Health
+func TestHealth(t *testing.T) {
+	c := setup(t)
+	defer c.Close()
+
+	t.Run("liveness", func(t *testing.T) {
+		resp, err := c.Get("/v1/health/live")
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("readiness", func(t *testing.T) {
+		resp, err := c.Get("/v1/health/ready")
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		body, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+
+		var res map[string]interface{}
+		assert.NoError(t, json.Unmarshal(body, &res))
+		assert.Equal(t, "ok", res["status"])
+		assert.Len(t, res["checks"], 1)
+	})
+}
+
 /// utils
 func setup(t *testing.T) *C {
 	app, err := app.NewTestApp()
@@ -470,6 +587,15 @@ func (c *C) PostJSONWithAuth(apiPath string, payload string, username string) (*
 	return c.Server.Client().Do(req)
 }
 
+func (c *C) GetWithAuth(apiPath string, username string) (*http.Response, error) {
+	req, err := http.NewRequest("GET", c.asURL(apiPath), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authentication", "username "+username)
+	return c.Server.Client().Do(req)
+}
+
 func (c *C) DeleteJSONWithAuth(apiPath string, username string) (*http.Response, error) {
 	req, err := http.NewRequest("DELETE", c.asURL(apiPath), nil)
 	if err != nil {
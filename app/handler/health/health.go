@@ -1,16 +1,122 @@
 package health
 
 import (
+	"context"
+	"encoding/json"
 	"net/http"
+	"sync"
+	"time"
+
+	"yatter-backend-go/app/app"
+	"yatter-backend-go/app/handler/httperror"
+
+	"github.com/go-chi/chi"
+)
+
+const (
+	statusOK   = "ok"
+	statusFail = "fail"
 )
 
-// Handle health check request
-func NewRouter() http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "text/plain")
-		_, err := w.Write([]byte("OK"))
-		if err != nil {
-			panic(err)
+// Response body for `GET /v1/health/ready`
+type Report struct {
+	Status string   `json:"status"`
+	Checks []Result `json:"checks"`
+}
+
+// Result of a single dependency check
+type Result struct {
+	Name      string  `json:"name"`
+	Status    string  `json:"status"`
+	LatencyMS float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// Implementation of handler
+type handler struct {
+	app *app.App
+}
+
+// Create Handler for `/v1/health/`
+func NewRouter(app *app.App) http.Handler {
+	r := chi.NewRouter()
+	h := &handler{app: app}
+
+	// `/v1/health` は互換性のため liveness として残す
+	for _, pattern := range []string{"/", "/live"} {
+		r.Get(pattern, h.Live)
+		r.Head(pattern, h.Live)
+	}
+	r.Get("/ready", h.Ready)
+	r.Head("/ready", h.Ready)
+
+	return r
+}
+
+// Handle request for `GET /v1/health/live`
+//
+// プロセスが応答できることだけを確認する。依存先は確認しない
+func (h *handler) Live(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain")
+	_, err := w.Write([]byte("OK"))
+	if err != nil {
+		panic(err)
+	}
+}
+
+// Handle request for `GET /v1/health/ready`
+//
+// 登録された依存先を並行して確認し、一つでも失敗したら 503 を返す
+func (h *handler) Ready(w http.ResponseWriter, r *http.Request) {
+	report := h.check(r.Context())
+
+	code := http.StatusOK
+	if report.Status != statusOK {
+		code = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+}
+
+func (h *handler) check(ctx context.Context) *Report {
+	checks := h.app.HealthChecks
+	results := make([]Result, len(checks))
+	timeout := h.app.Config.Health.CheckTimeout
+
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Add(1)
+		go func(i int, c app.HealthCheck) {
+			defer wg.Done()
+
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			start := time.Now()
+			err := c.Check(ctx)
+			results[i] = Result{
+				Name:      c.Name,
+				Status:    statusOK,
+				LatencyMS: float64(time.Since(start).Microseconds()) / 1000,
+			}
+			if err != nil {
+				results[i].Status = statusFail
+				results[i].Error = err.Error()
+			}
+		}(i, c)
+	}
+	wg.Wait()
+
+	report := &Report{Status: statusOK, Checks: results}
+	for _, res := range results {
+		if res.Status != statusOK {
+			report.Status = statusFail
 		}
 	}
+	return report
 }
@@ -1,8 +1,12 @@
 package httperror
 
 import (
-	"log"
 	"net/http"
+
+	"yatter-backend-go/app/logger"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
 )
 
 // Response with given status code
@@ -15,14 +19,34 @@ func BadRequest(w http.ResponseWriter, err error) {
 	http.Error(w, err.Error(), http.StatusBadRequest)
 }
 
+// Response with Unprocessable Entity (422)
+func UnprocessableEntity(w http.ResponseWriter, err error) {
+	http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+}
+
 // Response with Not Found (404)
 func NotFound(w http.ResponseWriter) {
 	Error(w, http.StatusNotFound)
 }
 
 // Response with Internal Server Error (500)
-func InternalServerError(w http.ResponseWriter, err error) {
-	log.Printf("[InternalServerError] %+v", err)
+//
+// リクエストに紐づいた logger でスタックトレース付きのエラーログを出す
+func InternalServerError(w http.ResponseWriter, r *http.Request, err error) {
+	logger.FromContext(r.Context()).Error("internal server error", zap.Error(withStack(err)))
 
 	Error(w, http.StatusInternalServerError)
 }
+
+type stackTracer interface {
+	StackTrace() errors.StackTrace
+}
+
+// スタックトレースを持っていないエラーには、ここで取得したものを付ける
+func withStack(err error) error {
+	var st stackTracer
+	if errors.As(err, &st) {
+		return err
+	}
+	return errors.WithStack(err)
+}
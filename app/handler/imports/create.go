@@ -0,0 +1,82 @@
+package imports
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+)
+
+// Maximum size of uploaded CSV
+const maxDataSize = 2 << 20
+
+// Handle request for `POST /v1/imports`
+//
+// multipart/form-data で type, mode (省略時は merge) と CSV ファイル data を受け取る。
+// 取り込みはワーカーが非同期に行うので、進捗は `GET /v1/imports/{id}` で確認する
+func (h *handler) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxDataSize+(1<<20))
+	if err := r.ParseMultipartForm(maxDataSize); err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+
+	typ := r.FormValue("type")
+	if !object.IsValidImportType(typ) {
+		httperror.BadRequest(w, fmt.Errorf("unknown type %q", typ))
+		return
+	}
+	mode := r.FormValue("mode")
+	if mode == "" {
+		mode = object.ImportModeMerge
+	}
+	if !object.IsValidImportMode(mode) {
+		httperror.BadRequest(w, fmt.Errorf("unknown mode %q", mode))
+		return
+	}
+
+	f, _, err := r.FormFile("data")
+	if err != nil {
+		httperror.BadRequest(w, errors.New("data is required"))
+		return
+	}
+	defer f.Close()
+	data, err := ioutil.ReadAll(f)
+	if err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+	if len(data) > maxDataSize {
+		httperror.BadRequest(w, errors.New("data is too large"))
+		return
+	}
+
+	imp := &object.Import{
+		AccountID: auth.AccountOf(r).ID,
+		Type:      typ,
+		Mode:      mode,
+		State:     object.ImportStatePending,
+		Data:      data,
+		CreateAt:  object.DateTime{Time: time.Now()},
+	}
+	imp.ID, err = h.app.Dao.Import().Add(ctx, imp)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	if err := json.NewEncoder(w).Encode(imp); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+}
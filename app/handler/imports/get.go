@@ -0,0 +1,120 @@
+package imports
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+	"yatter-backend-go/app/handler/request"
+)
+
+const (
+	DefaultLimit = 40
+	MaxLimit     = 80
+
+	// エラーは一度にまとめて確認したいので多めに返す
+	DefaultErrorsLimit = 100
+	MaxErrorsLimit     = 1000
+)
+
+// Handle request for `GET /v1/imports`
+func (h *handler) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	limit, err := request.QueryInt64(r, "limit", DefaultLimit)
+	if err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+	if limit == 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+
+	imports, err := h.app.Dao.Import().FindByAccount(ctx, auth.AccountOf(r).ID, limit)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(imports); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+}
+
+// Handle request for `GET /v1/imports/{id}`
+func (h *handler) Get(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := request.IDOf(r)
+	if err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+
+	imp, err := h.app.Dao.Import().Find(ctx, auth.AccountOf(r).ID, id)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+	if imp == nil {
+		httperror.NotFound(w)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(imp); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+}
+
+// Handle request for `GET /v1/imports/{id}/errors`
+func (h *handler) Errors(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := request.IDOf(r)
+	if err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+	limit, err := request.QueryInt64(r, "limit", DefaultErrorsLimit)
+	if err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+	if limit == 0 {
+		limit = DefaultErrorsLimit
+	}
+	if limit > MaxErrorsLimit {
+		limit = MaxErrorsLimit
+	}
+
+	// 他人のインポートのエラーは見せない
+	imp, err := h.app.Dao.Import().Find(ctx, auth.AccountOf(r).ID, id)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+	if imp == nil {
+		httperror.NotFound(w)
+		return
+	}
+
+	errs, err := h.app.Dao.Import().FindErrors(ctx, imp.ID, limit)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(errs); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+}
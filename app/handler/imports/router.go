@@ -0,0 +1,30 @@
+package imports
+
+import (
+	"net/http"
+
+	"yatter-backend-go/app/app"
+	"yatter-backend-go/app/config"
+	"yatter-backend-go/app/handler/auth"
+
+	"github.com/go-chi/chi"
+)
+
+// Implementation of handler
+type handler struct {
+	app *app.App
+}
+
+// Create Handler for `/v1/imports/`
+func NewRouter(app *app.App) http.Handler {
+	r := chi.NewRouter()
+	h := &handler{app: app}
+
+	r.Use(auth.Middleware(app))
+	r.Get("/", h.List)
+	r.With(app.RateLimiter.Middleware(config.RateLimitImportsCreate, auth.ClientKey)).Post("/", h.Create)
+	r.Get("/{id}", h.Get)
+	r.Get("/{id}/errors", h.Errors)
+
+	return r
+}
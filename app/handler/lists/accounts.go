@@ -0,0 +1,157 @@
+package lists
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/handler/httperror"
+	"yatter-backend-go/app/handler/request"
+)
+
+const (
+	DefaultLimit = 40
+	MaxLimit     = 80
+)
+
+// Request body for `POST /v1/lists/{id}/accounts` and `DELETE /v1/lists/{id}/accounts`
+type AccountsRequest struct {
+	Usernames []string `json:"usernames"`
+}
+
+// Handle request for `GET /v1/lists/{id}/accounts`
+func (h *handler) Accounts(w http.ResponseWriter, r *http.Request) {
+	limit, err := request.QueryInt64(r, "limit", DefaultLimit)
+	if err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+	if limit == 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+
+	list := h.findOwn(w, r)
+	if list == nil {
+		return
+	}
+
+	accounts, err := h.app.Dao.List().FindAccounts(r.Context(), list.ID, limit)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(accounts); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+}
+
+// Handle request for `POST /v1/lists/{id}/accounts`
+//
+// リストに入れられるのはフォローしているアカウントだけ
+func (h *handler) AddAccounts(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	list, accounts := h.parseAccounts(w, r)
+	if list == nil {
+		return
+	}
+	ids := make([]object.AccountID, 0, len(accounts))
+	for _, a := range accounts {
+		following, err := h.app.Dao.Follow().Exists(ctx, list.AccountID, a.ID)
+		if err != nil {
+			httperror.InternalServerError(w, r, err)
+			return
+		}
+		if !following {
+			httperror.UnprocessableEntity(w, fmt.Errorf("only followed accounts can be added: %s", a.Username))
+			return
+		}
+		ids = append(ids, a.ID)
+	}
+
+	if err := h.app.Dao.List().AddAccounts(ctx, list, ids); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(&struct{}{}); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+}
+
+// Handle request for `DELETE /v1/lists/{id}/accounts`
+func (h *handler) RemoveAccounts(w http.ResponseWriter, r *http.Request) {
+	list, accounts := h.parseAccounts(w, r)
+	if list == nil {
+		return
+	}
+	ids := make([]object.AccountID, 0, len(accounts))
+	for _, a := range accounts {
+		ids = append(ids, a.ID)
+	}
+
+	if err := h.app.Dao.List().RemoveAccounts(r.Context(), list.ID, ids); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(&struct{}{}); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+}
+
+// 認証中のアカウントのリストと、リクエストされたアカウントを取得する
+// 失敗した場合はエラーレスポンスを書き込んで nil を返す
+func (h *handler) parseAccounts(w http.ResponseWriter, r *http.Request) (*object.List, []*object.Account) {
+	var req AccountsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httperror.BadRequest(w, err)
+		return nil, nil
+	}
+	if len(req.Usernames) == 0 {
+		httperror.BadRequest(w, errors.New("usernames is required"))
+		return nil, nil
+	}
+
+	list := h.findOwn(w, r)
+	if list == nil {
+		return nil, nil
+	}
+
+	accounts, err := h.findAccounts(r.Context(), req.Usernames)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return nil, nil
+	}
+	if accounts == nil {
+		httperror.NotFound(w)
+		return nil, nil
+	}
+	return list, accounts
+}
+
+// ユーザー名からアカウントを取得する。存在しないアカウントが含まれる場合は nil を返す
+func (h *handler) findAccounts(ctx context.Context, usernames []string) ([]*object.Account, error) {
+	accounts := make([]*object.Account, 0, len(usernames))
+	for _, username := range usernames {
+		account, err := h.app.Dao.Account().FindByUsername(ctx, username)
+		if err != nil || account == nil {
+			return nil, err
+		}
+		accounts = append(accounts, account)
+	}
+	return accounts, nil
+}
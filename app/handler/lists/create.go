@@ -0,0 +1,62 @@
+package lists
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+)
+
+// リスト名の最大文字数
+const MaxTitleLength = 255
+
+// Request body for `POST /v1/lists` and `PUT /v1/lists/{id}`
+type Request struct {
+	Title string `json:"title"`
+}
+
+// 前後の空白を除いたリスト名を検証する
+func (req *Request) validate() (string, error) {
+	title := strings.TrimSpace(req.Title)
+	if title == "" {
+		return "", errors.New("title is required")
+	}
+	if utf8.RuneCountInString(title) > MaxTitleLength {
+		return "", fmt.Errorf("title must be at most %d characters", MaxTitleLength)
+	}
+	return title, nil
+}
+
+// Handle request for `POST /v1/lists`
+func (h *handler) Create(w http.ResponseWriter, r *http.Request) {
+	var req Request
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+	title, err := req.validate()
+	if err != nil {
+		httperror.UnprocessableEntity(w, err)
+		return
+	}
+
+	list := &object.List{AccountID: auth.AccountOf(r).ID, Title: title}
+	id, err := h.app.Dao.List().Add(r.Context(), list)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+	list.ID = id
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(list); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+}
@@ -0,0 +1,33 @@
+package lists
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"yatter-backend-go/app/domain/customerror"
+	"yatter-backend-go/app/handler/httperror"
+)
+
+// Handle request for `DELETE /v1/lists/{id}`
+func (h *handler) Delete(w http.ResponseWriter, r *http.Request) {
+	list := h.findOwn(w, r)
+	if list == nil {
+		return
+	}
+
+	if err := h.app.Dao.List().Delete(r.Context(), list.ID); err != nil {
+		if errors.Is(err, customerror.ErrNotFound) {
+			httperror.NotFound(w)
+			return
+		}
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(&struct{}{}); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+}
@@ -0,0 +1,46 @@
+package lists
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+	"yatter-backend-go/app/handler/request"
+)
+
+// Handle request for `GET /v1/lists/{id}`
+func (h *handler) Get(w http.ResponseWriter, r *http.Request) {
+	list := h.findOwn(w, r)
+	if list == nil {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(list); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+}
+
+// 認証中のアカウントのリストを取得する。見つからない場合はエラーレスポンスを書き込んで nil を返す
+// 他のアカウントのリストは存在しないものとして扱う
+func (h *handler) findOwn(w http.ResponseWriter, r *http.Request) *object.List {
+	id, err := request.IDOf(r)
+	if err != nil {
+		httperror.BadRequest(w, err)
+		return nil
+	}
+
+	list, err := h.app.Dao.List().FindByID(r.Context(), id)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return nil
+	}
+	if list == nil || list.AccountID != auth.AccountOf(r).ID {
+		httperror.NotFound(w)
+		return nil
+	}
+	return list
+}
@@ -0,0 +1,24 @@
+package lists
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+)
+
+// Handle request for `GET /v1/lists`
+func (h *handler) List(w http.ResponseWriter, r *http.Request) {
+	lists, err := h.app.Dao.List().FindByAccount(r.Context(), auth.AccountOf(r).ID)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(lists); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+}
@@ -0,0 +1,39 @@
+package lists
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"yatter-backend-go/app/handler/httperror"
+)
+
+// Handle request for `PUT /v1/lists/{id}`
+func (h *handler) Update(w http.ResponseWriter, r *http.Request) {
+	var req Request
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+	title, err := req.validate()
+	if err != nil {
+		httperror.UnprocessableEntity(w, err)
+		return
+	}
+
+	list := h.findOwn(w, r)
+	if list == nil {
+		return
+	}
+
+	if err := h.app.Dao.List().UpdateTitle(r.Context(), list.ID, title); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+	list.Title = title
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(list); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+}
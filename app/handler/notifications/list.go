@@ -0,0 +1,116 @@
+package notifications
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/domain/repository"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+	"yatter-backend-go/app/handler/request"
+	"yatter-backend-go/app/handler/statuses"
+)
+
+const (
+	DefaultLimit = 40
+	MaxLimit     = 80
+)
+
+// Handle request for `GET /v1/notifications`
+func (h *handler) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	opts := repository.NotificationOptions{}
+	var err error
+	if opts.MaxID, err = request.QueryInt64(r, "max_id", 0); err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+	if opts.SinceID, err = request.QueryInt64(r, "since_id", 0); err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+	if opts.Limit, err = request.QueryInt64(r, "limit", DefaultLimit); err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+	if opts.Limit <= 0 {
+		opts.Limit = DefaultLimit
+	}
+	if opts.Limit > MaxLimit {
+		opts.Limit = MaxLimit
+	}
+
+	account := auth.AccountOf(r)
+	notifications, err := h.app.Dao.Notification().FindByAccount(ctx, account.ID, opts)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+
+	// 同じアカウント・ステータスへの通知が続くことが多いので、取得したものを使い回す
+	accounts := make(map[object.AccountID]*object.Account)
+	statusesByID := make(map[object.StatusID]*object.Status)
+	for _, n := range notifications {
+		if n.FromAccountID != nil {
+			a, ok := accounts[*n.FromAccountID]
+			if !ok {
+				if a, err = h.app.Dao.Account().FindByID(ctx, *n.FromAccountID); err != nil {
+					httperror.InternalServerError(w, r, err)
+					return
+				}
+				accounts[*n.FromAccountID] = a
+			}
+			n.Account = a
+		}
+		if n.StatusID != nil {
+			s, ok := statusesByID[*n.StatusID]
+			if !ok {
+				if s, err = h.app.Dao.Status().FindWithAccountByID(ctx, *n.StatusID); err != nil {
+					httperror.InternalServerError(w, r, err)
+					return
+				}
+				statusesByID[*n.StatusID] = s
+			}
+			n.Status = s
+		}
+	}
+
+	attached := make([]*object.Status, 0, len(statusesByID))
+	for _, s := range statusesByID {
+		if s != nil {
+			attached = append(attached, s)
+		}
+	}
+	if err := statuses.Attach(ctx, h.app.Dao, attached, account.ID); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+
+	// フィルターで隠すステータスの通知は返さない
+	filters, err := statuses.Filters(ctx, h.app.Dao, account.ID, object.FilterContextNotifications)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+	hidden := make(map[object.StatusID]bool)
+	for _, s := range attached {
+		results, hide := filters.Match(s)
+		s.Filtered = results
+		hidden[s.ID] = hide
+	}
+	kept := notifications[:0]
+	for _, n := range notifications {
+		if n.Status == nil || !hidden[n.Status.ID] {
+			kept = append(kept, n)
+		}
+	}
+	notifications = kept
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(notifications); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+}
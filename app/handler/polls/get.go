@@ -0,0 +1,56 @@
+package polls
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+	"yatter-backend-go/app/handler/request"
+)
+
+// Handle request for `GET /v1/polls/{id}`
+func (h *handler) Get(w http.ResponseWriter, r *http.Request) {
+	id, err := request.IDOf(r)
+	if err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+
+	poll, err := h.findVisible(r.Context(), id, auth.AccountOf(r))
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+	if poll == nil {
+		httperror.NotFound(w)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(poll); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+}
+
+// 閲覧者が投稿を見られる場合だけ投票を返す。見られない場合は存在しないものとして nil を返す
+func (h *handler) findVisible(ctx context.Context, id object.PollID, viewer *object.Account) (*object.Poll, error) {
+	var viewerID object.AccountID
+	if viewer != nil {
+		viewerID = viewer.ID
+	}
+	poll, err := h.app.Dao.Poll().FindByID(ctx, id, viewerID)
+	if err != nil || poll == nil {
+		return nil, err
+	}
+
+	// 公開範囲に加えて、どちらかがブロックしている場合も見られない
+	status, err := h.app.Dao.Status().FindVisibleByID(ctx, poll.StatusID, viewerID)
+	if err != nil || status == nil {
+		return nil, err
+	}
+	return poll, nil
+}
@@ -0,0 +1,26 @@
+package polls
+
+import (
+	"net/http"
+
+	"yatter-backend-go/app/app"
+	"yatter-backend-go/app/handler/auth"
+
+	"github.com/go-chi/chi"
+)
+
+// Implementation of handler
+type handler struct {
+	app *app.App
+}
+
+// Create Handler for `/v1/polls`
+func NewRouter(app *app.App) http.Handler {
+	r := chi.NewRouter()
+	h := &handler{app: app}
+
+	r.With(auth.OptionalMiddleware(app)).Get("/{id}", h.Get)
+	r.With(auth.Middleware(app)).Post("/{id}/votes", h.Vote)
+
+	return r
+}
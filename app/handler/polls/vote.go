@@ -0,0 +1,95 @@
+package polls
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"yatter-backend-go/app/domain/customerror"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+	"yatter-backend-go/app/handler/request"
+)
+
+// Request body for `POST /v1/polls/{id}/votes`
+type VoteRequest struct {
+	// Indices of the chosen options
+	Choices []int `json:"choices"`
+}
+
+// Handle request for `POST /v1/polls/{id}/votes`
+func (h *handler) Vote(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := request.IDOf(r)
+	if err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+	var req VoteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+
+	account := auth.AccountOf(r)
+	poll, err := h.findVisible(ctx, id, account)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+	if poll == nil {
+		httperror.NotFound(w)
+		return
+	}
+	if err := req.Validate(poll); err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+
+	if err := h.app.Dao.Poll().Vote(ctx, id, account.ID, req.Choices); err != nil {
+		switch {
+		case errors.Is(err, customerror.ErrNotFound):
+			httperror.NotFound(w)
+		case errors.Is(err, customerror.ErrPollExpired), errors.Is(err, customerror.ErrAlreadyVoted):
+			httperror.UnprocessableEntity(w, err)
+		default:
+			httperror.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	poll, err = h.app.Dao.Poll().FindByID(ctx, id, account.ID)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(poll); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+}
+
+func (req *VoteRequest) Validate(poll *object.Poll) error {
+	if len(req.Choices) == 0 {
+		return errors.New("choices is required")
+	}
+	if len(req.Choices) > 1 && !poll.Multiple {
+		return errors.New("poll does not allow multiple choices")
+	}
+	seen := make(map[int]bool, len(req.Choices))
+	for _, c := range req.Choices {
+		if c < 0 || c >= len(poll.Options) {
+			return fmt.Errorf("choice %d is out of range", c)
+		}
+		if seen[c] {
+			return fmt.Errorf("duplicate choice %d", c)
+		}
+		seen[c] = true
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package preferences
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+)
+
+// Request body for `PATCH /v1/preferences`
+//
+// 指定されなかった項目は変更しない
+type UpdateRequest struct {
+	ExpandSpoilers *bool   `json:"reading:expand:spoilers"`
+	ExpandMedia    *string `json:"reading:expand:media"`
+}
+
+// Handle request for `GET /v1/preferences`
+func (h *handler) Get(w http.ResponseWriter, r *http.Request) {
+	account := auth.AccountOf(r)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(account.Preferences()); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+}
+
+// Handle request for `PATCH /v1/preferences`
+func (h *handler) Update(w http.ResponseWriter, r *http.Request) {
+	var req UpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+	if req.ExpandMedia != nil && !object.IsValidExpandMedia(*req.ExpandMedia) {
+		httperror.BadRequest(w, fmt.Errorf("unknown reading:expand:media %q", *req.ExpandMedia))
+		return
+	}
+
+	account := auth.AccountOf(r)
+	prefs := account.Preferences()
+	if req.ExpandSpoilers != nil {
+		prefs.ExpandSpoilers = *req.ExpandSpoilers
+	}
+	if req.ExpandMedia != nil {
+		prefs.ExpandMedia = *req.ExpandMedia
+	}
+
+	if err := h.app.Dao.Account().UpdatePreferences(r.Context(), account.ID, prefs); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(prefs); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+}
@@ -0,0 +1,122 @@
+package reports
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"unicode/utf8"
+
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+	"yatter-backend-go/app/handler/statuses"
+)
+
+const (
+	// コメントの最大文字数
+	MaxCommentLength = 1000
+	// 1 件の通報に含められるステータスの数
+	MaxStatuses = 20
+)
+
+// Request body for `POST /v1/reports`
+type Request struct {
+	// The username of the reported account
+	Username string `json:"username"`
+	// IDs of the reported statuses, which must be posted by the account
+	StatusIDs []object.StatusID `json:"status_ids"`
+	// Why the account is reported. Defaults to other
+	Category string `json:"category"`
+	// Additional details
+	Comment string `json:"comment"`
+}
+
+// Handle request for `POST /v1/reports`
+func (h *handler) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req Request
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+	if req.Username == "" {
+		httperror.UnprocessableEntity(w, errors.New("username is required"))
+		return
+	}
+	if req.Category == "" {
+		req.Category = object.ReportCategoryOther
+	}
+	if !object.IsValidReportCategory(req.Category) {
+		httperror.UnprocessableEntity(w, fmt.Errorf("invalid category: %s", req.Category))
+		return
+	}
+	if utf8.RuneCountInString(req.Comment) > MaxCommentLength {
+		httperror.UnprocessableEntity(w, fmt.Errorf("comment must be at most %d characters", MaxCommentLength))
+		return
+	}
+	if len(req.StatusIDs) > MaxStatuses {
+		httperror.UnprocessableEntity(w, fmt.Errorf("at most %d statuses can be reported", MaxStatuses))
+		return
+	}
+
+	account := auth.AccountOf(r)
+	target, err := h.app.Dao.Account().FindByUsername(ctx, req.Username)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+	if target == nil {
+		httperror.NotFound(w)
+		return
+	}
+	if target.ID == account.ID {
+		httperror.UnprocessableEntity(w, errors.New("cannot report yourself"))
+		return
+	}
+
+	// 見えないステータスの存在を知られないよう、対象外のものはまとめて同じエラーにする
+	for _, id := range req.StatusIDs {
+		status, err := h.app.Dao.Status().FindWithAccountByID(ctx, id)
+		if err != nil {
+			httperror.InternalServerError(w, r, err)
+			return
+		}
+		visible := false
+		if status != nil && status.Account.ID == target.ID {
+			if visible, err = statuses.VisibleTo(ctx, h.app.Dao, status, account); err != nil {
+				httperror.InternalServerError(w, r, err)
+				return
+			}
+		}
+		if !visible {
+			httperror.UnprocessableEntity(w, fmt.Errorf("status %d cannot be reported with this account", id))
+			return
+		}
+	}
+
+	report := &object.Report{
+		AccountID:       &account.ID,
+		TargetAccountID: &target.ID,
+		Category:        req.Category,
+		Comment:         req.Comment,
+		StatusIDs:       req.StatusIDs,
+	}
+	id, err := h.app.Dao.Report().Add(ctx, report)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+	created, err := h.app.Dao.Report().FindByID(ctx, id)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(created); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+}
@@ -0,0 +1,62 @@
+package request
+
+import (
+	"net"
+	"net/http"
+	"strings"
+)
+
+// Replace RemoteAddr with the client IP reported by trusted proxies
+//
+// middleware.RealIP と違い、接続元が trusted に含まれる時だけ X-Forwarded-For / X-Real-IP を使う。
+// X-Forwarded-For は右から辿り、信頼できるプロキシ以外で最初に現れたアドレスをクライアントとみなす
+func RealIP(trusted []*net.IPNet) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if ip := forwardedIP(r, trusted); ip != "" {
+				r.RemoteAddr = ip
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func forwardedIP(r *http.Request, trusted []*net.IPNet) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	if !isTrusted(net.ParseIP(host), trusted) {
+		return ""
+	}
+
+	if xff := r.Header.Get("X-Forwarded-For"); xff != "" {
+		hops := strings.Split(xff, ",")
+		for i := len(hops) - 1; i >= 0; i-- {
+			ip := net.ParseIP(strings.TrimSpace(hops[i]))
+			if ip == nil {
+				// 解釈できない値より先は信用しない
+				break
+			}
+			if i == 0 || !isTrusted(ip, trusted) {
+				return ip.String()
+			}
+		}
+	}
+	if ip := net.ParseIP(strings.TrimSpace(r.Header.Get("X-Real-IP"))); ip != nil {
+		return ip.String()
+	}
+	return ""
+}
+
+func isTrusted(ip net.IP, trusted []*net.IPNet) bool {
+	if ip == nil {
+		return false
+	}
+	for _, n := range trusted {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
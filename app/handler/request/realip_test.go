@@ -0,0 +1,49 @@
+package request
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRealIP(t *testing.T) {
+	_, proxies, _ := net.ParseCIDR("10.0.0.0/8")
+	trusted := []*net.IPNet{proxies}
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		xff        string
+		xrip       string
+		want       string
+	}{
+		{"untrusted peer", "203.0.113.1:1234", "198.51.100.1", "198.51.100.2", "203.0.113.1:1234"},
+		{"trusted peer", "10.0.0.1:1234", "198.51.100.1", "", "198.51.100.1"},
+		{"spoofed leftmost hop", "10.0.0.1:1234", "192.0.2.1, 198.51.100.1, 10.0.0.2", "", "198.51.100.1"},
+		{"all hops trusted", "10.0.0.1:1234", "10.0.0.3, 10.0.0.2", "", "10.0.0.3"},
+		{"x-real-ip", "10.0.0.1:1234", "", "198.51.100.2", "198.51.100.2"},
+		{"no header", "10.0.0.1:1234", "", "", "10.0.0.1:1234"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			h := RealIP(trusted)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r.RemoteAddr
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = tt.remoteAddr
+			if tt.xff != "" {
+				req.Header.Set("X-Forwarded-For", tt.xff)
+			}
+			if tt.xrip != "" {
+				req.Header.Set("X-Real-IP", tt.xrip)
+			}
+			h.ServeHTTP(httptest.NewRecorder(), req)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -2,13 +2,33 @@ package handler
 
 import (
 	"net/http"
-	"time"
 
 	"yatter-backend-go/app/app"
 	"yatter-backend-go/app/handler/accounts"
+	"yatter-backend-go/app/handler/admin"
+	"yatter-backend-go/app/handler/blocks"
+	"yatter-backend-go/app/handler/bookmarks"
+	"yatter-backend-go/app/handler/conversations"
+	"yatter-backend-go/app/handler/exports"
+	"yatter-backend-go/app/handler/filters"
+	"yatter-backend-go/app/handler/follow_requests"
 	"yatter-backend-go/app/handler/health"
+	"yatter-backend-go/app/handler/imports"
+	"yatter-backend-go/app/handler/lists"
+	"yatter-backend-go/app/handler/mutes"
+	"yatter-backend-go/app/handler/notifications"
+	"yatter-backend-go/app/handler/polls"
+	"yatter-backend-go/app/handler/preferences"
+	"yatter-backend-go/app/handler/reports"
+	"yatter-backend-go/app/handler/request"
+	"yatter-backend-go/app/handler/scheduled_statuses"
+	"yatter-backend-go/app/handler/search"
 	"yatter-backend-go/app/handler/statuses"
 	"yatter-backend-go/app/handler/timelines"
+	"yatter-backend-go/app/handler/trends"
+	"yatter-backend-go/app/logger"
+	"yatter-backend-go/app/metrics"
+	"yatter-backend-go/app/tracing"
 
 	"github.com/go-chi/chi"
 	"github.com/go-chi/chi/middleware"
@@ -20,20 +40,45 @@ func NewRouter(app *app.App) http.Handler {
 
 	// A good base middleware stack
 	r.Use(middleware.RequestID)
-	r.Use(middleware.RealIP)
-	r.Use(middleware.Logger)
+	r.Use(tracing.Middleware)
+	// Load で検証済みなのでエラーにはならない
+	trusted, _ := app.Config.Server.TrustedProxyNets()
+	r.Use(request.RealIP(trusted))
+	r.Use(metrics.Middleware(app.Metrics))
+	r.Use(logger.Middleware(app.Logger))
 	r.Use(middleware.Recoverer) // パニックが発生した時に、エラーログを記録する
 	r.Use(newCORS().Handler)
 
 	// Set a timeout value on the request context (ctx), that will signal
 	// through ctx.Done() that the request has timed out and further
 	// processing should be stopped.
-	r.Use(middleware.Timeout(60 * time.Second))
+	r.Use(middleware.Timeout(app.Config.Server.HandlerTimeout))
 
 	r.Mount("/v1/accounts", accounts.NewRouter(app))
-	r.Mount("/v1/health", health.NewRouter())
+	r.Mount("/v1/admin", admin.NewRouter(app))
+	r.Mount("/v1/blocks", blocks.NewRouter(app))
+	r.Mount("/v1/bookmarks", bookmarks.NewRouter(app))
+	r.Mount("/v1/conversations", conversations.NewRouter(app))
+	r.Mount("/v1/exports", exports.NewRouter(app))
+	r.Mount("/v1/follow_requests", follow_requests.NewRouter(app))
+	r.Mount("/v1/health", health.NewRouter(app))
+	r.Mount("/v1/imports", imports.NewRouter(app))
+	r.Mount("/v1/lists", lists.NewRouter(app))
+	r.Mount("/v1/mutes", mutes.NewRouter(app))
+	r.Mount("/v1/notifications", notifications.NewRouter(app))
+	r.Mount("/v1/polls", polls.NewRouter(app))
+	r.Mount("/v1/preferences", preferences.NewRouter(app))
+	r.Mount("/v1/reports", reports.NewRouter(app))
+	r.Mount("/v1/scheduled_statuses", scheduled_statuses.NewRouter(app))
 	r.Mount("/v1/statuses", statuses.NewRouter(app))
 	r.Mount("/v1/timelines", timelines.NewRouter(app))
+	r.Mount("/v1/trends", trends.NewRouter(app))
+	r.Mount("/v2/filters", filters.NewRouter(app))
+	r.Mount("/v2/search", search.NewRouter(app))
+
+	if app.Config.Metrics.Enabled {
+		r.Handle("/metrics", app.Metrics.Handler())
+	}
 
 	return r
 }
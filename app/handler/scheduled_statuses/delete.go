@@ -0,0 +1,34 @@
+package scheduled_statuses
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"yatter-backend-go/app/domain/customerror"
+	"yatter-backend-go/app/handler/httperror"
+)
+
+// Handle request for `DELETE /v1/scheduled_statuses/{id}`
+func (h *handler) Delete(w http.ResponseWriter, r *http.Request) {
+	scheduled := h.findOwn(w, r)
+	if scheduled == nil {
+		return
+	}
+
+	if err := h.app.Dao.ScheduledStatus().Delete(r.Context(), scheduled.ID); err != nil {
+		// 削除する前に投稿された場合
+		if errors.Is(err, customerror.ErrNotFound) {
+			httperror.NotFound(w)
+			return
+		}
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(&struct{}{}); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+}
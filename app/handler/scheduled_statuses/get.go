@@ -0,0 +1,46 @@
+package scheduled_statuses
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+	"yatter-backend-go/app/handler/request"
+)
+
+// Handle request for `GET /v1/scheduled_statuses/{id}`
+func (h *handler) Get(w http.ResponseWriter, r *http.Request) {
+	scheduled := h.findOwn(w, r)
+	if scheduled == nil {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(scheduled); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+}
+
+// 認証中のアカウントの予約を取得する。見つからない場合はエラーレスポンスを書き込んで nil を返す
+// 他のアカウントの予約は存在しないものとして扱う
+func (h *handler) findOwn(w http.ResponseWriter, r *http.Request) *object.ScheduledStatus {
+	id, err := request.IDOf(r)
+	if err != nil {
+		httperror.BadRequest(w, err)
+		return nil
+	}
+
+	scheduled, err := h.app.Dao.ScheduledStatus().FindByID(r.Context(), id)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return nil
+	}
+	if scheduled == nil || scheduled.AccountID != auth.AccountOf(r).ID {
+		httperror.NotFound(w)
+		return nil
+	}
+	return scheduled
+}
@@ -0,0 +1,53 @@
+package scheduled_statuses
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"yatter-backend-go/app/domain/repository"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+	"yatter-backend-go/app/handler/request"
+)
+
+const (
+	DefaultLimit = 20
+	MaxLimit     = 40
+)
+
+// Handle request for `GET /v1/scheduled_statuses`
+func (h *handler) List(w http.ResponseWriter, r *http.Request) {
+	opts := repository.ScheduledStatusOptions{}
+	var err error
+	if opts.MaxID, err = request.QueryInt64(r, "max_id", 0); err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+	if opts.SinceID, err = request.QueryInt64(r, "since_id", 0); err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+	if opts.Limit, err = request.QueryInt64(r, "limit", DefaultLimit); err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+	if opts.Limit <= 0 {
+		opts.Limit = DefaultLimit
+	}
+	if opts.Limit > MaxLimit {
+		opts.Limit = MaxLimit
+	}
+
+	account := auth.AccountOf(r)
+	statuses, err := h.app.Dao.ScheduledStatus().FindByAccount(r.Context(), account.ID, opts)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(statuses); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+}
@@ -0,0 +1,56 @@
+package scheduled_statuses
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/handler/httperror"
+	"yatter-backend-go/app/handler/statuses"
+)
+
+// Request body for `PUT /v1/scheduled_statuses/{id}`
+type UpdateRequest struct {
+	ScheduledAt *time.Time `json:"scheduled_at"`
+}
+
+// Handle request for `PUT /v1/scheduled_statuses/{id}`
+//
+// 変更できるのは投稿予定時刻だけ。内容を変える場合は取り消して予約し直してもらう
+func (h *handler) Update(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req UpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+	if req.ScheduledAt == nil {
+		httperror.BadRequest(w, errors.New("scheduled_at is required"))
+		return
+	}
+	if req.ScheduledAt.Before(time.Now().Add(statuses.MinScheduleAhead)) {
+		httperror.UnprocessableEntity(w, fmt.Errorf("scheduled_at must be at least %s ahead", statuses.MinScheduleAhead))
+		return
+	}
+
+	scheduled := h.findOwn(w, r)
+	if scheduled == nil {
+		return
+	}
+
+	if err := h.app.Dao.ScheduledStatus().UpdateScheduledAt(ctx, scheduled.ID, *req.ScheduledAt); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+	scheduled.ScheduledAt = object.DateTime{Time: *req.ScheduledAt}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(scheduled); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+}
@@ -0,0 +1,26 @@
+package search
+
+import (
+	"net/http"
+
+	"yatter-backend-go/app/app"
+	"yatter-backend-go/app/handler/auth"
+
+	"github.com/go-chi/chi"
+)
+
+// Implementation of handler
+type handler struct {
+	app *app.App
+}
+
+// Create Handler for `/v2/search`
+func NewRouter(app *app.App) http.Handler {
+	r := chi.NewRouter()
+	h := &handler{app: app}
+
+	// ログインしていればフォローしているアカウントの非公開投稿も検索できる
+	r.With(auth.OptionalMiddleware(app)).Get("/", h.Search)
+
+	return r
+}
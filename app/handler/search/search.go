@@ -0,0 +1,107 @@
+package search
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/domain/repository"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+	"yatter-backend-go/app/handler/request"
+)
+
+const (
+	DefaultLimit = 20
+	MaxLimit     = 40
+)
+
+// Types of search results
+const (
+	TypeAccounts = "accounts"
+	TypeStatuses = "statuses"
+	TypeHashtags = "hashtags"
+)
+
+// Response body for `GET /v2/search`
+type Results struct {
+	Accounts []*object.Account `json:"accounts"`
+	Statuses []*object.Status  `json:"statuses"`
+	Hashtags []*object.Tag     `json:"hashtags"`
+}
+
+// Handle request for `GET /v2/search`
+//
+// type を省略した場合はすべての種類を検索する
+func (h *handler) Search(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	q := strings.TrimSpace(r.URL.Query().Get("q"))
+	if q == "" {
+		httperror.BadRequest(w, errors.New("q is required"))
+		return
+	}
+	typ := r.URL.Query().Get("type")
+	switch typ {
+	case "", TypeAccounts, TypeStatuses, TypeHashtags:
+	default:
+		httperror.BadRequest(w, fmt.Errorf("unknown type %q", typ))
+		return
+	}
+
+	limit, err := request.QueryInt64(r, "limit", DefaultLimit)
+	if err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+	if limit == 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+	offset, err := request.QueryInt64(r, "offset", 0)
+	if err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+
+	opts := repository.SearchOptions{Offset: offset, Limit: limit}
+	if account := auth.AccountOf(r); account != nil {
+		opts.ViewerID = account.ID
+	}
+
+	searchRepo := h.app.Dao.Search()
+	results := &Results{
+		Accounts: []*object.Account{},
+		Statuses: []*object.Status{},
+		Hashtags: []*object.Tag{},
+	}
+	if typ == "" || typ == TypeAccounts {
+		if results.Accounts, err = searchRepo.Accounts(ctx, q, opts); err != nil {
+			httperror.InternalServerError(w, r, err)
+			return
+		}
+	}
+	if typ == "" || typ == TypeStatuses {
+		if results.Statuses, err = searchRepo.Statuses(ctx, q, opts); err != nil {
+			httperror.InternalServerError(w, r, err)
+			return
+		}
+	}
+	if typ == "" || typ == TypeHashtags {
+		if results.Hashtags, err = searchRepo.Hashtags(ctx, q, opts); err != nil {
+			httperror.InternalServerError(w, r, err)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(results); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+}
@@ -0,0 +1,32 @@
+package statuses
+
+import (
+	"context"
+
+	"yatter-backend-go/app/dao"
+	"yatter-backend-go/app/domain/object"
+)
+
+// Attach polls and viewer flags (bookmarked, pinned) to statuses
+//
+// viewerID は未認証の場合 0
+func Attach(ctx context.Context, d dao.Dao, statuses []*object.Status, viewerID object.AccountID) error {
+	if err := d.Poll().Attach(ctx, statuses, viewerID); err != nil {
+		return err
+	}
+	return d.Status().SetViewerFlags(ctx, statuses, viewerID)
+}
+
+// Load filters of the viewer applying to filterContext
+//
+// 未認証の場合やフィルターがない場合は nil を返す。nil の *object.FilterSet は何にもマッチしない
+func Filters(ctx context.Context, d dao.Dao, viewerID object.AccountID, filterContext object.FilterContext) (*object.FilterSet, error) {
+	if viewerID == 0 {
+		return nil, nil
+	}
+	filters, err := d.Filter().FindActive(ctx, viewerID, filterContext)
+	if err != nil {
+		return nil, err
+	}
+	return object.CompileFilters(filters)
+}
@@ -0,0 +1,36 @@
+package statuses
+
+import (
+	"net/http"
+
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+)
+
+// Handle request for `POST /v1/statuses/{id}/bookmark`
+func (h *handler) Bookmark(w http.ResponseWriter, r *http.Request) {
+	status := h.findVisible(w, r)
+	if status == nil {
+		return
+	}
+
+	if err := h.app.Dao.Bookmark().Add(r.Context(), auth.AccountOf(r).ID, status.ID); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+	h.writeStatus(w, r, status)
+}
+
+// Handle request for `POST /v1/statuses/{id}/unbookmark`
+func (h *handler) Unbookmark(w http.ResponseWriter, r *http.Request) {
+	status := h.findVisible(w, r)
+	if status == nil {
+		return
+	}
+
+	if err := h.app.Dao.Bookmark().Delete(r.Context(), auth.AccountOf(r).ID, status.ID); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+	h.writeStatus(w, r, status)
+}
@@ -5,6 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"yatter-backend-go/app/domain/object"
 	"yatter-backend-go/app/handler/auth"
@@ -13,10 +16,41 @@ import (
 
 // Request body for `POST /v1/statuses`
 type AddRequest struct {
-	Status   string `json:"status"`
-	MediaIds []int  `json:"media_ids"`
+	Status      string       `json:"status"`
+	MediaIds    []int        `json:"media_ids"`
+	Visibility  string       `json:"visibility"`
+	SpoilerText string       `json:"spoiler_text"`
+	Sensitive   bool         `json:"sensitive"`
+	Poll        *PollRequest `json:"poll"`
+
+	// Publish the status at this time instead of now
+	ScheduledAt *time.Time `json:"scheduled_at"`
+}
+
+// Poll in request body for `POST /v1/statuses`
+type PollRequest struct {
+	// Choices of the poll
+	Options []string `json:"options"`
+
+	// Duration of the poll in seconds
+	ExpiresIn int64 `json:"expires_in"`
+
+	Multiple   bool `json:"multiple"`
+	HideTotals bool `json:"hide_totals"`
 }
 
+const (
+	// Maximum length of spoiler_text in characters
+	MaxSpoilerTextLength = 1024
+
+	// Limits of polls
+	MinPollOptions      = 2
+	MaxPollOptions      = 4
+	MaxPollOptionLength = 50
+	MinPollExpiresIn    = 5 * 60
+	MaxPollExpiresIn    = 30 * 24 * 60 * 60
+)
+
 // Handle request for `POST /v1/statuses`
 func (h *handler) Create(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -31,29 +65,73 @@ func (h *handler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	account := auth.AccountOf(r)
+	decision, err := h.evaluateRules(ctx, account, &req)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+	if decision.Reject {
+		h.recordDecision(ctx, account, &req, decision, nil)
+		httperror.UnprocessableEntity(w, errors.New("status was rejected by the server rules"))
+		return
+	}
+	if decision.Sensitive {
+		req.Sensitive = true
+	}
+
+	if req.ScheduledAt != nil {
+		h.schedule(w, r, &req, decision)
+		return
+	}
+
 	status := new(object.Status)
 	statusRepo := h.app.Dao.Status() // domain/repository の取得
 
 	status.Content = req.Status
+	status.SpoilerText = req.SpoilerText
+	// 警告文が付いている場合は本文も隠すものとして扱う
+	status.Sensitive = req.Sensitive || req.SpoilerText != ""
+	status.Visibility = req.Visibility
+	if status.Visibility == "" {
+		status.Visibility = object.VisibilityPublic
+	}
+
+	if req.Poll != nil {
+		status.Poll = &object.Poll{
+			ExpiresAt:  object.DateTime{Time: time.Now().Add(time.Duration(req.Poll.ExpiresIn) * time.Second)},
+			Multiple:   req.Poll.Multiple,
+			HideTotals: req.Poll.HideTotals,
+		}
+		for _, title := range req.Poll.Options {
+			status.Poll.Options = append(status.Poll.Options, &object.PollOption{Title: strings.TrimSpace(title)})
+		}
+	}
 
 	// account の取得
-	status.Account = auth.AccountOf(r)
+	status.Account = account
 
 	id, err := statusRepo.Add(ctx, status)
 	if err != nil {
-		httperror.InternalServerError(w, err)
+		httperror.InternalServerError(w, r, err)
 		return
 	}
-	addedStatus, _ := statusRepo.FindWithAccountByID(ctx, id)
+	h.app.Metrics.StatusesCreated.Inc()
+	h.recordDecision(ctx, account, &req, decision, &id)
+	addedStatus, err := statusRepo.FindWithAccountByID(ctx, id)
 	if err != nil {
-		httperror.InternalServerError(w, err)
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+	if err := Attach(ctx, h.app.Dao, []*object.Status{addedStatus}, status.Account.ID); err != nil {
+		httperror.InternalServerError(w, r, err)
 		return
 	}
 
 	// Userの情報を返す
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(addedStatus); err != nil {
-		httperror.InternalServerError(w, err)
+		httperror.InternalServerError(w, r, err)
 		return
 	}
 }
@@ -73,9 +151,41 @@ func (req *AddRequest) Validate() error {
 	if req.Status == "" {
 		return errors.New("status is required")
 	}
+	if req.Visibility != "" && !object.IsValidVisibility(req.Visibility) {
+		return fmt.Errorf("unknown visibility %q", req.Visibility)
+	}
+	if utf8.RuneCountInString(req.SpoilerText) > MaxSpoilerTextLength {
+		return fmt.Errorf("spoiler_text must be at most %d characters", MaxSpoilerTextLength)
+	}
+	if req.Poll != nil {
+		if err := req.Poll.Validate(); err != nil {
+			return err
+		}
+	}
 	// bonus
 	// if len(req.MediaIds) == 0 {
 	// 	return errors.New("mediaID is required")
 	// }
 	return nil
 }
+
+func (req *PollRequest) Validate() error {
+	if len(req.Options) < MinPollOptions || len(req.Options) > MaxPollOptions {
+		return fmt.Errorf("poll must have %d to %d options", MinPollOptions, MaxPollOptions)
+	}
+	seen := make(map[string]bool, len(req.Options))
+	for _, o := range req.Options {
+		o = strings.TrimSpace(o)
+		if o == "" || utf8.RuneCountInString(o) > MaxPollOptionLength {
+			return fmt.Errorf("poll options must be 1 to %d characters", MaxPollOptionLength)
+		}
+		if seen[o] {
+			return fmt.Errorf("duplicate poll option %q", o)
+		}
+		seen[o] = true
+	}
+	if req.ExpiresIn < MinPollExpiresIn || req.ExpiresIn > MaxPollExpiresIn {
+		return fmt.Errorf("expires_in must be between %d and %d seconds", MinPollExpiresIn, MaxPollExpiresIn)
+	}
+	return nil
+}
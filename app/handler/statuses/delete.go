@@ -25,7 +25,7 @@ func (h *handler) Delete(w http.ResponseWriter, r *http.Request) {
 	statusRepo := h.app.Dao.Status() // domain/repository の取得
 	status, err := statusRepo.FindWithAccountByID(ctx, id)
 	if err != nil {
-		httperror.InternalServerError(w, err)
+		httperror.InternalServerError(w, r, err)
 	}
 	if status == nil {
 		httperror.NotFound(w)
@@ -41,14 +41,14 @@ func (h *handler) Delete(w http.ResponseWriter, r *http.Request) {
 		if errors.Is(err, customerror.ErrNotFound) {
 			httperror.NotFound(w)
 		} else {
-			httperror.InternalServerError(w, err)
+			httperror.InternalServerError(w, r, err)
 		}
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(&struct{}{}); err != nil {
-		httperror.InternalServerError(w, err)
+		httperror.InternalServerError(w, r, err)
 		return
 	}
 }
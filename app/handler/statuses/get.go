@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/handler/auth"
 	"yatter-backend-go/app/handler/httperror"
 	"yatter-backend-go/app/handler/request"
 )
@@ -18,20 +20,36 @@ func (h *handler) Get(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	var viewerID object.AccountID
+	if viewer := auth.AccountOf(r); viewer != nil {
+		viewerID = viewer.ID
+	}
 	statusRepo := h.app.Dao.Status() // domain/repository の取得
-	status, err := statusRepo.FindWithAccountByID(ctx, id)
+	// 公開範囲とブロックを考慮して取得する。閲覧権限がない投稿は存在しないものとして扱う
+	status, err := statusRepo.FindVisibleByID(ctx, id, viewerID)
 	if err != nil {
-		httperror.InternalServerError(w, err)
+		httperror.InternalServerError(w, r, err)
 		return
 	}
 	if status == nil {
 		httperror.NotFound(w)
 		return
 	}
+	if err := Attach(ctx, h.app.Dao, []*object.Status{status}, viewerID); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+	// 開いたステータス自体は隠さず、マッチしたフィルターを付けるだけにする
+	filters, err := Filters(ctx, h.app.Dao, viewerID, object.FilterContextThread)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+	status.Filtered, _ = filters.Match(status)
 
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(status); err != nil {
-		httperror.InternalServerError(w, err)
+		httperror.InternalServerError(w, r, err)
 		return
 	}
 }
@@ -0,0 +1,65 @@
+package statuses
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"yatter-backend-go/app/domain/customerror"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+)
+
+// プロフィールにピン留めできるステータスの数
+const MaxPins = 5
+
+// Handle request for `POST /v1/statuses/{id}/pin`
+func (h *handler) Pin(w http.ResponseWriter, r *http.Request) {
+	status := h.findOwn(w, r)
+	if status == nil {
+		return
+	}
+	// ダイレクトメッセージはプロフィールに表示できない
+	if status.Visibility == object.VisibilityDirect {
+		httperror.UnprocessableEntity(w, fmt.Errorf("direct statuses cannot be pinned"))
+		return
+	}
+
+	if err := h.app.Dao.Pin().Add(r.Context(), status.Account.ID, status.ID, MaxPins); err != nil {
+		if errors.Is(err, customerror.ErrTooManyPins) {
+			httperror.UnprocessableEntity(w, fmt.Errorf("cannot pin more than %d statuses", MaxPins))
+			return
+		}
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+	h.writeStatus(w, r, status)
+}
+
+// Handle request for `POST /v1/statuses/{id}/unpin`
+func (h *handler) Unpin(w http.ResponseWriter, r *http.Request) {
+	status := h.findOwn(w, r)
+	if status == nil {
+		return
+	}
+
+	if err := h.app.Dao.Pin().Delete(r.Context(), status.Account.ID, status.ID); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+	h.writeStatus(w, r, status)
+}
+
+// 自分のステータスを取得する。他人のステータスはピン留めできない
+func (h *handler) findOwn(w http.ResponseWriter, r *http.Request) *object.Status {
+	status := h.findVisible(w, r)
+	if status == nil {
+		return nil
+	}
+	if status.Account.ID != auth.AccountOf(r).ID {
+		httperror.UnprocessableEntity(w, fmt.Errorf("only own statuses can be pinned"))
+		return nil
+	}
+	return status
+}
@@ -1,10 +1,15 @@
 package statuses
 
 import (
+	"encoding/json"
 	"net/http"
 
 	"yatter-backend-go/app/app"
+	"yatter-backend-go/app/config"
+	"yatter-backend-go/app/domain/object"
 	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+	"yatter-backend-go/app/handler/request"
 
 	"github.com/go-chi/chi"
 )
@@ -23,15 +28,53 @@ func NewRouter(app *app.App) http.Handler {
 	r.Route("/", func(r chi.Router) {
 		// 以下の処理は認証を必要とする
 		r.Use(auth.Middleware(app))
-		r.Post("/", h.Create)
+		r.With(app.RateLimiter.Middleware(config.RateLimitStatusesCreate, auth.ClientKey)).Post("/", h.Create)
 	})
 
 	r.Route("/{id}", func(r chi.Router) {
 		r.Use(auth.Middleware(app))
 		r.Delete("/", h.Delete)
+		r.Post("/bookmark", h.Bookmark)
+		r.Post("/unbookmark", h.Unbookmark)
+		r.Post("/pin", h.Pin)
+		r.Post("/unpin", h.Unpin)
 	})
 
-	r.Get("/{id}", h.Get)
+	r.With(auth.OptionalMiddleware(app)).Get("/{id}", h.Get)
 
 	return r
 }
+
+// 閲覧者が見られるステータスを取得する。見つからない場合はエラーを書き込んで nil を返す
+func (h *handler) findVisible(w http.ResponseWriter, r *http.Request) *object.Status {
+	id, err := request.IDOf(r)
+	if err != nil {
+		httperror.BadRequest(w, err)
+		return nil
+	}
+
+	status, err := h.app.Dao.Status().FindVisibleByID(r.Context(), id, auth.AccountOf(r).ID)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return nil
+	}
+	if status == nil {
+		httperror.NotFound(w)
+		return nil
+	}
+	return status
+}
+
+// 投票と閲覧者から見た状態を付けてステータスを返す
+func (h *handler) writeStatus(w http.ResponseWriter, r *http.Request, status *object.Status) {
+	if err := Attach(r.Context(), h.app.Dao, []*object.Status{status}, auth.AccountOf(r).ID); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(status); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+}
@@ -0,0 +1,72 @@
+package statuses
+
+import (
+	"context"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/logger"
+
+	"go.uber.org/zap"
+)
+
+// 通報のコメントの最大文字数。reports.MaxCommentLength と同じ
+const maxReportCommentLength = 1000
+
+// 管理者が設定したコンテンツルールで、投稿しようとしている内容を調べる
+func (h *handler) evaluateRules(ctx context.Context, account *object.Account, req *AddRequest) (*object.ContentDecision, error) {
+	rules, err := h.app.Dao.ContentRule().FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	set, err := object.CompileContentRules(rules)
+	if err != nil {
+		return nil, err
+	}
+
+	candidate := &object.Status{Account: account, Content: req.Status, SpoilerText: req.SpoilerText}
+	if req.Poll != nil {
+		candidate.Poll = &object.Poll{}
+		for _, title := range req.Poll.Options {
+			candidate.Poll.Options = append(candidate.Poll.Options, &object.PollOption{Title: title})
+		}
+	}
+	return set.Evaluate(candidate, time.Now()), nil
+}
+
+// ルールによる判定を通報としてモデレーションキューに残し、通報の ID を返す
+// 要確認は未対応、拒否と sensitive の強制は対応済みとして残す。
+// 投稿自体は済んでいることがあるので、失敗してもログに残すだけにして nil を返す
+func (h *handler) recordDecision(ctx context.Context, account *object.Account, req *AddRequest, decision *object.ContentDecision, statusID *object.StatusID) *object.ReportID {
+	action := decision.Action()
+	if action == "" {
+		return nil
+	}
+
+	// 拒否されたステータスは残らないので、本文をコメントとして残す
+	comment := req.Status
+	if utf8.RuneCountInString(comment) > maxReportCommentLength {
+		comment = string([]rune(comment)[:maxReportCommentLength])
+	}
+	report := &object.Report{
+		TargetAccountID: &account.ID,
+		Category:        object.ReportCategoryOther,
+		Comment:         strings.TrimSpace(comment),
+		RuleAction:      &action,
+		RuleIDs:         decision.RuleIDs,
+	}
+	if statusID != nil {
+		report.StatusIDs = []object.StatusID{*statusID}
+	}
+	if action != object.ContentRuleActionFlag {
+		report.ActionTakenAt = &object.DateTime{Time: time.Now()}
+	}
+	id, err := h.app.Dao.Report().Add(ctx, report)
+	if err != nil {
+		logger.FromContext(ctx).Warn("content rule decision not recorded", zap.String("action", action), zap.Error(err))
+		return nil
+	}
+	return &id
+}
@@ -0,0 +1,80 @@
+package statuses
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+)
+
+const (
+	// Minimum time between now and scheduled_at
+	MinScheduleAhead = 5 * time.Minute
+
+	// Maximum number of scheduled statuses per account
+	MaxScheduledStatuses = 300
+)
+
+// `POST /v1/statuses` に scheduled_at が指定された場合は、ステータスの代わりに予約を登録する
+// コンテンツルールの判定は予約時に行い、通報は投稿された時にステータスと結び付ける
+func (h *handler) schedule(w http.ResponseWriter, r *http.Request, req *AddRequest, decision *object.ContentDecision) {
+	ctx := r.Context()
+
+	if req.ScheduledAt.Before(time.Now().Add(MinScheduleAhead)) {
+		httperror.UnprocessableEntity(w, fmt.Errorf("scheduled_at must be at least %s ahead", MinScheduleAhead))
+		return
+	}
+
+	account := auth.AccountOf(r)
+	scheduledRepo := h.app.Dao.ScheduledStatus()
+	n, err := scheduledRepo.CountByAccount(ctx, account.ID)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+	if n >= MaxScheduledStatuses {
+		httperror.UnprocessableEntity(w, fmt.Errorf("cannot schedule more than %d statuses", MaxScheduledStatuses))
+		return
+	}
+
+	scheduled := &object.ScheduledStatus{
+		AccountID:   account.ID,
+		ScheduledAt: object.DateTime{Time: *req.ScheduledAt},
+		Params: object.ScheduledStatusParams{
+			Text:        req.Status,
+			Visibility:  req.Visibility,
+			SpoilerText: req.SpoilerText,
+			Sensitive:   req.Sensitive || req.SpoilerText != "",
+		},
+	}
+	if scheduled.Params.Visibility == "" {
+		scheduled.Params.Visibility = object.VisibilityPublic
+	}
+	if req.Poll != nil {
+		scheduled.Params.Poll = &object.ScheduledPoll{
+			ExpiresIn:  req.Poll.ExpiresIn,
+			Multiple:   req.Poll.Multiple,
+			HideTotals: req.Poll.HideTotals,
+		}
+		for _, title := range req.Poll.Options {
+			scheduled.Params.Poll.Options = append(scheduled.Params.Poll.Options, strings.TrimSpace(title))
+		}
+	}
+
+	scheduled.ReportID = h.recordDecision(ctx, account, req, decision, nil)
+	if scheduled.ID, err = scheduledRepo.Add(ctx, scheduled); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(scheduled); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+}
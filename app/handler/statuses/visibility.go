@@ -0,0 +1,35 @@
+package statuses
+
+import (
+	"context"
+
+	"yatter-backend-go/app/dao"
+	"yatter-backend-go/app/domain/object"
+)
+
+// Check whether viewer can see the status
+//
+// viewer は未認証の場合 nil
+func VisibleTo(ctx context.Context, d dao.Dao, status *object.Status, viewer *object.Account) (bool, error) {
+	if viewer != nil && viewer.ID == status.Account.ID {
+		return true, nil
+	}
+
+	switch status.Visibility {
+	case object.VisibilityPublic, object.VisibilityUnlisted:
+		return true, nil
+	case object.VisibilityPrivate:
+		// フォローは承認されたものだけが存在する
+		if viewer == nil {
+			return false, nil
+		}
+		return d.Follow().Exists(ctx, viewer.ID, status.Account.ID)
+	case object.VisibilityDirect:
+		if viewer == nil {
+			return false, nil
+		}
+		return d.Status().IsMentioned(ctx, status.ID, viewer.ID)
+	default:
+		return false, nil
+	}
+}
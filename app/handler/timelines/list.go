@@ -0,0 +1,67 @@
+package timelines
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/domain/repository"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+	"yatter-backend-go/app/handler/request"
+)
+
+// Handle request for `GET /v1/timelines/list/{id}`
+//
+// リストのメンバーの投稿のうち、閲覧者が見られるものを返す
+func (h *handler) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := request.IDOf(r)
+	if err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+	params, err := parse(r)
+	if err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+
+	viewer := auth.AccountOf(r)
+	list, err := h.app.Dao.List().FindByID(ctx, id)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+	// 他のアカウントのリストは存在しないものとして扱う
+	if list == nil || list.AccountID != viewer.ID {
+		httperror.NotFound(w)
+		return
+	}
+
+	opts := repository.TimelineOptions{
+		ViewerID:  viewer.ID,
+		ListID:    list.ID,
+		OnlyMedia: params.OnlyMedia,
+		MaxID:     params.MaxID,
+		SinceID:   params.SinceID,
+		Limit:     params.Limit,
+	}
+	timeline, err := h.app.Dao.Status().FindPublicTimelines(ctx, opts)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+	filtered, err := h.attach(ctx, timeline, opts.ViewerID, object.FilterContextHome)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(filtered); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"net/http"
 
 	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/domain/repository"
+	"yatter-backend-go/app/handler/auth"
 	"yatter-backend-go/app/handler/httperror"
 	"yatter-backend-go/app/handler/request"
 )
@@ -37,16 +39,32 @@ func (h *handler) Public(w http.ResponseWriter, r *http.Request) {
 
 	statusRepo := h.app.Dao.Status() // domain/repository の取得
 
-	timeline, err := statusRepo.FindPublicTimelines(ctx, params.OnlyMedia, params.MaxID, params.SinceID, params.Limit)
+	opts := repository.TimelineOptions{
+		OnlyMedia: params.OnlyMedia,
+		MaxID:     params.MaxID,
+		SinceID:   params.SinceID,
+		Limit:     params.Limit,
+	}
+	// ログインしている場合はブロック・ミュートしているアカウントを除外する
+	if viewer := auth.AccountOf(r); viewer != nil {
+		opts.ViewerID = viewer.ID
+	}
+
+	timeline, err := statusRepo.FindPublicTimelines(ctx, opts)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+	filtered, err := h.attach(ctx, timeline, opts.ViewerID, object.FilterContextPublic)
 	if err != nil {
-		httperror.InternalServerError(w, err)
+		httperror.InternalServerError(w, r, err)
 		return
 	}
 
 	// Userの情報を返す
 	w.Header().Set("Content-Type", "application/json")
-	if err := json.NewEncoder(w).Encode(timeline); err != nil {
-		httperror.InternalServerError(w, err)
+	if err := json.NewEncoder(w).Encode(filtered); err != nil {
+		httperror.InternalServerError(w, r, err)
 		return
 	}
 }
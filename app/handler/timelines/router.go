@@ -1,9 +1,13 @@
 package timelines
 
 import (
+	"context"
 	"net/http"
 
 	"yatter-backend-go/app/app"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/statuses"
 
 	"github.com/go-chi/chi"
 )
@@ -18,7 +22,25 @@ func NewRouter(app *app.App) http.Handler {
 	r := chi.NewRouter()
 	h := &handler{app: app}
 
-	r.Get("/public", h.Public)
+	r.With(auth.OptionalMiddleware(app)).Get("/public", h.Public)
+	r.With(auth.OptionalMiddleware(app)).Get("/tag/{hashtag}", h.Tag)
+	r.With(auth.Middleware(app)).Get("/list/{id}", h.List)
 
 	return r
 }
+
+// タイムラインのステータスに投票と閲覧者から見た状態を付け、閲覧者のフィルターを適用する
+func (h *handler) attach(ctx context.Context, timeline object.Timelines, viewerID object.AccountID, filterContext object.FilterContext) ([]*object.Status, error) {
+	list := make([]*object.Status, 0, len(timeline))
+	for i := range timeline {
+		list = append(list, &timeline[i])
+	}
+	if err := statuses.Attach(ctx, h.app.Dao, list, viewerID); err != nil {
+		return nil, err
+	}
+	filters, err := statuses.Filters(ctx, h.app.Dao, viewerID, filterContext)
+	if err != nil {
+		return nil, err
+	}
+	return filters.Apply(list), nil
+}
@@ -0,0 +1,61 @@
+package timelines
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/domain/repository"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+
+	"github.com/go-chi/chi"
+)
+
+// Handle request for `GET /v1/timelines/tag/{hashtag}`
+//
+// 公開タイムラインのうち、ハッシュタグが付いた投稿だけを返す
+func (h *handler) Tag(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	tag := strings.TrimLeft(chi.URLParam(r, "hashtag"), "#＃")
+	if tag == "" {
+		httperror.BadRequest(w, errors.New("hashtag is required"))
+		return
+	}
+	params, err := parse(r)
+	if err != nil {
+		httperror.BadRequest(w, err)
+		return
+	}
+
+	opts := repository.TimelineOptions{
+		Tag:       tag,
+		OnlyMedia: params.OnlyMedia,
+		MaxID:     params.MaxID,
+		SinceID:   params.SinceID,
+		Limit:     params.Limit,
+	}
+	if viewer := auth.AccountOf(r); viewer != nil {
+		opts.ViewerID = viewer.ID
+	}
+
+	timeline, err := h.app.Dao.Status().FindPublicTimelines(ctx, opts)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+	filtered, err := h.attach(ctx, timeline, opts.ViewerID, object.FilterContextPublic)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(filtered); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+}
@@ -0,0 +1,27 @@
+package trends
+
+import (
+	"net/http"
+
+	"yatter-backend-go/app/app"
+	"yatter-backend-go/app/handler/auth"
+
+	"github.com/go-chi/chi"
+)
+
+// Implementation of handler
+type handler struct {
+	app *app.App
+}
+
+// Create Handler for `/v1/trends`
+func NewRouter(app *app.App) http.Handler {
+	r := chi.NewRouter()
+	h := &handler{app: app}
+
+	r.Get("/tags", h.Tags)
+	// ログインしていればブロック・ミュートしているアカウントの投稿を除く
+	r.With(auth.OptionalMiddleware(app)).Get("/statuses", h.Statuses)
+
+	return r
+}
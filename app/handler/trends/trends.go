@@ -0,0 +1,90 @@
+package trends
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/domain/repository"
+	"yatter-backend-go/app/handler/auth"
+	"yatter-backend-go/app/handler/httperror"
+	"yatter-backend-go/app/handler/request"
+	"yatter-backend-go/app/handler/statuses"
+)
+
+const (
+	DefaultLimit = 10
+	MaxLimit     = 20
+)
+
+// Handle request for `GET /v1/trends/tags`
+func (h *handler) Tags(w http.ResponseWriter, r *http.Request) {
+	limit, offset, ok := pageOf(w, r)
+	if !ok {
+		return
+	}
+
+	tags, err := h.app.Dao.Trend().FindTags(r.Context(), limit, offset)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(tags); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+}
+
+// Handle request for `GET /v1/trends/statuses`
+func (h *handler) Statuses(w http.ResponseWriter, r *http.Request) {
+	limit, offset, ok := pageOf(w, r)
+	if !ok {
+		return
+	}
+
+	opts := repository.TrendOptions{Offset: offset, Limit: limit}
+	if account := auth.AccountOf(r); account != nil {
+		opts.ViewerID = account.ID
+	}
+
+	trending, err := h.app.Dao.Trend().FindStatuses(r.Context(), opts)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+	filters, err := statuses.Filters(r.Context(), h.app.Dao, opts.ViewerID, object.FilterContextPublic)
+	if err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+	trending = filters.Apply(trending)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(trending); err != nil {
+		httperror.InternalServerError(w, r, err)
+		return
+	}
+}
+
+// limit と offset を読む。不正な場合はエラーレスポンスを書き込んで false を返す
+func pageOf(w http.ResponseWriter, r *http.Request) (limit, offset int64, ok bool) {
+	limit, err := request.QueryInt64(r, "limit", DefaultLimit)
+	if err != nil {
+		httperror.BadRequest(w, err)
+		return 0, 0, false
+	}
+	if limit == 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+	offset, err = request.QueryInt64(r, "offset", 0)
+	if err != nil {
+		httperror.BadRequest(w, err)
+		return 0, 0, false
+	}
+	return limit, offset, true
+}
@@ -0,0 +1,101 @@
+package importer
+
+import (
+	"context"
+
+	"yatter-backend-go/app/domain/object"
+)
+
+// 1 行分の関係を作る。取り込めなかった場合は理由を返す
+type applyFunc func(ctx context.Context, account, target *object.Account, row *row) (string, error)
+
+func (im *Importer) applier(t object.ImportType) applyFunc {
+	switch t {
+	case object.ImportTypeBlocks:
+		return im.block
+	case object.ImportTypeMutes:
+		return im.mute
+	default:
+		return im.follow
+	}
+}
+
+// `POST /v1/accounts/{username}/follow` と同じく、鍵アカウントにはフォローリクエストを送る
+func (im *Importer) follow(ctx context.Context, account, target *object.Account, _ *row) (string, error) {
+	for _, pair := range [][2]object.AccountID{{account.ID, target.ID}, {target.ID, account.ID}} {
+		blocked, err := im.dao.Block().Exists(ctx, pair[0], pair[1])
+		if err != nil {
+			return "", err
+		}
+		if blocked {
+			return ReasonBlocked, nil
+		}
+	}
+
+	following, err := im.dao.Follow().Exists(ctx, account.ID, target.ID)
+	if err != nil || following {
+		return "", err
+	}
+	if target.Locked {
+		return "", im.dao.FollowRequest().Add(ctx, account.ID, target.ID)
+	}
+	return "", im.dao.Follow().Add(ctx, account.ID, target.ID)
+}
+
+func (im *Importer) block(ctx context.Context, account, target *object.Account, _ *row) (string, error) {
+	return "", im.dao.Block().Add(ctx, account.ID, target.ID)
+}
+
+func (im *Importer) mute(ctx context.Context, account, target *object.Account, row *row) (string, error) {
+	return "", im.dao.Mute().Add(ctx, &object.Mute{
+		AccountID:         account.ID,
+		TargetAccountID:   target.ID,
+		HideNotifications: row.hideNotifications,
+	})
+}
+
+// overwrite の時に、一覧にない関係やブックマークを外す
+func (im *Importer) removeUnlisted(ctx context.Context, account *object.Account, t object.ImportType, keep map[int64]bool) error {
+	// 読みながら消さずに、対象を集めてから消す
+	var targets []int64
+	collect := func(a *object.Account) error {
+		if !keep[a.ID] {
+			targets = append(targets, a.ID)
+		}
+		return nil
+	}
+
+	var remove func(ctx context.Context, accountID, targetID object.AccountID) error
+	var err error
+	switch t {
+	case object.ImportTypeBookmarks:
+		err = im.dao.Bookmark().EachBookmarked(ctx, account.ID, func(id object.StatusID) error {
+			if !keep[id] {
+				targets = append(targets, id)
+			}
+			return nil
+		})
+		remove = im.dao.Bookmark().Delete
+	case object.ImportTypeBlocks:
+		err = im.dao.Block().EachBlocked(ctx, account.ID, collect)
+		remove = im.dao.Block().Delete
+	case object.ImportTypeMutes:
+		err = im.dao.Mute().EachMuted(ctx, account.ID, func(a *object.Account, _ *object.Mute) error {
+			return collect(a)
+		})
+		remove = im.dao.Mute().Delete
+	default:
+		err = im.dao.Follow().EachFollowing(ctx, account.ID, collect)
+		remove = im.dao.Follow().Delete
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, id := range targets {
+		if err := remove(ctx, account.ID, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
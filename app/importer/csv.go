@@ -0,0 +1,71 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+
+	"yatter-backend-go/app/domain/object"
+)
+
+// Header of the first column in Mastodon exports
+//
+// ブロックのエクスポートにはヘッダーがないので、1 行目がこれの場合だけ読み飛ばす
+const addressHeader = "Account address"
+
+// Row of the CSV
+type row struct {
+	// Row number starting from 1, including the header. Empty lines are not counted
+	line int
+
+	// Account address (`username` or `username@domain`), or status URL for bookmarks
+	address string
+
+	// Second column of mutes (default: true)
+	hideNotifications bool
+
+	// Some column could not be parsed
+	invalid bool
+}
+
+// Read Mastodon-format CSV
+//
+// 1 列目がアカウントのアドレス (ブックマークの場合はステータスの URL) で、
+// ミュートの場合は 2 列目が通知も隠すかどうか。それ以外の列 (Show boosts など) は無視する
+func parse(data []byte, t object.ImportType) ([]*row, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+
+	var rows []*row
+	for line := 1; ; line++ {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		address := strings.TrimSpace(record[0])
+		if address == "" {
+			continue
+		}
+		if line == 1 && strings.EqualFold(address, addressHeader) {
+			continue
+		}
+
+		rw := &row{line: line, address: address, hideNotifications: true}
+		if t == object.ImportTypeMutes && len(record) > 1 && strings.TrimSpace(record[1]) != "" {
+			v, err := strconv.ParseBool(strings.TrimSpace(record[1]))
+			if err != nil {
+				rw.invalid = true
+			}
+			rw.hideNotifications = v
+		}
+		rows = append(rows, rw)
+	}
+	return rows, nil
+}
@@ -0,0 +1,252 @@
+package importer
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"yatter-backend-go/app/dao"
+	"yatter-backend-go/app/domain/object"
+
+	"go.uber.org/zap"
+)
+
+// Number of rows processed between progress updates
+const progressInterval = 100
+
+// Reasons why a row could not be imported
+const (
+	ReasonNotFound      = "account not found"
+	ReasonRemote        = "remote accounts are not supported"
+	ReasonSelf          = "cannot target yourself"
+	ReasonBlocked       = "blocked"
+	ReasonInvalidColumn = "invalid value"
+
+	ReasonInvalidURL     = "not a status URL"
+	ReasonRemoteStatus   = "remote statuses are not supported"
+	ReasonStatusNotFound = "status not found"
+)
+
+// Max length of the value recorded in the error report
+const maxErrorValueLength = 255
+
+// Importer of CSV lists
+//
+// リクエストを受け付けた時点では account_import テーブルに pending として登録するだけで、
+// 取り込みはワーカーが ProcessPending で行う
+type Importer struct {
+	dao    dao.Dao
+	hosts  []string
+	logger *zap.Logger
+}
+
+// Create importer
+//
+// publicURL のホストが付いたアドレス (user@example.com) はローカルのアカウントとして扱う
+func New(dao dao.Dao, publicURL string, logger *zap.Logger) *Importer {
+	var hosts []string
+	if u, err := url.Parse(publicURL); err == nil && u.Host != "" {
+		hosts = append(hosts, u.Host, u.Hostname())
+	}
+	return &Importer{dao: dao, hosts: hosts, logger: logger}
+}
+
+// Process every pending import
+func (im *Importer) ProcessPending(ctx context.Context) error {
+	for ctx.Err() == nil {
+		imp, err := im.dao.Import().ClaimPending(ctx)
+		if err != nil {
+			return err
+		}
+		if imp == nil {
+			return nil
+		}
+
+		l := im.logger.With(zap.Int64("import_id", imp.ID), zap.Int64("account_id", imp.AccountID))
+		if err := im.process(ctx, imp); err != nil {
+			l.Error("failed to import", zap.Error(err))
+			// 停止時に中断した場合も失敗扱いにする。再度アップロードしてもらう
+			imp.State = object.ImportStateFailed
+			if err := im.dao.Import().UpdateProgress(context.Background(), imp); err != nil {
+				return err
+			}
+			continue
+		}
+		l.Info("imported", zap.Int("rows", imp.TotalRows), zap.Int("failed_rows", imp.FailedRows))
+	}
+	return nil
+}
+
+func (im *Importer) process(ctx context.Context, imp *object.Import) error {
+	account, err := im.dao.Account().FindByID(ctx, imp.AccountID)
+	if err != nil {
+		return err
+	}
+	if account == nil {
+		return fmt.Errorf("account %d not found", imp.AccountID)
+	}
+
+	rows, err := parse(imp.Data, imp.Type)
+	if err != nil {
+		return fmt.Errorf("parse csv: %w", err)
+	}
+	imp.Data = nil
+	imp.TotalRows = len(rows)
+	if err := im.dao.Import().UpdateProgress(ctx, imp); err != nil {
+		return err
+	}
+
+	apply := im.applier(imp.Type)
+	// 取り込んだアカウント、ブックマークの場合はステータスの ID
+	keep := make(map[int64]bool, len(rows))
+	var failures []*object.ImportError
+	for _, row := range rows {
+		var reason string
+		if imp.Type == object.ImportTypeBookmarks {
+			reason, err = im.importBookmark(ctx, account, row, keep)
+		} else {
+			reason, err = im.importRow(ctx, account, row, apply, keep)
+		}
+		if err != nil {
+			return err
+		}
+		if reason != "" {
+			value := row.address
+			if len(value) > maxErrorValueLength {
+				value = value[:maxErrorValueLength]
+			}
+			failures = append(failures, &object.ImportError{
+				ImportID: imp.ID,
+				Row:      row.line,
+				Username: value,
+				Reason:   reason,
+			})
+			imp.FailedRows++
+		}
+		imp.ProcessedRows++
+
+		if imp.ProcessedRows%progressInterval == 0 {
+			if err := im.flush(ctx, imp, failures); err != nil {
+				return err
+			}
+			failures = failures[:0]
+		}
+	}
+
+	// 一覧にないものを外すのは、全件取り込んだ後にして関係が一時的に消えないようにする
+	if imp.Mode == object.ImportModeOverwrite {
+		if err := im.removeUnlisted(ctx, account, imp.Type, keep); err != nil {
+			return err
+		}
+	}
+
+	imp.State = object.ImportStateDone
+	return im.flush(ctx, imp, failures)
+}
+
+func (im *Importer) flush(ctx context.Context, imp *object.Import, failures []*object.ImportError) error {
+	if err := im.dao.Import().AddErrors(ctx, failures); err != nil {
+		return err
+	}
+	return im.dao.Import().UpdateProgress(ctx, imp)
+}
+
+// 1 行取り込む。取り込めなかった場合は理由を返す
+func (im *Importer) importRow(ctx context.Context, account *object.Account, row *row, apply applyFunc, keep map[int64]bool) (string, error) {
+	if row.invalid {
+		return ReasonInvalidColumn, nil
+	}
+	username, ok := im.localUsername(row.address)
+	if !ok {
+		return ReasonRemote, nil
+	}
+
+	target, err := im.dao.Account().FindByUsername(ctx, username)
+	if err != nil {
+		return "", err
+	}
+	if target == nil {
+		return ReasonNotFound, nil
+	}
+	if target.ID == account.ID {
+		return ReasonSelf, nil
+	}
+
+	keep[target.ID] = true
+	return apply(ctx, account, target, row)
+}
+
+// ブックマークを 1 行取り込む。閲覧者が見られないステータスは存在しないものとして扱う
+func (im *Importer) importBookmark(ctx context.Context, account *object.Account, row *row, keep map[int64]bool) (string, error) {
+	id, reason := im.localStatusID(row.address)
+	if reason != "" {
+		return reason, nil
+	}
+
+	status, err := im.dao.Status().FindVisibleByID(ctx, id, account.ID)
+	if err != nil {
+		return "", err
+	}
+	if status == nil {
+		return ReasonStatusNotFound, nil
+	}
+
+	keep[status.ID] = true
+	return "", im.dao.Bookmark().Add(ctx, account.ID, status.ID)
+}
+
+// ステータスの URL から ID を取り出す。取り出せない場合は理由を返す
+//
+// 受け付けるのは /v1/statuses/{id} と、Mastodon の /users/{username}/statuses/{id}, /@{username}/{id}
+func (im *Importer) localStatusID(raw string) (object.StatusID, string) {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return 0, ReasonInvalidURL
+	}
+	local := false
+	for _, h := range im.hosts {
+		if strings.EqualFold(u.Host, h) {
+			local = true
+			break
+		}
+	}
+	if !local {
+		return 0, ReasonRemoteStatus
+	}
+
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	var idPart string
+	switch {
+	case len(segments) == 3 && segments[0] == "v1" && segments[1] == "statuses":
+		idPart = segments[2]
+	case len(segments) == 4 && segments[0] == "users" && segments[2] == "statuses":
+		idPart = segments[3]
+	case len(segments) == 2 && strings.HasPrefix(segments[0], "@"):
+		idPart = segments[1]
+	default:
+		return 0, ReasonInvalidURL
+	}
+	id, err := strconv.ParseInt(idPart, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, ReasonInvalidURL
+	}
+	return id, ""
+}
+
+// アドレスからローカルのユーザ名を取り出す。他のサーバーのアカウントなら false を返す
+func (im *Importer) localUsername(address string) (string, bool) {
+	address = strings.TrimPrefix(address, "@")
+	i := strings.Index(address, "@")
+	if i < 0 {
+		return address, true
+	}
+	domain := address[i+1:]
+	for _, h := range im.hosts {
+		if strings.EqualFold(domain, h) {
+			return address[:i], true
+		}
+	}
+	return "", false
+}
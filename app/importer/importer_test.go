@@ -0,0 +1,275 @@
+package importer
+
+import (
+	"context"
+	"testing"
+
+	"yatter-backend-go/app/dao"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/domain/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("following with header", func(t *testing.T) {
+		rows, err := parse([]byte("Account address,Show boosts\nbob,true\n\n@carol@example.com,false\n"), object.ImportTypeFollowing)
+		require.NoError(t, err)
+		require.Len(t, rows, 2)
+		assert.Equal(t, 2, rows[0].line)
+		assert.Equal(t, "bob", rows[0].address)
+		assert.Equal(t, 3, rows[1].line)
+		assert.Equal(t, "@carol@example.com", rows[1].address)
+		assert.False(t, rows[1].invalid)
+	})
+
+	t.Run("blocks without header", func(t *testing.T) {
+		rows, err := parse([]byte("bob\ncarol\n"), object.ImportTypeBlocks)
+		require.NoError(t, err)
+		require.Len(t, rows, 2)
+		assert.Equal(t, 1, rows[0].line)
+	})
+
+	t.Run("mutes", func(t *testing.T) {
+		rows, err := parse([]byte("Account address,Hide notifications\nbob,false\ncarol\ndave,maybe\n"), object.ImportTypeMutes)
+		require.NoError(t, err)
+		require.Len(t, rows, 3)
+		assert.False(t, rows[0].hideNotifications)
+		assert.True(t, rows[1].hideNotifications)
+		assert.True(t, rows[2].invalid)
+	})
+}
+
+func TestImporter_localUsername(t *testing.T) {
+	im := New(nil, "https://example.com:8443", zap.NewNop())
+
+	for address, want := range map[string]string{
+		"bob":                   "bob",
+		"@bob":                  "bob",
+		"bob@example.com":       "bob",
+		"@bob@example.com:8443": "bob",
+		"bob@EXAMPLE.com":       "bob",
+	} {
+		got, ok := im.localUsername(address)
+		assert.True(t, ok, address)
+		assert.Equal(t, want, got, address)
+	}
+
+	_, ok := im.localUsername("bob@other.example")
+	assert.False(t, ok)
+}
+
+// 必要なメソッドだけを実装したスタブ。それ以外を呼ぶと nil の埋め込みで panic する
+type stubDao struct {
+	dao.Dao
+	accounts  map[string]*object.Account
+	following map[object.AccountID]bool
+	requested map[object.AccountID]bool
+	blocked   map[object.AccountID]bool
+	visible   map[object.StatusID]bool
+	bookmarks map[object.StatusID]bool
+	imp       *object.Import
+	errors    []*object.ImportError
+}
+
+func (d *stubDao) Account() repository.Account             { return &stubAccount{d: d} }
+func (d *stubDao) Import() repository.Import               { return &stubImport{d: d} }
+func (d *stubDao) Follow() repository.Follow               { return &stubFollow{d: d} }
+func (d *stubDao) FollowRequest() repository.FollowRequest { return &stubFollowRequest{d: d} }
+func (d *stubDao) Block() repository.Block                 { return &stubBlock{d: d} }
+func (d *stubDao) Status() repository.Status               { return &stubStatus{d: d} }
+func (d *stubDao) Bookmark() repository.Bookmark           { return &stubBookmark{d: d} }
+
+type stubAccount struct {
+	repository.Account
+	d *stubDao
+}
+
+func (s *stubAccount) FindByID(_ context.Context, id object.AccountID) (*object.Account, error) {
+	for _, a := range s.d.accounts {
+		if a.ID == id {
+			return a, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *stubAccount) FindByUsername(_ context.Context, username string) (*object.Account, error) {
+	return s.d.accounts[username], nil
+}
+
+type stubImport struct {
+	repository.Import
+	d *stubDao
+}
+
+func (s *stubImport) UpdateProgress(_ context.Context, imp *object.Import) error {
+	copied := *imp
+	s.d.imp = &copied
+	return nil
+}
+
+func (s *stubImport) AddErrors(_ context.Context, errs []*object.ImportError) error {
+	s.d.errors = append(s.d.errors, errs...)
+	return nil
+}
+
+type stubFollow struct {
+	repository.Follow
+	d *stubDao
+}
+
+func (s *stubFollow) Exists(_ context.Context, _, targetID object.AccountID) (bool, error) {
+	return s.d.following[targetID], nil
+}
+
+func (s *stubFollow) Add(_ context.Context, _, targetID object.AccountID) error {
+	s.d.following[targetID] = true
+	return nil
+}
+
+func (s *stubFollow) Delete(_ context.Context, _, targetID object.AccountID) error {
+	delete(s.d.following, targetID)
+	return nil
+}
+
+func (s *stubFollow) EachFollowing(_ context.Context, _ object.AccountID, fn func(*object.Account) error) error {
+	for id := range s.d.following {
+		if err := fn(&object.Account{ID: id}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type stubFollowRequest struct {
+	repository.FollowRequest
+	d *stubDao
+}
+
+func (s *stubFollowRequest) Add(_ context.Context, _, targetID object.AccountID) error {
+	s.d.requested[targetID] = true
+	return nil
+}
+
+type stubBlock struct {
+	repository.Block
+	d *stubDao
+}
+
+func (s *stubBlock) Exists(_ context.Context, accountID, targetID object.AccountID) (bool, error) {
+	return s.d.blocked[accountID] || s.d.blocked[targetID], nil
+}
+
+type stubStatus struct {
+	repository.Status
+	d *stubDao
+}
+
+func (s *stubStatus) FindVisibleByID(_ context.Context, id object.StatusID, _ object.AccountID) (*object.Status, error) {
+	if !s.d.visible[id] {
+		return nil, nil
+	}
+	return &object.Status{ID: id}, nil
+}
+
+type stubBookmark struct {
+	repository.Bookmark
+	d *stubDao
+}
+
+func (s *stubBookmark) Add(_ context.Context, _ object.AccountID, statusID object.StatusID) error {
+	s.d.bookmarks[statusID] = true
+	return nil
+}
+
+func (s *stubBookmark) Delete(_ context.Context, _ object.AccountID, statusID object.StatusID) error {
+	delete(s.d.bookmarks, statusID)
+	return nil
+}
+
+func (s *stubBookmark) EachBookmarked(_ context.Context, _ object.AccountID, fn func(object.StatusID) error) error {
+	for id := range s.d.bookmarks {
+		if err := fn(id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestImporter_process(t *testing.T) {
+	d := &stubDao{
+		accounts: map[string]*object.Account{
+			"alice":  {ID: 1, Username: "alice"},
+			"bob":    {ID: 2, Username: "bob"},
+			"carol":  {ID: 3, Username: "carol", Locked: true},
+			"dave":   {ID: 4, Username: "dave"},
+			"mallet": {ID: 5, Username: "mallet"},
+		},
+		// dave は一覧にないので overwrite で外れる
+		following: map[object.AccountID]bool{4: true},
+		requested: map[object.AccountID]bool{},
+		blocked:   map[object.AccountID]bool{5: true},
+	}
+	im := New(d, "https://example.com", zap.NewNop())
+
+	imp := &object.Import{
+		ID:        10,
+		AccountID: 1,
+		Type:      object.ImportTypeFollowing,
+		Mode:      object.ImportModeOverwrite,
+		Data:      []byte("Account address,Show boosts\nbob,true\ncarol,true\nghost,true\nalice,true\nmallet,true\nerin@other.example,true\n"),
+	}
+	require.NoError(t, im.process(context.Background(), imp))
+
+	assert.Equal(t, map[object.AccountID]bool{2: true}, d.following)
+	assert.Equal(t, map[object.AccountID]bool{3: true}, d.requested)
+
+	assert.Equal(t, object.ImportStateDone, d.imp.State)
+	assert.Equal(t, 6, d.imp.TotalRows)
+	assert.Equal(t, 6, d.imp.ProcessedRows)
+	assert.Equal(t, 4, d.imp.FailedRows)
+
+	require.Len(t, d.errors, 4)
+	assert.Equal(t, &object.ImportError{ImportID: 10, Row: 4, Username: "ghost", Reason: ReasonNotFound}, d.errors[0])
+	assert.Equal(t, ReasonSelf, d.errors[1].Reason)
+	assert.Equal(t, ReasonBlocked, d.errors[2].Reason)
+	assert.Equal(t, ReasonRemote, d.errors[3].Reason)
+}
+
+func TestImporter_process_bookmarks(t *testing.T) {
+	d := &stubDao{
+		accounts: map[string]*object.Account{"alice": {ID: 1, Username: "alice"}},
+		// 30 は見られない投稿
+		visible: map[object.StatusID]bool{10: true, 20: true},
+		// 40 は一覧にないので overwrite で外れる
+		bookmarks: map[object.StatusID]bool{40: true},
+	}
+	im := New(d, "https://example.com", zap.NewNop())
+
+	imp := &object.Import{
+		ID:        11,
+		AccountID: 1,
+		Type:      object.ImportTypeBookmarks,
+		Mode:      object.ImportModeOverwrite,
+		Data: []byte("https://example.com/v1/statuses/10\n" +
+			"https://example.com/users/bob/statuses/20\n" +
+			"https://example.com/@bob/30\n" +
+			"https://other.example/users/erin/statuses/1\n" +
+			"https://example.com/about\n"),
+	}
+	require.NoError(t, im.process(context.Background(), imp))
+
+	assert.Equal(t, map[object.StatusID]bool{10: true, 20: true}, d.bookmarks)
+
+	assert.Equal(t, object.ImportStateDone, d.imp.State)
+	assert.Equal(t, 5, d.imp.TotalRows)
+	assert.Equal(t, 3, d.imp.FailedRows)
+
+	require.Len(t, d.errors, 3)
+	assert.Equal(t, &object.ImportError{ImportID: 11, Row: 3, Username: "https://example.com/@bob/30", Reason: ReasonStatusNotFound}, d.errors[0])
+	assert.Equal(t, ReasonRemoteStatus, d.errors[1].Reason)
+	assert.Equal(t, ReasonInvalidURL, d.errors[2].Reason)
+}
@@ -0,0 +1,55 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+
+	"yatter-backend-go/app/config"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+var contextKey = new(struct{})
+
+// Create structured logger
+func New(cfg config.Log) (*zap.Logger, error) {
+	level, err := zapcore.ParseLevel(cfg.Level)
+	if err != nil {
+		return nil, fmt.Errorf("logger: %w", err)
+	}
+
+	zcfg := zap.NewProductionConfig()
+	zcfg.Level = zap.NewAtomicLevelAt(level)
+	zcfg.Encoding = cfg.Format
+	zcfg.EncoderConfig.TimeKey = "time"
+	zcfg.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+	// スタックトレースは pkg/errors で取得したものを errorVerbose に出す
+	zcfg.DisableStacktrace = true
+	if cfg.Format == config.LogFormatConsole {
+		zcfg.EncoderConfig.EncodeLevel = zapcore.CapitalLevelEncoder
+	}
+
+	return zcfg.Build()
+}
+
+// Return copy of ctx holding the logger
+func WithContext(ctx context.Context, l *zap.Logger) context.Context {
+	return context.WithValue(ctx, contextKey, l)
+}
+
+// Read logger from ctx
+//
+// リクエストのコンテキストから取り出した logger には request_id などが付いている。
+// 見つからない場合はグローバルな logger を返す
+func FromContext(ctx context.Context) *zap.Logger {
+	if l, ok := ctx.Value(contextKey).(*zap.Logger); ok {
+		return l
+	}
+	return zap.L()
+}
+
+// Add fields to the logger held by ctx
+func With(ctx context.Context, fields ...zap.Field) context.Context {
+	return WithContext(ctx, FromContext(ctx).With(fields...))
+}
@@ -0,0 +1,86 @@
+package logger
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Attach request-scoped logger and write access log
+//
+// 以降のハンドラでは FromContext(r.Context()) で request_id と route 付きの
+// logger を取得できる。middleware.Logger の代わりに使う
+func Middleware(base *zap.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			rctx := chi.RouteContext(ctx)
+			fields := []zap.Field{
+				zap.String("request_id", middleware.GetReqID(ctx)),
+			}
+			if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+				fields = append(fields, zap.String("trace_id", sc.TraceID().String()))
+			}
+			l := base.With(fields...)
+
+			start := time.Now()
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			// ルートパターンはルーティング後に確定する。エンコーダは With のフィールドを
+			// その場でシリアライズするので、ハンドラ向けの logger には書き込み時に付ける
+			hl := l.WithOptions(zap.WrapCore(func(c zapcore.Core) zapcore.Core {
+				return &routeCore{Core: c, rctx: rctx}
+			}))
+			next.ServeHTTP(ww, r.WithContext(WithContext(ctx, hl)))
+
+			code := ww.Status()
+			if code == 0 {
+				code = http.StatusOK
+			}
+			// 認証後に account_id が付いた logger を使いたいが、コンテキストは戻ってこないので
+			// アクセスログには request_id で紐付ける
+			l.Info("request",
+				zap.String("method", r.Method),
+				zap.String("path", r.URL.Path),
+				zap.String("route", routePattern(rctx)),
+				zap.String("remote_addr", r.RemoteAddr),
+				zap.Int("status", code),
+				zap.Int("bytes", ww.BytesWritten()),
+				zap.Duration("latency", time.Since(start)),
+			)
+		})
+	}
+}
+
+// zapcore.Core adding chi route pattern on each write
+type routeCore struct {
+	zapcore.Core
+	rctx *chi.Context
+}
+
+func (c *routeCore) With(fields []zapcore.Field) zapcore.Core {
+	return &routeCore{Core: c.Core.With(fields), rctx: c.rctx}
+}
+
+func (c *routeCore) Check(e zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(e.Level) {
+		return ce.AddCore(e, c)
+	}
+	return ce
+}
+
+func (c *routeCore) Write(e zapcore.Entry, fields []zapcore.Field) error {
+	return c.Core.Write(e, append(fields, zap.String("route", routePattern(c.rctx))))
+}
+
+func routePattern(rctx *chi.Context) string {
+	if rctx == nil {
+		return ""
+	}
+	return rctx.RoutePattern()
+}
@@ -0,0 +1,50 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestMiddleware(t *testing.T) {
+	// 本番と同じく With のフィールドを即時にシリアライズするエンコーダで確認する
+	var buf bytes.Buffer
+	core := zapcore.NewCore(
+		zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()),
+		zapcore.AddSync(&buf),
+		zap.InfoLevel,
+	)
+
+	r := chi.NewRouter()
+	r.Use(middleware.RequestID)
+	r.Use(Middleware(zap.New(core)))
+	r.Get("/v1/statuses/{id}", func(w http.ResponseWriter, r *http.Request) {
+		FromContext(r.Context()).Info("in handler")
+	})
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/statuses/1", nil))
+
+	var entries []map[string]interface{}
+	dec := json.NewDecoder(&buf)
+	for dec.More() {
+		var e map[string]interface{}
+		require.NoError(t, dec.Decode(&e))
+		entries = append(entries, e)
+	}
+	require.Len(t, entries, 2)
+	for _, e := range entries {
+		assert.NotEmpty(t, e["request_id"])
+		assert.Equal(t, "/v1/statuses/{id}", e["route"])
+	}
+	assert.Equal(t, "in handler", entries[0]["msg"])
+	assert.Equal(t, float64(http.StatusOK), entries[1]["status"])
+}
@@ -0,0 +1,90 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "yatter"
+
+// Prometheus collectors of the application
+//
+// テストで App を何度も作れるように、グローバルなレジストリは使わない
+type Metrics struct {
+	registry *prometheus.Registry
+
+	// Number of HTTP requests by route pattern, method and status code
+	HTTPRequests *prometheus.CounterVec
+
+	// Latency of HTTP requests by route pattern and method
+	HTTPDuration *prometheus.HistogramVec
+
+	// Number of statuses created
+	StatusesCreated prometheus.Counter
+
+	// Number of accounts registered
+	AccountsRegistered prometheus.Counter
+
+	// Number of requests rejected by auth.Middleware by reason
+	AuthFailures *prometheus.CounterVec
+}
+
+// Create metrics with a new registry
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		HTTPRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "requests_total",
+			Help:      "Number of HTTP requests.",
+		}, []string{"route", "method", "code"}),
+		HTTPDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "Latency of HTTP requests.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"route", "method"}),
+		StatusesCreated: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "statuses_created_total",
+			Help:      "Number of statuses created.",
+		}),
+		AccountsRegistered: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "accounts_registered_total",
+			Help:      "Number of accounts registered.",
+		}),
+		AuthFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "auth_failures_total",
+			Help:      "Number of requests rejected by authentication.",
+		}, []string{"reason"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.HTTPRequests,
+		m.HTTPDuration,
+		m.StatusesCreated,
+		m.AccountsRegistered,
+		m.AuthFailures,
+	)
+	return m
+}
+
+// Register connection pool stats of the database
+func (m *Metrics) RegisterDB(name string, stats func() sql.DBStats) {
+	m.registry.MustRegister(newDBStatsCollector(name, stats))
+}
+
+// Handler for `GET /metrics`
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
@@ -0,0 +1,43 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+)
+
+// Label for requests which did not match any route
+const unmatchedRoute = "unmatched"
+
+// Record count and latency of each request
+//
+// ラベルには生のパスではなく chi のルートパターン (`/v1/statuses/{id}`) を使い、
+// 系列数が増え続けないようにする
+func Middleware(m *Metrics) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+
+			next.ServeHTTP(ww, r)
+
+			// ルーティングが終わった後でないとパターンは確定しない
+			route := unmatchedRoute
+			if rctx := chi.RouteContext(r.Context()); rctx != nil {
+				if pattern := rctx.RoutePattern(); pattern != "" {
+					route = pattern
+				}
+			}
+			code := ww.Status()
+			if code == 0 {
+				code = http.StatusOK
+			}
+
+			m.HTTPRequests.WithLabelValues(route, r.Method, strconv.Itoa(code)).Inc()
+			m.HTTPDuration.WithLabelValues(route, r.Method).Observe(time.Since(start).Seconds())
+		})
+	}
+}
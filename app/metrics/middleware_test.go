@@ -0,0 +1,31 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiddleware(t *testing.T) {
+	m := New()
+
+	r := chi.NewRouter()
+	r.Use(Middleware(m))
+	r.Route("/v1/statuses", func(r chi.Router) {
+		r.Get("/{id}", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		})
+	})
+
+	for _, path := range []string{"/v1/statuses/1", "/v1/statuses/2", "/nowhere"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	// 生のパスではなくルートパターンで集計される
+	assert.Equal(t, float64(2), testutil.ToFloat64(m.HTTPRequests.WithLabelValues("/v1/statuses/{id}", http.MethodGet, "404")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.HTTPRequests.WithLabelValues(unmatchedRoute, http.MethodGet, "404")))
+}
@@ -0,0 +1,65 @@
+package ratelimit
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"yatter-backend-go/app/config"
+	"yatter-backend-go/app/handler/httperror"
+	"yatter-backend-go/app/logger"
+
+	"go.uber.org/zap"
+)
+
+// Extract the client a request is counted for
+type KeyFunc func(r *http.Request) string
+
+// Apply rate limit policies to requests
+type Limiter struct {
+	cfg   config.RateLimit
+	store Store
+}
+
+// Create limiter
+func New(cfg config.RateLimit, store Store) *Limiter {
+	return &Limiter{cfg: cfg, store: store}
+}
+
+// Limit requests by the named policy
+//
+// 設定に存在しないポリシーや、レート制限が無効な場合は何もしない
+func (l *Limiter) Middleware(policy string, key KeyFunc) func(http.Handler) http.Handler {
+	p, ok := l.cfg.Policies[policy]
+	if !l.cfg.Enabled || !ok {
+		return func(next http.Handler) http.Handler { return next }
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			res, err := l.store.Take(ctx, policy+":"+key(r), p.Limit, p.Window)
+			if err != nil {
+				// ストアの障害でサービス全体を止めないように、制限せずに通す
+				logger.FromContext(ctx).Warn("rate limit store failed", zap.String("policy", policy), zap.Error(err))
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			h := w.Header()
+			h.Set("X-RateLimit-Limit", strconv.Itoa(res.Limit))
+			h.Set("X-RateLimit-Remaining", strconv.Itoa(res.Remaining))
+			h.Set("X-RateLimit-Reset", strconv.FormatInt(res.Reset.Unix(), 10))
+
+			if !res.Allowed {
+				retryAfter := int(math.Ceil(time.Until(res.Reset).Seconds()))
+				h.Set("Retry-After", strconv.Itoa(retryAfter))
+				httperror.Error(w, http.StatusTooManyRequests)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
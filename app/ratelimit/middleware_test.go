@@ -0,0 +1,69 @@
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"yatter-backend-go/app/config"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimiter_Middleware(t *testing.T) {
+	cfg := config.RateLimit{
+		Enabled: true,
+		Policies: map[string]config.RateLimitPolicy{
+			"test": {Limit: 2, Window: time.Minute},
+		},
+	}
+	key := func(r *http.Request) string { return r.Header.Get("X-Client") }
+	h := New(cfg, NewMemoryStore()).Middleware("test", key)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	do := func(client string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		req.Header.Set("X-Client", client)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		return w
+	}
+
+	w := do("a")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "2", w.Header().Get("X-RateLimit-Limit"))
+	assert.Equal(t, "1", w.Header().Get("X-RateLimit-Remaining"))
+	assert.NotEmpty(t, w.Header().Get("X-RateLimit-Reset"))
+
+	assert.Equal(t, http.StatusOK, do("a").Code)
+
+	w = do("a")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "0", w.Header().Get("X-RateLimit-Remaining"))
+	assert.NotEmpty(t, w.Header().Get("Retry-After"))
+
+	// クライアントごとに数える
+	assert.Equal(t, http.StatusOK, do("b").Code)
+}
+
+func TestMemoryStore(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	s := NewMemoryStore()
+	s.now = func() time.Time { return now }
+
+	res, _ := s.Take(ctx, "k", 1, time.Minute)
+	assert.True(t, res.Allowed)
+	res, _ = s.Take(ctx, "k", 1, time.Minute)
+	assert.False(t, res.Allowed)
+
+	// ウィンドウが過ぎたらリセットされる
+	now = now.Add(time.Minute)
+	res, _ = s.Take(ctx, "k", 1, time.Minute)
+	assert.True(t, res.Allowed)
+
+	now = now.Add(time.Minute)
+	assert.NoError(t, s.Cleanup(ctx))
+	assert.Len(t, s.counters, 0)
+}
@@ -0,0 +1,93 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Counter state after taking a request
+type Result struct {
+	// Whether the request is allowed
+	Allowed bool
+
+	// Maximum number of requests in the window
+	Limit int
+
+	// Number of requests left in the window
+	Remaining int
+
+	// Time the window resets
+	Reset time.Time
+}
+
+// Storage of request counters
+//
+// 固定ウィンドウのカウンタなので、Redis なら INCR と PEXPIRE で同じように実装できる
+type Store interface {
+	// Count a request of key and report whether it is within limit
+	Take(ctx context.Context, key string, limit int, window time.Duration) (Result, error)
+}
+
+// In-memory implementation of Store
+//
+// 単一プロセスでしか共有されないので、複数台で動かす場合は外部ストアを使うこと
+type MemoryStore struct {
+	mu       sync.Mutex
+	counters map[string]*counter
+	now      func() time.Time
+}
+
+type counter struct {
+	count int
+	reset time.Time
+}
+
+// Create in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		counters: make(map[string]*counter),
+		now:      time.Now,
+	}
+}
+
+// Store
+func (s *MemoryStore) Take(_ context.Context, key string, limit int, window time.Duration) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	c, ok := s.counters[key]
+	if !ok || !now.Before(c.reset) {
+		c = &counter{reset: now.Add(window)}
+		s.counters[key] = c
+	}
+	c.count++
+
+	remaining := limit - c.count
+	if remaining < 0 {
+		remaining = 0
+	}
+	return Result{
+		Allowed:   c.count <= limit,
+		Limit:     limit,
+		Remaining: remaining,
+		Reset:     c.reset,
+	}, nil
+}
+
+// Remove expired counters
+//
+// 定期的に呼ばないとアクセスのあったキーの数だけメモリを使い続ける
+func (s *MemoryStore) Cleanup(_ context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	for key, c := range s.counters {
+		if !now.Before(c.reset) {
+			delete(s.counters, key)
+		}
+	}
+	return nil
+}
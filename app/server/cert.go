@@ -0,0 +1,114 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Keep TLS certificate up to date with the files on disk
+//
+// 証明書の更新 (Let's Encrypt など) でサーバーを再起動しなくて済むように、
+// 定期的にファイルの更新日時を確認し、SIGHUP を受け取った時にも読み直す。
+type certReloader struct {
+	certFile string
+	keyFile  string
+	logger   *zap.Logger
+
+	mu      sync.RWMutex
+	cert    *tls.Certificate
+	modTime time.Time
+}
+
+func newCertReloader(certFile, keyFile string, logger *zap.Logger) (*certReloader, error) {
+	r := &certReloader{certFile: certFile, keyFile: keyFile, logger: logger}
+	if err := r.reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// tls.Config.GetCertificate
+func (r *certReloader) getCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cert, nil
+}
+
+func (r *certReloader) reload() error {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("load TLS key pair: %w", err)
+	}
+	modTime, err := r.latestModTime()
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cert = &cert
+	r.modTime = modTime
+	return nil
+}
+
+func (r *certReloader) latestModTime() (time.Time, error) {
+	var latest time.Time
+	for _, path := range []string{r.certFile, r.keyFile} {
+		info, err := os.Stat(path)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("stat %s: %w", path, err)
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest, nil
+}
+
+func (r *certReloader) changed() bool {
+	modTime, err := r.latestModTime()
+	if err != nil {
+		// 更新途中でファイルが一時的に存在しない場合があるので、次の確認を待つ
+		return false
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return modTime.After(r.modTime)
+}
+
+func (r *certReloader) watch(ctx context.Context, interval time.Duration) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+		case <-ticker.C:
+			if !r.changed() {
+				continue
+			}
+		}
+
+		// 失敗した場合は古い証明書を使い続ける
+		if err := r.reload(); err != nil {
+			r.logger.Error("reload TLS certificate failed", zap.Error(err))
+			continue
+		}
+		r.logger.Info("TLS certificate reloaded")
+	}
+}
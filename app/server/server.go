@@ -0,0 +1,74 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"yatter-backend-go/app/config"
+
+	"go.uber.org/zap"
+)
+
+// Serve handler until ctx is canceled, then shut down gracefully
+//
+// ctx がキャンセルされると新規の接続の受け付けをやめ、処理中のリクエストが
+// 終わるまで最大 ShutdownTimeout だけ待つ。
+func Run(ctx context.Context, cfg config.Server, handler http.Handler, logger *zap.Logger) error {
+	srv := &http.Server{
+		Addr:              ":" + strconv.Itoa(cfg.Port),
+		Handler:           handler,
+		ReadTimeout:       cfg.ReadTimeout,
+		ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+		WriteTimeout:      cfg.WriteTimeout,
+		IdleTimeout:       cfg.IdleTimeout,
+		ErrorLog:          zap.NewStdLog(logger.Named("http")),
+	}
+
+	if cfg.TLS.Enabled() {
+		reloader, err := newCertReloader(cfg.TLS.CertFile, cfg.TLS.KeyFile, logger)
+		if err != nil {
+			return err
+		}
+		go reloader.watch(ctx, cfg.TLS.ReloadInterval)
+
+		srv.TLSConfig = &tls.Config{
+			MinVersion:     tls.VersionTLS12,
+			GetCertificate: reloader.getCertificate,
+		}
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		var err error
+		if srv.TLSConfig != nil {
+			logger.Info("serve", zap.String("addr", "https://"+srv.Addr))
+			// 証明書は TLSConfig.GetCertificate から取得する
+			err = srv.ListenAndServeTLS("", "")
+		} else {
+			logger.Info("serve", zap.String("addr", "http://"+srv.Addr))
+			err = srv.ListenAndServe()
+		}
+		errCh <- err
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	logger.Info("shutting down", zap.Duration("timeout", cfg.ShutdownTimeout))
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	if err := <-errCh; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
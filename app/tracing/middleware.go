@@ -0,0 +1,59 @@
+package tracing
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	serverName = "yatter-backend-go"
+
+	// Key of request id attribute
+	requestIDKey = attribute.Key("http.request_id")
+)
+
+var tracer = otel.Tracer("yatter-backend-go/app/tracing")
+
+// Start server span for each request
+//
+// 受け取った traceparent ヘッダーを引き継ぎ、span 名はルーティング後に
+// chi のルートパターン (`GET /v1/statuses/{id}`) で付け直す
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		ctx, span := tracer.Start(ctx, r.Method,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(semconv.HTTPServerAttributesFromHTTPRequest(serverName, "", r)...),
+			trace.WithAttributes(semconv.NetAttributesFromHTTPRequest("tcp", r)...),
+		)
+		defer span.End()
+
+		if id := middleware.GetReqID(ctx); id != "" {
+			span.SetAttributes(requestIDKey.String(id))
+		}
+
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		next.ServeHTTP(ww, r.WithContext(ctx))
+
+		if rctx := chi.RouteContext(r.Context()); rctx != nil {
+			if route := rctx.RoutePattern(); route != "" {
+				span.SetName(r.Method + " " + route)
+				span.SetAttributes(semconv.HTTPRouteKey.String(route))
+			}
+		}
+
+		code := ww.Status()
+		if code == 0 {
+			code = http.StatusOK
+		}
+		span.SetAttributes(semconv.HTTPAttributesFromHTTPStatusCode(code)...)
+		span.SetStatus(semconv.SpanStatusFromHTTPStatusCodeAndSpanKind(code, trace.SpanKindServer))
+	})
+}
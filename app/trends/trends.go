@@ -0,0 +1,91 @@
+package trends
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"yatter-backend-go/app/config"
+	"yatter-backend-go/app/dao"
+	"yatter-backend-go/app/domain/object"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// Window of uses taken into account
+	window = 24 * time.Hour
+
+	// Number of days to compute the usual usage of a hashtag from
+	baselineDays = 7
+
+	// Maximum number of hashtags and statuses kept in the rankings
+	maxTrends = 100
+)
+
+// Calculator of trending hashtags and statuses
+//
+// ランキングはワーカーが Refresh で定期的に計算し直し、
+// API は trend_tag / trend_status テーブルを読むだけにする
+type Calculator struct {
+	dao    dao.Dao
+	cfg    config.Trends
+	logger *zap.Logger
+}
+
+// Create calculator
+func New(dao dao.Dao, cfg config.Trends, logger *zap.Logger) *Calculator {
+	return &Calculator{dao: dao, cfg: cfg, logger: logger}
+}
+
+// Recompute the rankings
+func (c *Calculator) Refresh(ctx context.Context) error {
+	now := time.Now()
+	since := now.Add(-window)
+
+	usages, err := c.dao.Trend().TagUsages(ctx, since, c.cfg.HalfLife)
+	if err != nil {
+		return err
+	}
+	baselines, err := c.dao.Trend().TagBaselines(ctx, since.Add(-baselineDays*window), since)
+	if err != nil {
+		return err
+	}
+
+	trends := rank(usages, baselines, c.cfg.MinAccounts)
+	if err := c.dao.Trend().ReplaceTags(ctx, trends); err != nil {
+		return err
+	}
+	if err := c.dao.Trend().ReplaceStatuses(ctx, since, c.cfg.HalfLife, maxTrends); err != nil {
+		return err
+	}
+
+	c.logger.Debug("refreshed trends", zap.Int("tags", len(trends)))
+	return nil
+}
+
+// 普段から使われているハッシュタグより、急に使われ始めたものが上に来るようにする
+// baseline は過去の 1 日あたりのアカウント数で、普段と同じくらいならスコアは半分になる
+func rank(usages []*object.TagUsage, baselines map[object.TagID]int, minAccounts int) []*object.TagTrend {
+	trends := make([]*object.TagTrend, 0, len(usages))
+	for _, u := range usages {
+		if u.Accounts < minAccounts || u.Decayed <= 0 {
+			continue
+		}
+		baseline := float64(baselines[u.TagID]) / baselineDays
+		trends = append(trends, &object.TagTrend{
+			TagID:    u.TagID,
+			Name:     u.Name,
+			UsesHour: u.UsesHour,
+			UsesDay:  u.UsesDay,
+			Accounts: u.Accounts,
+			Score:    u.Decayed * u.Decayed / (u.Decayed + baseline),
+		})
+	}
+
+	sort.SliceStable(trends, func(i, j int) bool { return trends[i].Score > trends[j].Score })
+	if len(trends) > maxTrends {
+		trends = trends[:maxTrends]
+	}
+	return trends
+}
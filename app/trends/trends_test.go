@@ -0,0 +1,80 @@
+package trends
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"yatter-backend-go/app/config"
+	"yatter-backend-go/app/dao"
+	"yatter-backend-go/app/domain/object"
+	"yatter-backend-go/app/domain/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestRank(t *testing.T) {
+	usages := []*object.TagUsage{
+		{TagID: 1, Name: "usual", Accounts: 10, Decayed: 8},
+		{TagID: 2, Name: "rising", Accounts: 5, Decayed: 4},
+		{TagID: 3, Name: "lonely", Accounts: 1, Decayed: 1},
+	}
+	// usual は普段から 1 日 10 アカウントが使っている
+	baselines := map[object.TagID]int{1: 70}
+
+	trends := rank(usages, baselines, 2)
+	require.Len(t, trends, 2)
+	// 使ったアカウントは少なくても、普段使われていない rising が上に来る
+	assert.Equal(t, "rising", trends[0].Name)
+	assert.InDelta(t, 4.0, trends[0].Score, 1e-9)
+	assert.Equal(t, "usual", trends[1].Name)
+	assert.InDelta(t, 64.0/18, trends[1].Score, 1e-9)
+}
+
+func TestCalculator_Refresh(t *testing.T) {
+	d := &stubDao{usages: []*object.TagUsage{
+		{TagID: 1, Name: "go", Accounts: 3, Decayed: 2},
+		{TagID: 2, Name: "once", Accounts: 1, Decayed: 1},
+	}}
+	c := New(d, config.Trends{HalfLife: 6 * time.Hour, MinAccounts: 2}, zap.NewNop())
+
+	require.NoError(t, c.Refresh(context.Background()))
+	require.Len(t, d.replaced, 1)
+	assert.Equal(t, "go", d.replaced[0].Name)
+	assert.True(t, d.statusesReplaced)
+}
+
+// 必要なメソッドだけを実装したスタブ。それ以外を呼ぶと nil の埋め込みで panic する
+type stubDao struct {
+	dao.Dao
+	usages           []*object.TagUsage
+	replaced         []*object.TagTrend
+	statusesReplaced bool
+}
+
+func (d *stubDao) Trend() repository.Trend { return &stubTrend{d: d} }
+
+type stubTrend struct {
+	repository.Trend
+	d *stubDao
+}
+
+func (s *stubTrend) TagUsages(context.Context, time.Time, time.Duration) ([]*object.TagUsage, error) {
+	return s.d.usages, nil
+}
+
+func (s *stubTrend) TagBaselines(context.Context, time.Time, time.Time) (map[object.TagID]int, error) {
+	return map[object.TagID]int{}, nil
+}
+
+func (s *stubTrend) ReplaceTags(_ context.Context, trends []*object.TagTrend) error {
+	s.d.replaced = trends
+	return nil
+}
+
+func (s *stubTrend) ReplaceStatuses(context.Context, time.Time, time.Duration, int64) error {
+	s.d.statusesReplaced = true
+	return nil
+}
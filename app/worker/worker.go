@@ -0,0 +1,99 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Manager of background jobs
+//
+// サーバー停止時に Stop を呼ぶと、すべてのジョブの context がキャンセルされ、
+// 終了するまで待つ。
+type Runner struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	logger *zap.Logger
+}
+
+// Create runner
+func New(logger *zap.Logger) *Runner {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Runner{ctx: ctx, cancel: cancel, logger: logger}
+}
+
+// Run fn in background
+//
+// fn は ctx がキャンセルされたら速やかに return しなければならない
+func (r *Runner) Go(name string, fn func(ctx context.Context) error) {
+	l := r.logger.With(zap.String("job", name))
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		defer func() {
+			if p := recover(); p != nil {
+				l.Error("job panicked", zap.Any("panic", p), zap.Stack("stack"))
+			}
+		}()
+
+		if err := fn(r.ctx); err != nil && r.ctx.Err() == nil {
+			l.Error("job failed", zap.Error(err))
+		}
+	}()
+}
+
+// Run fn every interval until the runner is stopped
+//
+// 1 回の実行でパニックしても、次の周期からは続けて実行する
+func (r *Runner) Every(name string, interval time.Duration, fn func(ctx context.Context) error) {
+	l := r.logger.With(zap.String("job", name))
+	r.Go(name, func(ctx context.Context) error {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+				r.tick(ctx, l, fn)
+			}
+		}
+	})
+}
+
+// 周期ジョブを 1 回実行する。パニックはここで回収してループを止めない
+func (r *Runner) tick(ctx context.Context, l *zap.Logger, fn func(ctx context.Context) error) {
+	defer func() {
+		if p := recover(); p != nil {
+			l.Error("job panicked", zap.Any("panic", p), zap.Stack("stack"))
+		}
+	}()
+
+	if err := fn(ctx); err != nil && ctx.Err() == nil {
+		l.Error("job failed", zap.Error(err))
+	}
+}
+
+// Cancel all jobs and wait for them to return
+//
+// ctx が先に終了した場合は ctx.Err() を返す
+func (r *Runner) Stop(ctx context.Context) error {
+	r.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		r.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,75 @@
+package worker
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestRunner_Stop(t *testing.T) {
+	t.Run("waits for jobs", func(t *testing.T) {
+		r := New(zap.NewNop())
+
+		var finished int32
+		r.Go("job", func(ctx context.Context) error {
+			<-ctx.Done()
+			atomic.StoreInt32(&finished, 1)
+			return nil
+		})
+		r.Every("periodic", time.Millisecond, func(ctx context.Context) error {
+			return nil
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		assert.NoError(t, r.Stop(ctx))
+		assert.Equal(t, int32(1), atomic.LoadInt32(&finished))
+	})
+
+	t.Run("gives up after timeout", func(t *testing.T) {
+		r := New(zap.NewNop())
+
+		release := make(chan struct{})
+		defer close(release)
+		r.Go("stuck", func(ctx context.Context) error {
+			<-release
+			return nil
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		assert.ErrorIs(t, r.Stop(ctx), context.DeadlineExceeded)
+	})
+}
+
+func TestRunner_Every(t *testing.T) {
+	t.Run("keeps running after panic", func(t *testing.T) {
+		r := New(zap.NewNop())
+
+		var ticks int32
+		done := make(chan struct{})
+		r.Every("flaky", time.Millisecond, func(ctx context.Context) error {
+			switch atomic.AddInt32(&ticks, 1) {
+			case 1:
+				panic("boom")
+			case 3:
+				close(done)
+			}
+			return nil
+		})
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("job stopped after panic")
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		assert.NoError(t, r.Stop(ctx))
+	})
+}
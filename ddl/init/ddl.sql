@@ -6,16 +6,394 @@ CREATE TABLE `account` (
   `avatar` text,
   `header` text,
   `note` text,
+  `locked` boolean NOT NULL DEFAULT FALSE,
+  `expand_spoilers` boolean NOT NULL DEFAULT FALSE,
+  `expand_media` varchar(16) NOT NULL DEFAULT 'default',
+  `delete_scheduled_at` datetime,
+  `role` varchar(16) NOT NULL DEFAULT 'user',
+  `silenced_at` datetime,
+  `suspended_at` datetime,
   `create_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
-  PRIMARY KEY (`id`)
+  PRIMARY KEY (`id`),
+  INDEX `idx_delete_scheduled_at` (`delete_scheduled_at`),
+  INDEX `idx_display_name` (`display_name`),
+  FULLTEXT `idx_fulltext` (`username`, `display_name`) WITH PARSER ngram
 );
 
 CREATE TABLE `status` (
   `id` bigint(20) NOT NULL AUTO_INCREMENT,
   `account_id` bigint(20) NOT NULL,
   `content` text NOT NULL,
+  `visibility` varchar(16) NOT NULL DEFAULT 'public',
+  `spoiler_text` varchar(1024) NOT NULL DEFAULT '',
+  `sensitive` boolean NOT NULL DEFAULT FALSE,
+  `trendable` boolean,
+  `conversation_id` bigint(20),
   `create_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
   PRIMARY KEY (`id`),
   INDEX `idx_account_id` (`account_id`),
+  INDEX `idx_conversation_id` (`conversation_id`),
+  FULLTEXT `idx_fulltext_content` (`content`) WITH PARSER ngram,
   CONSTRAINT `fk_status_account_id` FOREIGN KEY (`account_id`) REFERENCES  `account` (`id`)
 );
+
+CREATE TABLE `tag` (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT,
+  `name` varchar(255) NOT NULL UNIQUE,
+  `trendable` boolean,
+  `create_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`)
+);
+
+CREATE TABLE `status_tag` (
+  `status_id` bigint(20) NOT NULL,
+  `tag_id` bigint(20) NOT NULL,
+  PRIMARY KEY (`status_id`, `tag_id`),
+  INDEX `idx_tag_id` (`tag_id`),
+  CONSTRAINT `fk_status_tag_status_id` FOREIGN KEY (`status_id`) REFERENCES `status` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `fk_status_tag_tag_id` FOREIGN KEY (`tag_id`) REFERENCES `tag` (`id`)
+);
+
+CREATE TABLE `block` (
+  `account_id` bigint(20) NOT NULL,
+  `target_account_id` bigint(20) NOT NULL,
+  `create_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`account_id`, `target_account_id`),
+  INDEX `idx_target_account_id` (`target_account_id`),
+  CONSTRAINT `fk_block_account_id` FOREIGN KEY (`account_id`) REFERENCES `account` (`id`),
+  CONSTRAINT `fk_block_target_account_id` FOREIGN KEY (`target_account_id`) REFERENCES `account` (`id`)
+);
+
+CREATE TABLE `mute` (
+  `account_id` bigint(20) NOT NULL,
+  `target_account_id` bigint(20) NOT NULL,
+  `hide_notifications` boolean NOT NULL DEFAULT TRUE,
+  `expires_at` datetime,
+  `create_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`account_id`, `target_account_id`),
+  INDEX `idx_target_account_id` (`target_account_id`),
+  INDEX `idx_expires_at` (`expires_at`),
+  CONSTRAINT `fk_mute_account_id` FOREIGN KEY (`account_id`) REFERENCES `account` (`id`),
+  CONSTRAINT `fk_mute_target_account_id` FOREIGN KEY (`target_account_id`) REFERENCES `account` (`id`)
+);
+
+CREATE TABLE `follow` (
+  `account_id` bigint(20) NOT NULL,
+  `target_account_id` bigint(20) NOT NULL,
+  `create_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`account_id`, `target_account_id`),
+  INDEX `idx_target_account_id` (`target_account_id`),
+  CONSTRAINT `fk_follow_account_id` FOREIGN KEY (`account_id`) REFERENCES `account` (`id`),
+  CONSTRAINT `fk_follow_target_account_id` FOREIGN KEY (`target_account_id`) REFERENCES `account` (`id`)
+);
+
+CREATE TABLE `follow_request` (
+  `account_id` bigint(20) NOT NULL,
+  `target_account_id` bigint(20) NOT NULL,
+  `create_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`account_id`, `target_account_id`),
+  INDEX `idx_target_account_id` (`target_account_id`),
+  CONSTRAINT `fk_follow_request_account_id` FOREIGN KEY (`account_id`) REFERENCES `account` (`id`),
+  CONSTRAINT `fk_follow_request_target_account_id` FOREIGN KEY (`target_account_id`) REFERENCES `account` (`id`)
+);
+
+CREATE TABLE `export` (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT,
+  `account_id` bigint(20) NOT NULL,
+  `state` varchar(16) NOT NULL DEFAULT 'pending',
+  `claimed_at` datetime,
+  `token` varchar(64) UNIQUE,
+  `path` text,
+  `expires_at` datetime,
+  `create_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  INDEX `idx_account_id` (`account_id`),
+  INDEX `idx_state` (`state`),
+  CONSTRAINT `fk_export_account_id` FOREIGN KEY (`account_id`) REFERENCES `account` (`id`)
+);
+
+CREATE TABLE `account_import` (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT,
+  `account_id` bigint(20) NOT NULL,
+  `type` varchar(16) NOT NULL,
+  `mode` varchar(16) NOT NULL,
+  `state` varchar(16) NOT NULL DEFAULT 'pending',
+  `data` mediumblob NOT NULL,
+  `total_rows` int NOT NULL DEFAULT 0,
+  `processed_rows` int NOT NULL DEFAULT 0,
+  `failed_rows` int NOT NULL DEFAULT 0,
+  `create_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  INDEX `idx_account_id` (`account_id`),
+  INDEX `idx_state` (`state`),
+  CONSTRAINT `fk_account_import_account_id` FOREIGN KEY (`account_id`) REFERENCES `account` (`id`)
+);
+
+CREATE TABLE `account_import_error` (
+  `import_id` bigint(20) NOT NULL,
+  `row` int NOT NULL,
+  `username` varchar(255) NOT NULL,
+  `reason` varchar(255) NOT NULL,
+  PRIMARY KEY (`import_id`, `row`),
+  CONSTRAINT `fk_account_import_error_import_id` FOREIGN KEY (`import_id`) REFERENCES `account_import` (`id`)
+);
+
+CREATE TABLE `trend_tag` (
+  `tag_id` bigint(20) NOT NULL,
+  `score` double NOT NULL,
+  `uses_hour` int NOT NULL,
+  `uses_day` int NOT NULL,
+  `accounts` int NOT NULL,
+  PRIMARY KEY (`tag_id`),
+  INDEX `idx_score` (`score`),
+  CONSTRAINT `fk_trend_tag_tag_id` FOREIGN KEY (`tag_id`) REFERENCES `tag` (`id`)
+);
+
+CREATE TABLE `trend_status` (
+  `status_id` bigint(20) NOT NULL,
+  `score` double NOT NULL,
+  PRIMARY KEY (`status_id`),
+  INDEX `idx_score` (`score`),
+  CONSTRAINT `fk_trend_status_status_id` FOREIGN KEY (`status_id`) REFERENCES `status` (`id`) ON DELETE CASCADE
+);
+
+CREATE TABLE `poll` (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT,
+  `status_id` bigint(20) NOT NULL UNIQUE,
+  `expires_at` datetime NOT NULL,
+  `multiple` boolean NOT NULL DEFAULT FALSE,
+  `hide_totals` boolean NOT NULL DEFAULT FALSE,
+  `votes_count` int NOT NULL DEFAULT 0,
+  `voters_count` int NOT NULL DEFAULT 0,
+  `closed_at` datetime,
+  `create_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  INDEX `idx_closed_at_expires_at` (`closed_at`, `expires_at`),
+  CONSTRAINT `fk_poll_status_id` FOREIGN KEY (`status_id`) REFERENCES `status` (`id`) ON DELETE CASCADE
+);
+
+CREATE TABLE `poll_option` (
+  `poll_id` bigint(20) NOT NULL,
+  `position` int NOT NULL,
+  `title` varchar(255) NOT NULL,
+  `votes_count` int NOT NULL DEFAULT 0,
+  PRIMARY KEY (`poll_id`, `position`),
+  CONSTRAINT `fk_poll_option_poll_id` FOREIGN KEY (`poll_id`) REFERENCES `poll` (`id`) ON DELETE CASCADE
+);
+
+CREATE TABLE `poll_vote` (
+  `poll_id` bigint(20) NOT NULL,
+  `account_id` bigint(20) NOT NULL,
+  `choice` int NOT NULL,
+  `create_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`poll_id`, `account_id`, `choice`),
+  INDEX `idx_account_id` (`account_id`),
+  CONSTRAINT `fk_poll_vote_poll_id` FOREIGN KEY (`poll_id`) REFERENCES `poll` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `fk_poll_vote_account_id` FOREIGN KEY (`account_id`) REFERENCES `account` (`id`)
+);
+
+CREATE TABLE `notification` (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT,
+  `account_id` bigint(20) NOT NULL,
+  `type` varchar(32) NOT NULL,
+  `from_account_id` bigint(20),
+  `status_id` bigint(20),
+  `create_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  INDEX `idx_account_id_id` (`account_id`, `id`),
+  CONSTRAINT `fk_notification_account_id` FOREIGN KEY (`account_id`) REFERENCES `account` (`id`),
+  CONSTRAINT `fk_notification_from_account_id` FOREIGN KEY (`from_account_id`) REFERENCES `account` (`id`),
+  CONSTRAINT `fk_notification_status_id` FOREIGN KEY (`status_id`) REFERENCES `status` (`id`) ON DELETE CASCADE
+);
+
+CREATE TABLE `scheduled_status` (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT,
+  `account_id` bigint(20) NOT NULL,
+  `scheduled_at` datetime NOT NULL,
+  `params` json NOT NULL,
+  `report_id` bigint(20),
+  `create_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  INDEX `idx_account_id` (`account_id`),
+  INDEX `idx_scheduled_at` (`scheduled_at`),
+  CONSTRAINT `fk_scheduled_status_account_id` FOREIGN KEY (`account_id`) REFERENCES `account` (`id`)
+);
+
+CREATE TABLE `bookmark` (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT,
+  `account_id` bigint(20) NOT NULL,
+  `status_id` bigint(20) NOT NULL,
+  `create_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE `idx_account_id_status_id` (`account_id`, `status_id`),
+  CONSTRAINT `fk_bookmark_account_id` FOREIGN KEY (`account_id`) REFERENCES `account` (`id`),
+  CONSTRAINT `fk_bookmark_status_id` FOREIGN KEY (`status_id`) REFERENCES `status` (`id`) ON DELETE CASCADE
+);
+
+CREATE TABLE `status_pin` (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT,
+  `account_id` bigint(20) NOT NULL,
+  `status_id` bigint(20) NOT NULL UNIQUE,
+  `create_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  INDEX `idx_account_id` (`account_id`),
+  CONSTRAINT `fk_status_pin_account_id` FOREIGN KEY (`account_id`) REFERENCES `account` (`id`),
+  CONSTRAINT `fk_status_pin_status_id` FOREIGN KEY (`status_id`) REFERENCES `status` (`id`) ON DELETE CASCADE
+);
+
+CREATE TABLE `list` (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT,
+  `account_id` bigint(20) NOT NULL,
+  `title` varchar(255) NOT NULL,
+  `create_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  INDEX `idx_account_id` (`account_id`),
+  CONSTRAINT `fk_list_account_id` FOREIGN KEY (`account_id`) REFERENCES `account` (`id`)
+);
+
+CREATE TABLE `list_account` (
+  `list_id` bigint(20) NOT NULL,
+  `account_id` bigint(20) NOT NULL,
+  `create_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`list_id`, `account_id`),
+  INDEX `idx_account_id` (`account_id`),
+  CONSTRAINT `fk_list_account_list_id` FOREIGN KEY (`list_id`) REFERENCES `list` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `fk_list_account_account_id` FOREIGN KEY (`account_id`) REFERENCES `account` (`id`)
+);
+
+CREATE TABLE `filter` (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT,
+  `account_id` bigint(20) NOT NULL,
+  `title` varchar(255) NOT NULL,
+  `context` json NOT NULL,
+  `filter_action` varchar(8) NOT NULL DEFAULT 'warn',
+  `expires_at` datetime,
+  `create_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  INDEX `idx_account_id` (`account_id`),
+  CONSTRAINT `fk_filter_account_id` FOREIGN KEY (`account_id`) REFERENCES `account` (`id`)
+);
+
+CREATE TABLE `filter_keyword` (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT,
+  `filter_id` bigint(20) NOT NULL,
+  `keyword` varchar(255) NOT NULL,
+  `whole_word` boolean NOT NULL DEFAULT TRUE,
+  PRIMARY KEY (`id`),
+  INDEX `idx_filter_id` (`filter_id`),
+  CONSTRAINT `fk_filter_keyword_filter_id` FOREIGN KEY (`filter_id`) REFERENCES `filter` (`id`) ON DELETE CASCADE
+);
+
+CREATE TABLE `status_mention` (
+  `status_id` bigint(20) NOT NULL,
+  `account_id` bigint(20) NOT NULL,
+  PRIMARY KEY (`status_id`, `account_id`),
+  INDEX `idx_account_id` (`account_id`),
+  CONSTRAINT `fk_status_mention_status_id` FOREIGN KEY (`status_id`) REFERENCES `status` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `fk_status_mention_account_id` FOREIGN KEY (`account_id`) REFERENCES `account` (`id`)
+);
+
+CREATE TABLE `conversation` (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT,
+  `participant_key` char(64) NOT NULL UNIQUE,
+  `create_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`)
+);
+
+CREATE TABLE `conversation_participant` (
+  `conversation_id` bigint(20) NOT NULL,
+  `account_id` bigint(20) NOT NULL,
+  PRIMARY KEY (`conversation_id`, `account_id`),
+  INDEX `idx_account_id` (`account_id`),
+  CONSTRAINT `fk_conversation_participant_conversation_id` FOREIGN KEY (`conversation_id`) REFERENCES `conversation` (`id`),
+  CONSTRAINT `fk_conversation_participant_account_id` FOREIGN KEY (`account_id`) REFERENCES `account` (`id`)
+);
+
+CREATE TABLE `conversation_account` (
+  `conversation_id` bigint(20) NOT NULL,
+  `account_id` bigint(20) NOT NULL,
+  `unread` boolean NOT NULL DEFAULT FALSE,
+  PRIMARY KEY (`conversation_id`, `account_id`),
+  INDEX `idx_account_id` (`account_id`),
+  CONSTRAINT `fk_conversation_account_conversation_id` FOREIGN KEY (`conversation_id`) REFERENCES `conversation` (`id`),
+  CONSTRAINT `fk_conversation_account_account_id` FOREIGN KEY (`account_id`) REFERENCES `account` (`id`)
+);
+
+-- コンテンツルールによる通報は account_id が NULL
+CREATE TABLE `report` (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT,
+  `account_id` bigint(20),
+  `target_account_id` bigint(20),
+  `category` varchar(16) NOT NULL DEFAULT 'other',
+  `comment` varchar(1000) NOT NULL DEFAULT '',
+  `rule_action` varchar(16),
+  `rule_ids` json,
+  `assigned_account_id` bigint(20),
+  `action_taken_by_account_id` bigint(20),
+  `action_taken_at` datetime,
+  `create_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  INDEX `idx_account_id` (`account_id`),
+  INDEX `idx_target_account_id` (`target_account_id`),
+  INDEX `idx_action_taken_at` (`action_taken_at`),
+  CONSTRAINT `fk_report_account_id` FOREIGN KEY (`account_id`) REFERENCES `account` (`id`),
+  CONSTRAINT `fk_report_target_account_id` FOREIGN KEY (`target_account_id`) REFERENCES `account` (`id`) ON DELETE SET NULL,
+  CONSTRAINT `fk_report_assigned_account_id` FOREIGN KEY (`assigned_account_id`) REFERENCES `account` (`id`) ON DELETE SET NULL,
+  CONSTRAINT `fk_report_action_taken_by_account_id` FOREIGN KEY (`action_taken_by_account_id`) REFERENCES `account` (`id`) ON DELETE SET NULL
+);
+
+-- scheduled_status は report より先に作るので、コンテンツルールの通報への参照は後から付ける
+ALTER TABLE `scheduled_status`
+  ADD CONSTRAINT `fk_scheduled_status_report_id` FOREIGN KEY (`report_id`) REFERENCES `report` (`id`) ON DELETE SET NULL;
+
+-- 通報後にステータスが削除されても、通報の内容として残す
+CREATE TABLE `report_status` (
+  `report_id` bigint(20) NOT NULL,
+  `status_id` bigint(20) NOT NULL,
+  PRIMARY KEY (`report_id`, `status_id`),
+  CONSTRAINT `fk_report_status_report_id` FOREIGN KEY (`report_id`) REFERENCES `report` (`id`) ON DELETE CASCADE
+);
+
+-- 監査のため、操作したアカウントが削除されても残す
+CREATE TABLE `moderation_log` (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT,
+  `account_id` bigint(20) NOT NULL,
+  `action` varchar(32) NOT NULL,
+  `target_type` varchar(16) NOT NULL,
+  `target_id` varchar(255) NOT NULL,
+  `create_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  INDEX `idx_account_id` (`account_id`),
+  INDEX `idx_target` (`target_type`, `target_id`)
+);
+
+CREATE TABLE `account_ip` (
+  `account_id` bigint(20) NOT NULL,
+  `ip` varchar(45) NOT NULL,
+  `first_used_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `last_used_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`account_id`, `ip`),
+  INDEX `idx_ip` (`ip`),
+  CONSTRAINT `fk_account_ip_account_id` FOREIGN KEY (`account_id`) REFERENCES `account` (`id`)
+);
+
+CREATE TABLE `account_warning` (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT,
+  `account_id` bigint(20) NOT NULL,
+  `action` varchar(16) NOT NULL,
+  `text` varchar(1000) NOT NULL DEFAULT '',
+  `create_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  INDEX `idx_account_id` (`account_id`),
+  CONSTRAINT `fk_account_warning_account_id` FOREIGN KEY (`account_id`) REFERENCES `account` (`id`)
+);
+
+CREATE TABLE `content_rule` (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT,
+  `type` varchar(16) NOT NULL,
+  `value` varchar(255) NOT NULL,
+  `action` varchar(16) NOT NULL,
+  `max_account_age` bigint(20) NOT NULL DEFAULT 0,
+  `create_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`)
+);
@@ -3,25 +3,62 @@ package main
 import (
 	"context"
 	"log"
-	"net/http"
-	"strconv"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"yatter-backend-go/app/app"
 	"yatter-backend-go/app/config"
 	"yatter-backend-go/app/handler"
+	"yatter-backend-go/app/server"
+	"yatter-backend-go/app/tracing"
+
+	"go.uber.org/zap"
 )
 
 func main() {
-	log.Fatalf("%+v", serve(context.Background()))
+	cfg, err := config.Load(os.Args[1:])
+	if err != nil {
+		log.Fatalf("%+v", err)
+	}
+	if err := serve(context.Background(), cfg); err != nil {
+		log.Fatalf("%+v", err)
+	}
 }
 
-func serve(ctx context.Context) error {
-	app, err := app.NewApp()
+func serve(ctx context.Context, cfg *config.Config) error {
+	// SIGINT/SIGTERM を受け取ったら graceful shutdown する
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	shutdownTracing, err := tracing.Setup(ctx, cfg.Tracing)
 	if err != nil {
 		return err
 	}
-	addr := ":" + strconv.Itoa(config.Port())
-	log.Printf("Serve on http://%s", addr)
+	// アプリの終了処理中の span も送信できるように、最後に止める
+	defer func() {
+		flushCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+		defer cancel()
+		if err := shutdownTracing(flushCtx); err != nil {
+			log.Printf("Failed to shutdown tracing: %+v", err)
+		}
+	}()
+
+	app, err := app.NewApp(cfg)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		closeCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+		defer cancel()
+		if err := app.Close(closeCtx); err != nil {
+			log.Printf("Failed to close app: %+v", err)
+		}
+	}()
+
+	// logger を受け取らないライブラリのログも構造化して出力する
+	defer zap.ReplaceGlobals(app.Logger)()
+	defer zap.RedirectStdLog(app.Logger)()
 
-	return http.ListenAndServe(addr, handler.NewRouter(app))
+	return server.Run(ctx, cfg.Server, handler.NewRouter(app), app.Logger)
 }